@@ -1,9 +1,6 @@
 package client
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,6 +20,8 @@ type Client struct {
 	APISecret string
 	BaseURL   string
 	HTTPClient *http.Client
+	// Signer signs requests. When nil, requests are signed with HMAC SHA256 using APISecret.
+	Signer Signer
 }
 
 // NewClient creates a new Binance API client
@@ -37,11 +36,25 @@ func NewClient(apiKey, apiSecret string) *Client {
 	}
 }
 
-// sign creates a HMAC SHA256 signature
-func (c *Client) sign(queryString string) string {
-	h := hmac.New(sha256.New, []byte(c.APISecret))
-	h.Write([]byte(queryString))
-	return hex.EncodeToString(h.Sum(nil))
+// NewClientWithSigner creates a new Binance API client signing requests with signer,
+// e.g. an Ed25519Signer
+func NewClientWithSigner(apiKey string, signer Signer) *Client {
+	c := NewClient(apiKey, "")
+	c.Signer = signer
+	return c
+}
+
+// Sign signs payload with the client's signer
+func (c *Client) Sign(payload string) (string, error) {
+	if c.Signer != nil {
+		return c.Signer.Sign(payload)
+	}
+	return HMACSigner{Secret: c.APISecret}.Sign(payload)
+}
+
+// EncodeParams converts parameters to the URL query string that is signed
+func EncodeParams(params map[string]interface{}) string {
+	return buildQueryString(params)
 }
 
 // buildQueryString converts parameters to URL query string
@@ -85,8 +98,11 @@ func (c *Client) SignRequest(method, endpoint string, params map[string]interfac
 	queryString := buildQueryString(params)
 
 	// Sign the query string
-	signature := c.sign(queryString)
-	queryString += "&signature=" + signature
+	signature, err := c.Sign(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	queryString += "&signature=" + url.QueryEscape(signature)
 
 	// Build full URL
 	fullURL := c.BaseURL + endpoint + "?" + queryString
@@ -100,6 +116,42 @@ func (c *Client) SignRequest(method, endpoint string, params map[string]interfac
 	// Add headers
 	req.Header.Set("X-MBX-APIKEY", c.APIKey)
 
+	return c.do(req)
+}
+
+// Query performs an unsigned API request for public market data
+func (c *Client) Query(method, endpoint string, params map[string]interface{}) ([]byte, error) {
+	fullURL := c.BaseURL + endpoint
+	if queryString := buildQueryString(params); queryString != "" {
+		fullURL += "?" + queryString
+	}
+
+	req, err := http.NewRequest(method, fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return c.do(req)
+}
+
+// SendRequest performs an API request authenticated by API key only, without a signature
+func (c *Client) SendRequest(method, endpoint string, params map[string]interface{}) ([]byte, error) {
+	fullURL := c.BaseURL + endpoint
+	if queryString := buildQueryString(params); queryString != "" {
+		fullURL += "?" + queryString
+	}
+
+	req, err := http.NewRequest(method, fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-MBX-APIKEY", c.APIKey)
+
+	return c.do(req)
+}
+
+// do executes the request and returns the response body
+func (c *Client) do(req *http.Request) ([]byte, error) {
 	// Execute request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -115,7 +167,7 @@ func (c *Client) SignRequest(method, endpoint string, params map[string]interfac
 
 	// Check for errors
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, NewAPIError(resp.StatusCode, body)
 	}
 
 	return body, nil
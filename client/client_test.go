@@ -0,0 +1,78 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHMACSigner(t *testing.T) {
+	// Example from the Binance API documentation
+	signer := HMACSigner{Secret: "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"}
+	signature, err := signer.Sign("symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if expected := "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"; signature != expected {
+		t.Errorf("Expected signature %s, got %s", expected, signature)
+	}
+}
+
+func TestEd25519Signer(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewEd25519Signer(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("NewEd25519Signer() error = %v", err)
+	}
+	signature, err := signer.Sign("timestamp=1")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || !ed25519.Verify(publicKey, []byte("timestamp=1"), decoded) {
+		t.Errorf("Expected valid base64 Ed25519 signature, got %s", signature)
+	}
+
+	if _, err := NewEd25519Signer([]byte("not a key")); err == nil {
+		t.Error("Expected error for invalid PEM, got nil")
+	}
+}
+
+func TestSignRequestAPIError(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature := r.URL.Query().Get("signature")
+		if _, err := base64.StdEncoding.DecodeString(signature); err != nil {
+			t.Errorf("Expected base64 signature to survive query encoding, got %q", signature)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-1100,"msg":"Illegal characters found in parameter 'symbol'."}`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithSigner("test-api-key", &Ed25519Signer{PrivateKey: privateKey})
+	c.BaseURL = server.URL
+
+	_, err := c.SignRequest("GET", "/api/v3/account", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != 400 || apiErr.Code != -1100 || apiErr.Message != "Illegal characters found in parameter 'symbol'." {
+		t.Errorf("Unexpected API error: %+v", apiErr)
+	}
+}
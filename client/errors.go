@@ -0,0 +1,31 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// APIError is an error response returned by the Binance REST or WebSocket API
+//
+// Use errors.As to inspect the Binance error code, e.g. -2010 for a rejected new order.
+type APIError struct {
+	// StatusCode is the HTTP status, or the status field of a WebSocket API response
+	StatusCode int `json:"-"`
+	// Code is the Binance error code, 0 if the body was not a Binance error
+	Code int `json:"code"`
+	// Message is the Binance error message
+	Message string `json:"msg"`
+	// Body is the raw error response
+	Body string `json:"-"`
+}
+
+// NewAPIError creates an APIError from a status and a {"code":..,"msg":..} error body
+func NewAPIError(statusCode int, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Body: string(body)}
+	json.Unmarshal(body, e)
+	return e
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+)
+
+// Signer signs request payloads
+// The payload is the query string of the request parameters, including the timestamp.
+type Signer interface {
+	Sign(payload string) (string, error)
+}
+
+// HMACSigner signs payloads with HMAC SHA256 using an API secret key
+type HMACSigner struct {
+	Secret string
+}
+
+// Sign returns the hex encoded HMAC SHA256 signature of payload
+func (s HMACSigner) Sign(payload string) (string, error) {
+	h := hmac.New(sha256.New, []byte(s.Secret))
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Ed25519Signer signs payloads with an Ed25519 private key
+// Ed25519 keys are required for WebSocket API session logon.
+type Ed25519Signer struct {
+	PrivateKey ed25519.PrivateKey
+}
+
+// NewEd25519Signer creates a signer from a PEM encoded PKCS#8 Ed25519 private key,
+// as generated by the Binance key generator or openssl genpkey -algorithm ed25519
+func NewEd25519Signer(privateKeyPEM []byte) (*Ed25519Signer, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not Ed25519", key)
+	}
+	return &Ed25519Signer{PrivateKey: privateKey}, nil
+}
+
+// Sign returns the base64 encoded Ed25519 signature of payload
+func (s *Ed25519Signer) Sign(payload string) (string, error) {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.PrivateKey, []byte(payload))), nil
+}
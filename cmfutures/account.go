@@ -0,0 +1,262 @@
+package cmfutures
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Income types of IncomeHistory
+const (
+	IncomeTypeTransfer            = "TRANSFER"
+	IncomeTypeWelcomeBonus        = "WELCOME_BONUS"
+	IncomeTypeFundingFee          = "FUNDING_FEE"
+	IncomeTypeRealizedPnl         = "REALIZED_PNL"
+	IncomeTypeCommission          = "COMMISSION"
+	IncomeTypeInsuranceClear      = "INSURANCE_CLEAR"
+	IncomeTypeDeliveredSettlement = "DELIVERED_SETTELMENT" // spelled as sent by the API
+)
+
+// Account is the COIN-M futures account returned by Account
+// Every amount is denominated in the margin coin of its asset or position.
+type Account struct {
+	Assets      []AccountAsset    `json:"assets"`
+	Positions   []AccountPosition `json:"positions"`
+	CanDeposit  bool              `json:"canDeposit"`
+	CanTrade    bool              `json:"canTrade"`
+	CanWithdraw bool              `json:"canWithdraw"`
+	FeeTier     int               `json:"feeTier"`
+	UpdateTime  int64             `json:"updateTime"`
+}
+
+// AccountAsset is a margin coin of the account, e.g. BTC
+type AccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+// AccountPosition is a position of the account
+// PositionAmt and MaxQty are in contracts; margins and profit are in the margin coin.
+type AccountPosition struct {
+	Symbol                 string `json:"symbol"`
+	PositionAmt            string `json:"positionAmt"`
+	InitialMargin          string `json:"initialMargin"`
+	MaintMargin            string `json:"maintMargin"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	Leverage               string `json:"leverage"`
+	Isolated               bool   `json:"isolated"`
+	PositionSide           string `json:"positionSide"`
+	EntryPrice             string `json:"entryPrice"`
+	BreakEvenPrice         string `json:"breakEvenPrice"`
+	MaxQty                 string `json:"maxQty"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+// Balance is a margin coin balance returned by Balance
+type Balance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	WithdrawAvailable  string `json:"withdrawAvailable"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	UpdateTime         int64  `json:"updateTime"`
+}
+
+// PositionRisk is a position returned by PositionRisk
+// PositionAmt and MaxQty are in contracts; NotionalValue, margins and profit are in the margin coin.
+type PositionRisk struct {
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	EntryPrice       string `json:"entryPrice"`
+	BreakEvenPrice   string `json:"breakEvenPrice"`
+	MarkPrice        string `json:"markPrice"`
+	UnRealizedProfit string `json:"unRealizedProfit"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	Leverage         string `json:"leverage"`
+	MaxQty           string `json:"maxQty"`
+	MarginType       string `json:"marginType"`
+	IsolatedMargin   string `json:"isolatedMargin"`
+	IsAutoAddMargin  string `json:"isAutoAddMargin"`
+	PositionSide     string `json:"positionSide"`
+	NotionalValue    string `json:"notionalValue"`
+	IsolatedWallet   string `json:"isolatedWallet"`
+	UpdateTime       int64  `json:"updateTime"`
+}
+
+// Leverage is the response of ChangeLeverage
+// MaxQty is the largest position, in contracts, allowed at this leverage.
+type Leverage struct {
+	Symbol   string `json:"symbol"`
+	Leverage int    `json:"leverage"`
+	MaxQty   string `json:"maxQty"`
+}
+
+// Income is an income history record, denominated in Asset
+type Income struct {
+	Symbol     string `json:"symbol"`
+	IncomeType string `json:"incomeType"`
+	Income     string `json:"income"`
+	Asset      string `json:"asset"`
+	Info       string `json:"info"`
+	Time       int64  `json:"time"`
+	TranID     int64  `json:"tranId"`
+	TradeID    string `json:"tradeId"`
+}
+
+// Account queries current account information (USER_DATA)
+// Response can be decoded into Account with client.ParseResponse.
+//
+// Weight(IP): 5
+//
+// GET /dapi/v1/account
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Account-Information
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) Account(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/dapi/v1/account", params)
+}
+
+// Balance queries futures account balances (USER_DATA)
+// Response can be decoded into []Balance with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /dapi/v1/balance
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Futures-Account-Balance
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) Balance(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/dapi/v1/balance", params)
+}
+
+// PositionRisk queries current position information (USER_DATA)
+// Response can be decoded into []PositionRisk with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /dapi/v1/positionRisk
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Position-Information
+//
+// Optional parameters:
+//   - marginAsset: Margin coin, e.g. BTC
+//   - pair: Underlying pair, e.g. BTCUSD
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) PositionRisk(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/dapi/v1/positionRisk", params)
+}
+
+// ChangeLeverage changes the initial leverage of a symbol (TRADE)
+// Response can be decoded into Leverage with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// POST /dapi/v1/leverage
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Change-Initial-Leverage
+//
+// Parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - leverage: Target initial leverage, 1 to 125
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) ChangeLeverage(symbol string, leverage int, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol":   symbol,
+		"leverage": leverage,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	params["leverage"] = leverage
+
+	return f.SignRequest("POST", "/dapi/v1/leverage", params)
+}
+
+// ChangeMarginType changes the margin type of a symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /dapi/v1/marginType
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Change-Margin-Type
+//
+// Parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - marginType: MarginTypeIsolated or MarginTypeCrossed
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) ChangeMarginType(symbol, marginType string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol":     symbol,
+		"marginType": marginType,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	params["marginType"] = marginType
+
+	return f.SignRequest("POST", "/dapi/v1/marginType", params)
+}
+
+// IncomeHistory queries income history (USER_DATA)
+// Response can be decoded into []Income with client.ParseResponse.
+//
+// Weight(IP): 20
+//
+// GET /dapi/v1/income
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Get-Income-History
+//
+// If neither startTime nor endTime is sent, the last 7 days are returned.
+//
+// Optional parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - incomeType: One of the IncomeType constants
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - page: Page number
+//   - limit: Default 100, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) IncomeHistory(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/dapi/v1/income", params)
+}
@@ -0,0 +1,26 @@
+package cmfutures
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+// BaseURL is the base URL of the COIN-M futures API
+const BaseURL = "https://dapi.binance.com"
+
+// CMFuturesClient handles COIN-M futures API endpoints on dapi.binance.com
+//
+// COIN-M contracts are inverse: quantities are whole contracts of a fixed USD
+// size (ContractInfo.ContractSize) and margins, balances and PnL are
+// denominated in the margin coin, e.g. BTC for BTCUSD_PERP.
+type CMFuturesClient struct {
+	*client.Client
+}
+
+// NewCMFuturesClient creates a new CMFuturesClient
+func NewCMFuturesClient(apiKey, apiSecret string) *CMFuturesClient {
+	c := client.NewClient(apiKey, apiSecret)
+	c.BaseURL = BaseURL
+	return &CMFuturesClient{
+		Client: c,
+	}
+}
@@ -0,0 +1,172 @@
+package cmfutures
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+func TestCMFuturesClientMethods(t *testing.T) {
+	client := NewCMFuturesClient("test_key", "test_secret")
+
+	if client == nil {
+		t.Fatal("Expected non-nil client")
+	}
+
+	if client.Client == nil {
+		t.Error("Expected embedded Client to be non-nil")
+	}
+
+	if client.BaseURL != BaseURL {
+		t.Errorf("Expected BaseURL %s, got %s", BaseURL, client.BaseURL)
+	}
+
+	t.Log("CMFuturesClient structure verification passed")
+}
+
+func TestCMFuturesRequiresParameters(t *testing.T) {
+	futures := NewCMFuturesClient("test_key", "test_secret")
+
+	if _, err := futures.ChangeLeverage("", 10, nil); err == nil {
+		t.Error("Expected error for empty symbol, got nil")
+	}
+	if _, err := futures.CancelOrder("BTCUSD_PERP", nil); err == nil {
+		t.Error("Expected error without orderId or origClientOrderId, got nil")
+	}
+	if _, err := futures.GetOrders(nil); err == nil {
+		t.Error("Expected error without symbol or pair, got nil")
+	}
+	if _, err := futures.UserTrades(map[string]interface{}{}); err == nil {
+		t.Error("Expected error without symbol or pair, got nil")
+	}
+	if _, err := futures.Basis("BTCUSD", "", "1h", nil); err == nil {
+		t.Error("Expected error for empty contract type, got nil")
+	}
+	if _, err := futures.NewOrder(OrderRequest{Symbol: "BTCUSD_PERP", Side: SideBuy, Type: OrderTypeMarket}, nil); err == nil {
+		t.Error("Expected error for invalid order, got nil")
+	}
+}
+
+func TestCMFuturesSignedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("signature") == "" || r.Header.Get("X-MBX-APIKEY") != "test_key" {
+			t.Errorf("Expected signed request, got %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/dapi/v1/leverage":
+			if query.Get("leverage") != "20" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":-4028,"msg":"Leverage 0 is not valid"}`))
+				return
+			}
+			w.Write([]byte(`{"leverage":20,"maxQty":"1000","symbol":"BTCUSD_PERP"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	futures := NewCMFuturesClient("test_key", "test_secret")
+	futures.BaseURL = server.URL
+
+	response, err := futures.ChangeLeverage("BTCUSD_PERP", 20, nil)
+	if err != nil {
+		t.Fatalf("ChangeLeverage() error = %v", err)
+	}
+	var leverage Leverage
+	if err := client.ParseResponse(response, &leverage); err != nil || leverage.Leverage != 20 || leverage.MaxQty != "1000" {
+		t.Errorf("Unexpected leverage %+v, error %v", leverage, err)
+	}
+
+	_, err = futures.ChangeLeverage("BTCUSD_PERP", 0, nil)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != -4028 {
+		t.Errorf("Expected APIError with code -4028, got %v", err)
+	}
+}
+
+func TestCMFuturesContract(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dapi/v1/exchangeInfo" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"symbols":[{"symbol":"BTCUSD_PERP","contractType":"PERPETUAL","contractSize":100},{"symbol":"ETHUSD_PERP","contractType":"PERPETUAL","contractSize":10}]}`))
+	}))
+	defer server.Close()
+
+	futures := NewCMFuturesClient("test_key", "test_secret")
+	futures.BaseURL = server.URL
+
+	contract, err := futures.Contract("ETHUSD_PERP")
+	if err != nil {
+		t.Fatalf("Contract() error = %v", err)
+	}
+	if contract.ContractSize != 10 || !contract.IsPerpetual() {
+		t.Errorf("Unexpected contract %+v", contract)
+	}
+	if _, err := futures.Contract("XRPUSD_PERP"); err == nil {
+		t.Error("Expected error for unknown contract, got nil")
+	}
+}
+
+func TestAccount(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	futures := NewCMFuturesClient(apiKey, apiSecret)
+
+	response, err := futures.Account(nil)
+	if err != nil {
+		t.Fatalf("Account() error = %v", err)
+	}
+
+	var account Account
+	if err := client.ParseResponse(response, &account); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	prettyJSON, _ := json.MarshalIndent(account, "", "  ")
+	t.Logf("COIN-M Futures Account Response:\n%s", string(prettyJSON))
+}
+
+func TestPremiumIndex(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	futures := NewCMFuturesClient(apiKey, apiSecret)
+
+	response, err := futures.PremiumIndex(map[string]interface{}{"symbol": "BTCUSD_PERP"})
+	if err != nil {
+		t.Fatalf("PremiumIndex() error = %v", err)
+	}
+
+	var indexes []PremiumIndex
+	if err := client.ParseResponse(response, &indexes); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	for _, index := range indexes {
+		t.Logf("%s mark price %s, funding rate %s", index.Symbol, index.MarkPrice, index.LastFundingRate)
+	}
+}
@@ -0,0 +1,265 @@
+package cmfutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Contract types of ContractInfo, Basis and ContinuousKlines
+const (
+	ContractTypePerpetual      = "PERPETUAL"
+	ContractTypeCurrentQuarter = "CURRENT_QUARTER"
+	ContractTypeNextQuarter    = "NEXT_QUARTER"
+)
+
+// ExchangeInfo is the response of ExchangeInfo
+type ExchangeInfo struct {
+	Timezone   string         `json:"timezone"`
+	ServerTime int64          `json:"serverTime"`
+	Symbols    []ContractInfo `json:"symbols"`
+}
+
+// ContractInfo describes a perpetual or delivery contract
+//
+// Each contract is worth ContractSize units of the quote asset (USD), while
+// margin is posted in MarginAsset, e.g. a BTCUSD_PERP contract is 100 USD
+// margined in BTC.
+type ContractInfo struct {
+	Symbol                string            `json:"symbol"`
+	Pair                  string            `json:"pair"`
+	ContractType          string            `json:"contractType"`
+	DeliveryDate          int64             `json:"deliveryDate"`
+	OnboardDate           int64             `json:"onboardDate"`
+	ContractStatus        string            `json:"contractStatus"`
+	ContractSize          int64             `json:"contractSize"`
+	MarginAsset           string            `json:"marginAsset"`
+	MaintMarginPercent    string            `json:"maintMarginPercent"`
+	RequiredMarginPercent string            `json:"requiredMarginPercent"`
+	BaseAsset             string            `json:"baseAsset"`
+	QuoteAsset            string            `json:"quoteAsset"`
+	PricePrecision        int               `json:"pricePrecision"`
+	QuantityPrecision     int               `json:"quantityPrecision"`
+	BaseAssetPrecision    int               `json:"baseAssetPrecision"`
+	QuotePrecision        int               `json:"quotePrecision"`
+	TriggerProtect        string            `json:"triggerProtect"`
+	LiquidationFee        string            `json:"liquidationFee"`
+	MarketTakeBound       string            `json:"marketTakeBound"`
+	Filters               []json.RawMessage `json:"filters"`
+}
+
+// IsPerpetual reports whether the contract has no delivery date
+func (c ContractInfo) IsPerpetual() bool {
+	return c.ContractType == ContractTypePerpetual
+}
+
+// DeliveryTime returns the delivery time of a quarterly contract
+func (c ContractInfo) DeliveryTime() time.Time {
+	return time.UnixMilli(c.DeliveryDate)
+}
+
+// CoinValue returns the value in the margin coin of contracts at price
+func (c ContractInfo) CoinValue(contracts int64, price float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	return float64(contracts*c.ContractSize) / price
+}
+
+// Contracts returns the whole number of contracts worth at most coinAmount of the margin coin at price
+func (c ContractInfo) Contracts(coinAmount, price float64) int64 {
+	if c.ContractSize <= 0 {
+		return 0
+	}
+	return int64(math.Floor(coinAmount * price / float64(c.ContractSize)))
+}
+
+// PremiumIndex is the mark price and funding rate of a contract
+// Funding fields are empty for delivery contracts.
+type PremiumIndex struct {
+	Symbol               string `json:"symbol"`
+	Pair                 string `json:"pair"`
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"`
+	LastFundingRate      string `json:"lastFundingRate"`
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      int64  `json:"nextFundingTime"`
+	Time                 int64  `json:"time"`
+}
+
+// FundingRate is a funding rate history record of a perpetual contract
+type FundingRate struct {
+	Symbol      string `json:"symbol"`
+	FundingTime int64  `json:"fundingTime"`
+	FundingRate string `json:"fundingRate"`
+}
+
+// Basis is the basis of a contract type against the index price
+type Basis struct {
+	Pair                string `json:"pair"`
+	ContractType        string `json:"contractType"`
+	IndexPrice          string `json:"indexPrice"`
+	FuturesPrice        string `json:"futuresPrice"`
+	Basis               string `json:"basis"`
+	BasisRate           string `json:"basisRate"`
+	AnnualizedBasisRate string `json:"annualizedBasisRate"`
+	Timestamp           int64  `json:"timestamp"`
+}
+
+// ExchangeInfo queries contract specifications and trading rules (MARKET_DATA)
+// Response can be decoded into ExchangeInfo with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /dapi/v1/exchangeInfo
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Exchange-Information
+func (f *CMFuturesClient) ExchangeInfo() ([]byte, error) {
+	return f.Query("GET", "/dapi/v1/exchangeInfo", nil)
+}
+
+// Contract fetches the specification of one contract from ExchangeInfo
+func (f *CMFuturesClient) Contract(symbol string) (*ContractInfo, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	response, err := f.ExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	var info ExchangeInfo
+	if err := json.Unmarshal(response, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange info: %w", err)
+	}
+	for i := range info.Symbols {
+		if info.Symbols[i].Symbol == symbol {
+			return &info.Symbols[i], nil
+		}
+	}
+	return nil, fmt.Errorf("contract %s not found", symbol)
+}
+
+// PremiumIndex queries mark and index prices, and funding rates of perpetuals (MARKET_DATA)
+// Response can be decoded into []PremiumIndex with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /dapi/v1/premiumIndex
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Index-Price-and-Mark-Price
+//
+// Optional parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - pair: Underlying pair, e.g. BTCUSD
+func (f *CMFuturesClient) PremiumIndex(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.Query("GET", "/dapi/v1/premiumIndex", params)
+}
+
+// FundingRateHistory queries funding rate history of a perpetual contract (MARKET_DATA)
+// Response can be decoded into []FundingRate with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /dapi/v1/fundingRate
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Get-Funding-Rate-History-of-Perpetual-Futures
+//
+// Parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//
+// Optional parameters:
+//   - startTime: Start time in milliseconds, inclusive
+//   - endTime: End time in milliseconds, inclusive
+//   - limit: Default 100, max 1000
+func (f *CMFuturesClient) FundingRateHistory(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return f.Query("GET", "/dapi/v1/fundingRate", params)
+}
+
+// Basis queries the basis of a contract type against the index price (MARKET_DATA)
+// Response can be decoded into []Basis with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /futures/data/basis
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Basis
+//
+// Parameters:
+//   - pair: Underlying pair, e.g. BTCUSD
+//   - contractType: ContractTypePerpetual, ContractTypeCurrentQuarter or ContractTypeNextQuarter
+//   - period: "5m", "15m", "30m", "1h", "2h", "4h", "6h", "12h" or "1d"
+//
+// Optional parameters:
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - limit: Default 30, max 500
+func (f *CMFuturesClient) Basis(pair, contractType, period string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"pair":         pair,
+		"contractType": contractType,
+		"period":       period,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["pair"] = pair
+	params["contractType"] = contractType
+	params["period"] = period
+
+	return f.Query("GET", "/futures/data/basis", params)
+}
+
+// ContinuousKlines queries klines of a contract type, e.g. the rolling current quarter (MARKET_DATA)
+// Each kline is an array of [open time, open, high, low, close, volume in contracts,
+// close time, base asset volume, trade count, taker buy volume, taker buy base asset volume, ignore].
+//
+// Weight(IP): 1 to 10 depending on limit
+//
+// GET /dapi/v1/continuousKlines
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Continuous-Contract-Kline-Candlestick-Data
+//
+// Parameters:
+//   - pair: Underlying pair, e.g. BTCUSD
+//   - contractType: ContractTypePerpetual, ContractTypeCurrentQuarter or ContractTypeNextQuarter
+//   - interval: Kline interval, e.g. "1m", "1h", "1d"
+//
+// Optional parameters:
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - limit: Default 500, max 1500
+func (f *CMFuturesClient) ContinuousKlines(pair, contractType, interval string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"pair":         pair,
+		"contractType": contractType,
+		"interval":     interval,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["pair"] = pair
+	params["contractType"] = contractType
+	params["interval"] = interval
+
+	return f.Query("GET", "/dapi/v1/continuousKlines", params)
+}
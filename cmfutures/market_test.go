@@ -0,0 +1,76 @@
+package cmfutures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestContractInfoSizing(t *testing.T) {
+	perpetual := ContractInfo{Symbol: "BTCUSD_PERP", ContractType: ContractTypePerpetual, ContractSize: 100}
+	altcoin := ContractInfo{Symbol: "ETHUSD_PERP", ContractType: ContractTypePerpetual, ContractSize: 10}
+
+	tests := []struct {
+		name          string
+		contract      ContractInfo
+		contracts     int64
+		coinAmount    float64
+		price         float64
+		wantValue     float64
+		wantContracts int64
+	}{
+		{"one BTC at 50000", perpetual, 500, 1, 50000, 1, 500},
+		{"rounds down partial contracts", perpetual, 2, 0.005, 50000, 0.004, 2},
+		{"ETH contract size", altcoin, 40, 0.2, 2000, 0.2, 40},
+		{"zero price", perpetual, 1, 1, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.contract.CoinValue(tt.contracts, tt.price); got != tt.wantValue {
+				t.Errorf("CoinValue() = %v, want %v", got, tt.wantValue)
+			}
+			if got := tt.contract.Contracts(tt.coinAmount, tt.price); got != tt.wantContracts {
+				t.Errorf("Contracts() = %v, want %v", got, tt.wantContracts)
+			}
+		})
+	}
+}
+
+func TestExchangeInfoDecode(t *testing.T) {
+	data := []byte(`{
+		"timezone": "UTC",
+		"serverTime": 1719475200000,
+		"symbols": [{
+			"symbol": "BTCUSD_240628",
+			"pair": "BTCUSD",
+			"contractType": "CURRENT_QUARTER",
+			"deliveryDate": 1719561600000,
+			"onboardDate": 1703836800000,
+			"contractStatus": "TRADING",
+			"contractSize": 100,
+			"marginAsset": "BTC",
+			"baseAsset": "BTC",
+			"quoteAsset": "USD",
+			"pricePrecision": 1,
+			"quantityPrecision": 0,
+			"filters": [{"filterType": "PRICE_FILTER", "tickSize": "0.1"}]
+		}]
+	}`)
+
+	var info ExchangeInfo
+	if err := client.ParseResponse(data, &info); err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+	if len(info.Symbols) != 1 {
+		t.Fatalf("Expected 1 contract, got %d", len(info.Symbols))
+	}
+	contract := info.Symbols[0]
+	if contract.IsPerpetual() || contract.ContractSize != 100 || contract.MarginAsset != "BTC" || len(contract.Filters) != 1 {
+		t.Errorf("Unexpected contract: %+v", contract)
+	}
+	if want := time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC); !contract.DeliveryTime().Equal(want) {
+		t.Errorf("Expected delivery at %v, got %v", want, contract.DeliveryTime().UTC())
+	}
+}
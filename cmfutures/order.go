@@ -0,0 +1,210 @@
+package cmfutures
+
+import (
+	"fmt"
+
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// OrderSide is the side of an order
+type OrderSide string
+
+const (
+	SideBuy  OrderSide = "BUY"
+	SideSell OrderSide = "SELL"
+)
+
+// PositionSide is the position an order applies to
+// BOTH is used in one-way mode, LONG and SHORT in hedge mode.
+type PositionSide string
+
+const (
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+// OrderType is the type of a futures order
+type OrderType string
+
+const (
+	OrderTypeLimit              OrderType = "LIMIT"
+	OrderTypeMarket             OrderType = "MARKET"
+	OrderTypeStop               OrderType = "STOP"
+	OrderTypeStopMarket         OrderType = "STOP_MARKET"
+	OrderTypeTakeProfit         OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitMarket   OrderType = "TAKE_PROFIT_MARKET"
+	OrderTypeTrailingStopMarket OrderType = "TRAILING_STOP_MARKET"
+)
+
+// TimeInForce defines how long an order remains active
+type TimeInForce string
+
+const (
+	TimeInForceGTC TimeInForce = "GTC"
+	TimeInForceIOC TimeInForce = "IOC"
+	TimeInForceFOK TimeInForce = "FOK"
+	// TimeInForceGTX is post only
+	TimeInForceGTX TimeInForce = "GTX"
+)
+
+// Working types: the price that triggers stop and take profit orders
+const (
+	WorkingTypeMarkPrice     = "MARK_PRICE"
+	WorkingTypeContractPrice = "CONTRACT_PRICE"
+)
+
+// Margin types of ChangeMarginType
+const (
+	MarginTypeIsolated = "ISOLATED"
+	MarginTypeCrossed  = "CROSSED"
+)
+
+// OrderRequest describes a new COIN-M futures order
+//
+// Quantity is a number of contracts; use ContractInfo.Contracts to size an
+// order from a coin amount. Zero values are treated as "not set". Which
+// fields are required depends on the order type:
+//   - LIMIT: TimeInForce, Quantity, Price or PriceMatch
+//   - MARKET: Quantity
+//   - STOP / TAKE_PROFIT: Quantity, Price or PriceMatch, StopPrice
+//   - STOP_MARKET / TAKE_PROFIT_MARKET: StopPrice, and Quantity unless ClosePosition is set
+//   - TRAILING_STOP_MARKET: Quantity, CallbackRate
+type OrderRequest struct {
+	Symbol                  string
+	Side                    OrderSide
+	PositionSide            PositionSide
+	Type                    OrderType
+	TimeInForce             TimeInForce
+	Quantity                int64
+	Price                   float64
+	ReduceOnly              bool
+	NewClientOrderID        string
+	StopPrice               float64
+	ClosePosition           bool
+	ActivationPrice         float64
+	CallbackRate            float64
+	WorkingType             string
+	PriceProtect            bool
+	NewOrderRespType        string
+	PriceMatch              string
+	SelfTradePreventionMode string
+}
+
+// Validate checks that the fields required by the order type are set
+func (o *OrderRequest) Validate() error {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol": o.Symbol,
+		"side":   string(o.Side),
+		"type":   string(o.Type),
+	}); err != nil {
+		return err
+	}
+
+	switch o.Side {
+	case SideBuy, SideSell:
+	default:
+		return fmt.Errorf("invalid order side %s", o.Side)
+	}
+
+	if o.ClosePosition {
+		if o.Type != OrderTypeStopMarket && o.Type != OrderTypeTakeProfitMarket {
+			return fmt.Errorf("closePosition is only supported by STOP_MARKET and TAKE_PROFIT_MARKET orders")
+		}
+		if o.Quantity > 0 || o.ReduceOnly {
+			return fmt.Errorf("closePosition cannot be sent with quantity or reduceOnly")
+		}
+	}
+
+	switch o.Type {
+	case OrderTypeLimit:
+		if o.TimeInForce == "" {
+			return fmt.Errorf("%s order requires timeInForce", o.Type)
+		}
+		return o.require(true, o.PriceMatch == "", false)
+	case OrderTypeMarket:
+		return o.require(true, false, false)
+	case OrderTypeStop, OrderTypeTakeProfit:
+		return o.require(true, o.PriceMatch == "", true)
+	case OrderTypeStopMarket, OrderTypeTakeProfitMarket:
+		return o.require(!o.ClosePosition, false, true)
+	case OrderTypeTrailingStopMarket:
+		if o.CallbackRate <= 0 {
+			return fmt.Errorf("%s order requires callbackRate", o.Type)
+		}
+		return o.require(true, false, false)
+	default:
+		return fmt.Errorf("invalid order type %s", o.Type)
+	}
+}
+
+// require reports the first missing field among those requested
+func (o *OrderRequest) require(quantity, price, stopPrice bool) error {
+	if quantity && o.Quantity <= 0 {
+		return fmt.Errorf("%s order requires quantity", o.Type)
+	}
+	if price && o.Price <= 0 {
+		return fmt.Errorf("%s order requires price", o.Type)
+	}
+	if stopPrice && o.StopPrice <= 0 {
+		return fmt.Errorf("%s order requires stopPrice", o.Type)
+	}
+	return nil
+}
+
+// Params converts the order into request parameters, merged over params
+func (o *OrderRequest) Params(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = o.Symbol
+	params["side"] = string(o.Side)
+	params["type"] = string(o.Type)
+
+	if o.PositionSide != "" {
+		params["positionSide"] = string(o.PositionSide)
+	}
+	if o.TimeInForce != "" {
+		params["timeInForce"] = string(o.TimeInForce)
+	}
+	if o.Quantity > 0 {
+		params["quantity"] = o.Quantity
+	}
+	if o.Price > 0 {
+		params["price"] = o.Price
+	}
+	if o.ReduceOnly {
+		params["reduceOnly"] = "true"
+	}
+	if o.NewClientOrderID != "" {
+		params["newClientOrderId"] = o.NewClientOrderID
+	}
+	if o.StopPrice > 0 {
+		params["stopPrice"] = o.StopPrice
+	}
+	if o.ClosePosition {
+		params["closePosition"] = "true"
+	}
+	if o.ActivationPrice > 0 {
+		params["activationPrice"] = o.ActivationPrice
+	}
+	if o.CallbackRate > 0 {
+		params["callbackRate"] = o.CallbackRate
+	}
+	if o.WorkingType != "" {
+		params["workingType"] = o.WorkingType
+	}
+	if o.PriceProtect {
+		params["priceProtect"] = "TRUE"
+	}
+	if o.NewOrderRespType != "" {
+		params["newOrderRespType"] = o.NewOrderRespType
+	}
+	if o.PriceMatch != "" {
+		params["priceMatch"] = o.PriceMatch
+	}
+	if o.SelfTradePreventionMode != "" {
+		params["selfTradePreventionMode"] = o.SelfTradePreventionMode
+	}
+	return params
+}
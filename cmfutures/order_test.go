@@ -0,0 +1,66 @@
+package cmfutures
+
+import "testing"
+
+func TestOrderRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   OrderRequest
+		wantErr bool
+	}{
+		{"limit", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 1, Price: 60000}, false},
+		{"limit with price match", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 1, PriceMatch: "QUEUE"}, false},
+		{"limit without price", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 1}, true},
+		{"limit without timeInForce", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideBuy, Type: OrderTypeLimit, Quantity: 1, Price: 60000}, true},
+		{"market", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, PositionSide: PositionSideShort, Type: OrderTypeMarket, Quantity: 2}, false},
+		{"market without quantity", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeMarket}, true},
+		{"stop", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeStop, Quantity: 1, Price: 55000, StopPrice: 55500}, false},
+		{"stop without stopPrice", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeStop, Quantity: 1, Price: 55000}, true},
+		{"stop market closing position", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeStopMarket, StopPrice: 55000, ClosePosition: true}, false},
+		{"close position with quantity", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeTakeProfitMarket, StopPrice: 70000, Quantity: 1, ClosePosition: true}, true},
+		{"trailing stop", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeTrailingStopMarket, Quantity: 1, CallbackRate: 1}, false},
+		{"trailing stop without callbackRate", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideSell, Type: OrderTypeTrailingStopMarket, Quantity: 1}, true},
+		{"missing symbol", OrderRequest{Side: SideBuy, Type: OrderTypeMarket, Quantity: 1}, true},
+		{"invalid type", OrderRequest{Symbol: "BTCUSD_PERP", Side: SideBuy, Type: "STOP_LOSS", Quantity: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrderRequestParams(t *testing.T) {
+	order := OrderRequest{
+		Symbol:      "BTCUSD_240628",
+		Side:        SideBuy,
+		Type:        OrderTypeLimit,
+		TimeInForce: TimeInForceGTC,
+		Quantity:    3,
+		Price:       65000.5,
+		ReduceOnly:  true,
+	}
+	params := order.Params(nil)
+
+	expected := map[string]interface{}{
+		"symbol":      "BTCUSD_240628",
+		"side":        "BUY",
+		"type":        "LIMIT",
+		"timeInForce": "GTC",
+		"quantity":    int64(3),
+		"price":       65000.5,
+		"reduceOnly":  "true",
+	}
+	if len(params) != len(expected) {
+		t.Errorf("Expected %d params, got %v", len(expected), params)
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, params[key])
+		}
+	}
+}
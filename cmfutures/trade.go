@@ -0,0 +1,227 @@
+package cmfutures
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Order is a COIN-M futures order as returned by NewOrder, CancelOrder, GetOrder,
+// GetOpenOrders and GetOrders
+// Quantities are in contracts and CumBase is the filled value in the base coin.
+type Order struct {
+	Symbol                  string `json:"symbol"`
+	Pair                    string `json:"pair"`
+	OrderID                 int64  `json:"orderId"`
+	ClientOrderID           string `json:"clientOrderId"`
+	Price                   string `json:"price"`
+	AvgPrice                string `json:"avgPrice"`
+	OrigQty                 string `json:"origQty"`
+	ExecutedQty             string `json:"executedQty"`
+	CumQty                  string `json:"cumQty"`
+	CumBase                 string `json:"cumBase"`
+	Status                  string `json:"status"`
+	TimeInForce             string `json:"timeInForce"`
+	Type                    string `json:"type"`
+	OrigType                string `json:"origType"`
+	Side                    string `json:"side"`
+	PositionSide            string `json:"positionSide"`
+	ReduceOnly              bool   `json:"reduceOnly"`
+	ClosePosition           bool   `json:"closePosition"`
+	StopPrice               string `json:"stopPrice"`
+	ActivatePrice           string `json:"activatePrice"`
+	PriceRate               string `json:"priceRate"`
+	WorkingType             string `json:"workingType"`
+	PriceProtect            bool   `json:"priceProtect"`
+	PriceMatch              string `json:"priceMatch"`
+	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
+	Time                    int64  `json:"time"`
+	UpdateTime              int64  `json:"updateTime"`
+}
+
+// Trade is an account trade returned by UserTrades
+// Qty is in contracts; BaseQty, RealizedPnl and Commission are in coins.
+type Trade struct {
+	Symbol          string `json:"symbol"`
+	Pair            string `json:"pair"`
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	Side            string `json:"side"`
+	PositionSide    string `json:"positionSide"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	BaseQty         string `json:"baseQty"`
+	RealizedPnl     string `json:"realizedPnl"`
+	MarginAsset     string `json:"marginAsset"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Buyer           bool   `json:"buyer"`
+	Maker           bool   `json:"maker"`
+	Time            int64  `json:"time"`
+}
+
+// NewOrder sends in a new order (TRADE)
+// Response can be decoded into Order with client.ParseResponse.
+//
+// Weight: 1 on the order rate limits, 0 on IP
+//
+// POST /dapi/v1/order
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/New-Order
+//
+// Parameters:
+//   - order: The order to place, validated against its type
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) NewOrder(order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return f.SignRequest("POST", "/dapi/v1/order", order.Params(params))
+}
+
+// CancelOrder cancels an active order (TRADE)
+// Response can be decoded into Order with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// DELETE /dapi/v1/order
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Cancel-Order
+//
+// Parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) CancelOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("DELETE", "/dapi/v1/order", params)
+}
+
+// CancelOpenOrders cancels all open orders on a symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// DELETE /dapi/v1/allOpenOrders
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Cancel-All-Open-Orders
+//
+// Parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) CancelOpenOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("DELETE", "/dapi/v1/allOpenOrders", params)
+}
+
+// GetOrder checks an order's status (USER_DATA)
+// Response can be decoded into Order with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /dapi/v1/order
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Query-Order
+//
+// Parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) GetOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("GET", "/dapi/v1/order", params)
+}
+
+// GetOpenOrders queries open orders on a symbol or pair, or on all symbols (USER_DATA)
+// Response can be decoded into []Order with client.ParseResponse.
+//
+// Weight(IP): 1 for a single symbol, 40 otherwise
+//
+// GET /dapi/v1/openOrders
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Current-All-Open-Orders
+//
+// Optional parameters:
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - pair: Underlying pair, e.g. BTCUSD
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) GetOpenOrders(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/dapi/v1/openOrders", params)
+}
+
+// GetOrders queries all orders of a symbol or pair: active, canceled, or filled (USER_DATA)
+// Response can be decoded into []Order with client.ParseResponse.
+//
+// Weight(IP): 20 with symbol, 40 with pair
+//
+// GET /dapi/v1/allOrders
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/All-Orders
+//
+// Optional parameters (either symbol or pair must be sent):
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - pair: Underlying pair, e.g. BTCUSD
+//   - orderId: Return orders from this ID onwards
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds, at most 7 days after startTime
+//   - limit: Default 50, max 100
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) GetOrders(params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckOneOfParameters(params, "symbol", "pair"); err != nil {
+		return nil, err
+	}
+	return f.SignRequest("GET", "/dapi/v1/allOrders", params)
+}
+
+// UserTrades queries trades of the account on a symbol or pair (USER_DATA)
+// Response can be decoded into []Trade with client.ParseResponse.
+//
+// Weight(IP): 20 with symbol, 40 with pair
+//
+// GET /dapi/v1/userTrades
+//
+// https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Account-Trade-List
+//
+// Optional parameters (either symbol or pair must be sent):
+//   - symbol: Contract symbol, e.g. BTCUSD_PERP
+//   - pair: Underlying pair, e.g. BTCUSD
+//   - orderId: Only return trades of this order, requires symbol
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds, at most 7 days after startTime
+//   - fromId: Trade ID to fetch from, cannot be sent with pair
+//   - limit: Default 50, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (f *CMFuturesClient) UserTrades(params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckOneOfParameters(params, "symbol", "pair"); err != nil {
+		return nil, err
+	}
+	return f.SignRequest("GET", "/dapi/v1/userTrades", params)
+}
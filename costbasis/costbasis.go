@@ -0,0 +1,434 @@
+// Package costbasis tracks acquisition lots and realized and unrealized PnL
+//
+// A Tracker values every trade and deposit in one quote asset. Each trade
+// acquires one asset and disposes of another, and a commission paid in a
+// third asset such as BNB is a disposal of that asset too, so holding BNB for
+// fees realizes its gains as they are spent. Disposals consume lots in the
+// order of the tracker's Method. Withdrawals move lots out of the tracker
+// without a disposal, and a deposit marked as returning a withdrawal brings
+// them back, so coins sent to an own wallet keep their cost and acquired date.
+package costbasis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/pnl"
+	"github.com/sidan-lab/sidan-binance-go/portfolio"
+)
+
+// Method selects the lots a disposal consumes
+type Method string
+
+const (
+	// FIFO consumes the oldest lots first
+	FIFO Method = "FIFO"
+	// LIFO consumes the newest lots first
+	LIFO Method = "LIFO"
+	// HIFO consumes the lots with the highest unit cost first
+	HIFO Method = "HIFO"
+	// Average pools every acquisition of an asset at its average unit cost
+	Average Method = "AVERAGE"
+)
+
+// dust is the quantity below which a lot is considered used up
+const dust = 1e-12
+
+// Lot is a quantity of an asset acquired at one unit cost
+type Lot struct {
+	// ID is the transaction that acquired the lot, e.g. "BTCUSDT:12345"
+	ID       string
+	Asset    string
+	Quantity float64
+	// UnitCost is the cost of one unit in the tracker's quote asset, fees included
+	UnitCost float64
+	Acquired time.Time
+}
+
+// Match is the part of a lot consumed by a disposal
+type Match struct {
+	LotID    string
+	Acquired time.Time
+	Quantity float64
+	Cost     float64
+}
+
+// Disposal is a sale, a spend on another asset or a commission payment
+type Disposal struct {
+	// ID is the transaction, with a ":fee" suffix for commission payments
+	ID       string
+	Asset    string
+	Time     time.Time
+	Quantity float64
+	// Proceeds is the value received in the quote asset, fees deducted
+	Proceeds float64
+	Cost     float64
+	Gain     float64
+	Matches  []Match
+	// Unmatched is the quantity disposed beyond the tracked lots, e.g. from
+	// an acquisition before the tracked history; it has no cost
+	Unmatched float64
+}
+
+// Position is the holding and PnL of one asset
+type Position struct {
+	Asset       string
+	Quantity    float64
+	Cost        float64
+	AverageCost float64
+	// Price and Value are the current price and value in the quote asset;
+	// Priced is false when the asset could not be priced
+	Price      float64
+	Value      float64
+	Priced     bool
+	Realized   float64
+	Unrealized float64
+}
+
+// Report is the realized and unrealized PnL of every asset
+type Report struct {
+	Method     Method
+	Quote      string
+	Positions  []Position
+	Realized   float64
+	Unrealized float64
+	Disposals  []Disposal
+}
+
+// Tracker keeps the lots of every asset and the disposals matched against them
+//
+// Transactions must be applied in chronological order; Process sorts them.
+type Tracker struct {
+	method Method
+	quote  string
+	pricer pnl.Pricer
+
+	lots map[string][]Lot
+	// withdrawals are the lots moved out by each withdrawal, by ID, until
+	// deposits return them; withdrawalIDs keeps the order they were applied
+	withdrawals   map[string]*outflow
+	withdrawalIDs []string
+	realized      map[string]float64
+	disposals     []Disposal
+}
+
+// outflow is the asset and lots a withdrawal moved out of the tracker
+type outflow struct {
+	asset string
+	lots  []Lot
+}
+
+// NewTracker creates a tracker valuing in quote and matching lots by method
+// pricer values trades quoted in another asset and commissions at the daily
+// close; it may be nil when every trade is quoted and charged in quote.
+func NewTracker(method Method, quote string, pricer pnl.Pricer) (*Tracker, error) {
+	switch method {
+	case FIFO, LIFO, HIFO, Average:
+	default:
+		return nil, fmt.Errorf("unsupported cost basis method %q", method)
+	}
+	return &Tracker{
+		method:      method,
+		quote:       strings.ToUpper(quote),
+		pricer:      pricer,
+		lots:        make(map[string][]Lot),
+		withdrawals: make(map[string]*outflow),
+		realized:    make(map[string]float64),
+	}, nil
+}
+
+// Process applies deposits, withdrawals and trades in chronological order,
+// in that order when they happen at the same time
+func (t *Tracker) Process(deposits []Deposit, withdrawals []Withdrawal, trades []Trade) error {
+	type transaction struct {
+		at    time.Time
+		apply func() error
+	}
+	var transactions []transaction
+	for _, deposit := range deposits {
+		transactions = append(transactions, transaction{deposit.Time, func() error { return t.Deposit(deposit) }})
+	}
+	for _, withdrawal := range withdrawals {
+		transactions = append(transactions, transaction{withdrawal.Time, func() error { return t.Withdraw(withdrawal) }})
+	}
+	for _, trade := range trades {
+		transactions = append(transactions, transaction{trade.Time, func() error { return t.Trade(trade) }})
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].at.Before(transactions[j].at)
+	})
+	for _, transaction := range transactions {
+		if err := transaction.apply(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deposit adds a lot at the deposit's supplied price
+// A deposit returning a withdrawal first brings back that withdrawal's lots,
+// up to the deposited quantity, with their cost and acquired date.
+func (t *Tracker) Deposit(deposit Deposit) error {
+	if deposit.Quantity <= 0 {
+		return fmt.Errorf("deposit %s has quantity %v", deposit.ID, deposit.Quantity)
+	}
+	asset := strings.ToUpper(deposit.Asset)
+	remaining := deposit.Quantity
+	if deposit.Returns != "" {
+		out, ok := t.withdrawals[deposit.Returns]
+		if !ok {
+			return fmt.Errorf("deposit %s returns unknown withdrawal %s", deposit.ID, deposit.Returns)
+		}
+		if out.asset != asset {
+			return fmt.Errorf("deposit %s of %s returns withdrawal %s of %s", deposit.ID, asset, deposit.Returns, out.asset)
+		}
+		var returned []Lot
+		returned, out.lots = split(out.lots, deposit.Quantity)
+		for _, lot := range returned {
+			t.acquire(lot.ID, asset, lot.Quantity, lot.Quantity*lot.UnitCost, lot.Acquired)
+			remaining -= lot.Quantity
+		}
+	}
+	t.acquire(deposit.ID, asset, remaining, remaining*deposit.Price, deposit.Time)
+	return nil
+}
+
+// Withdraw moves lots of the withdrawn quantity out of the tracker, in the
+// order of the tracker's Method, without realizing a gain. The lots of the
+// fee are dropped, as they are neither sold nor deposited back.
+func (t *Tracker) Withdraw(withdrawal Withdrawal) error {
+	if withdrawal.Quantity <= 0 {
+		return fmt.Errorf("withdrawal %s has quantity %v", withdrawal.ID, withdrawal.Quantity)
+	}
+	if _, ok := t.withdrawals[withdrawal.ID]; ok {
+		return fmt.Errorf("withdrawal %s is applied twice", withdrawal.ID)
+	}
+	asset := strings.ToUpper(withdrawal.Asset)
+	out := &outflow{asset: asset}
+	if asset != t.quote {
+		lots := t.lots[asset]
+		t.order(lots)
+		out.lots, lots = split(lots, withdrawal.Quantity)
+		_, lots = split(lots, withdrawal.Fee)
+		setLots(t.lots, asset, lots)
+	}
+	t.withdrawals[withdrawal.ID] = out
+	t.withdrawalIDs = append(t.withdrawalIDs, withdrawal.ID)
+	return nil
+}
+
+// Trade applies a fill: the bought asset is acquired at the value given up
+// plus the commission, and the sold asset disposed of for the value received
+// less the commission
+func (t *Tracker) Trade(trade Trade) error {
+	value, err := t.value(trade.Quote, trade.QuoteQuantity, trade.Time)
+	if err != nil {
+		return fmt.Errorf("trade %s: %w", trade.ID, err)
+	}
+	commissionAsset := strings.ToUpper(trade.CommissionAsset)
+	base, quote := strings.ToUpper(trade.Base), strings.ToUpper(trade.Quote)
+
+	// A commission in the base asset is part of the traded quantity and needs no price
+	var fee float64
+	if commissionAsset != base {
+		fee, err = t.value(commissionAsset, trade.Commission, trade.Time)
+		if err != nil {
+			return fmt.Errorf("commission of trade %s: %w", trade.ID, err)
+		}
+	}
+
+	if trade.Buy {
+		switch commissionAsset {
+		case base:
+			// The commission is taken from the bought quantity, so its cost is
+			// already part of the value paid
+			t.acquire(trade.ID, base, trade.Quantity-trade.Commission, value, trade.Time)
+			t.dispose(trade.ID, quote, trade.QuoteQuantity, value, trade.Time)
+		case quote:
+			t.acquire(trade.ID, base, trade.Quantity, value+fee, trade.Time)
+			t.dispose(trade.ID, quote, trade.QuoteQuantity+trade.Commission, value+fee, trade.Time)
+		default:
+			t.acquire(trade.ID, base, trade.Quantity, value+fee, trade.Time)
+			t.dispose(trade.ID, quote, trade.QuoteQuantity, value, trade.Time)
+			t.dispose(trade.ID+":fee", commissionAsset, trade.Commission, fee, trade.Time)
+		}
+		return nil
+	}
+
+	switch commissionAsset {
+	case quote:
+		t.dispose(trade.ID, base, trade.Quantity, value-fee, trade.Time)
+		t.acquire(trade.ID, quote, trade.QuoteQuantity-trade.Commission, value-fee, trade.Time)
+	case base:
+		t.dispose(trade.ID, base, trade.Quantity+trade.Commission, value, trade.Time)
+		t.acquire(trade.ID, quote, trade.QuoteQuantity, value, trade.Time)
+	default:
+		t.dispose(trade.ID, base, trade.Quantity, value-fee, trade.Time)
+		t.acquire(trade.ID, quote, trade.QuoteQuantity, value, trade.Time)
+		t.dispose(trade.ID+":fee", commissionAsset, trade.Commission, fee, trade.Time)
+	}
+	return nil
+}
+
+// Lots returns the open lots of an asset in the order disposals consume them
+func (t *Tracker) Lots(asset string) []Lot {
+	lots := append([]Lot(nil), t.lots[strings.ToUpper(asset)]...)
+	t.order(lots)
+	return lots
+}
+
+// Withdrawn returns the lots moved out by withdrawals and not returned by a
+// deposit, in the order the withdrawals were applied
+func (t *Tracker) Withdrawn() []Lot {
+	var lots []Lot
+	for _, id := range t.withdrawalIDs {
+		lots = append(lots, t.withdrawals[id].lots...)
+	}
+	return lots
+}
+
+// Disposals returns every disposal in the order they were applied
+func (t *Tracker) Disposals() []Disposal {
+	return append([]Disposal(nil), t.disposals...)
+}
+
+// Report values the open lots with pricer and sums the PnL of every asset
+// Assets pricer fails to price are reported with Priced false and no
+// unrealized PnL. pricer may be nil to report realized PnL only.
+func (t *Tracker) Report(pricer portfolio.Pricer) *Report {
+	report := &Report{Method: t.method, Quote: t.quote, Disposals: t.Disposals()}
+
+	assets := make(map[string]bool)
+	for asset := range t.lots {
+		assets[asset] = true
+	}
+	for asset := range t.realized {
+		assets[asset] = true
+	}
+	for asset := range assets {
+		position := Position{Asset: asset, Realized: t.realized[asset]}
+		for _, lot := range t.lots[asset] {
+			position.Quantity += lot.Quantity
+			position.Cost += lot.Quantity * lot.UnitCost
+		}
+		if position.Quantity > 0 {
+			position.AverageCost = position.Cost / position.Quantity
+			if pricer != nil {
+				if price, err := pricer.Price(asset, t.quote); err == nil {
+					position.Price = price
+					position.Value = position.Quantity * price
+					position.Unrealized = position.Value - position.Cost
+					position.Priced = true
+				}
+			}
+		}
+		report.Realized += position.Realized
+		report.Unrealized += position.Unrealized
+		report.Positions = append(report.Positions, position)
+	}
+	sort.Slice(report.Positions, func(i, j int) bool {
+		return report.Positions[i].Asset < report.Positions[j].Asset
+	})
+	return report
+}
+
+// acquire adds a lot costing cost in total
+func (t *Tracker) acquire(id, asset string, quantity, cost float64, at time.Time) {
+	if asset == t.quote || quantity <= dust {
+		return
+	}
+	lots := t.lots[asset]
+	if t.method == Average && len(lots) > 0 {
+		pool := &lots[0]
+		total := pool.Quantity*pool.UnitCost + cost
+		pool.Quantity += quantity
+		pool.UnitCost = total / pool.Quantity
+		return
+	}
+	t.lots[asset] = append(lots, Lot{ID: id, Asset: asset, Quantity: quantity, UnitCost: cost / quantity, Acquired: at})
+}
+
+// dispose consumes lots of asset for proceeds in total and records the gain
+func (t *Tracker) dispose(id, asset string, quantity, proceeds float64, at time.Time) {
+	if asset == t.quote || quantity <= dust {
+		return
+	}
+	disposal := Disposal{ID: id, Asset: asset, Time: at, Quantity: quantity, Proceeds: proceeds}
+
+	lots := t.lots[asset]
+	t.order(lots)
+	used, lots := split(lots, quantity)
+	setLots(t.lots, asset, lots)
+	remaining := quantity
+	for _, lot := range used {
+		cost := lot.Quantity * lot.UnitCost
+		disposal.Matches = append(disposal.Matches, Match{LotID: lot.ID, Acquired: lot.Acquired, Quantity: lot.Quantity, Cost: cost})
+		disposal.Cost += cost
+		remaining -= lot.Quantity
+	}
+	if remaining > dust {
+		disposal.Unmatched = remaining
+	}
+
+	disposal.Gain = disposal.Proceeds - disposal.Cost
+	t.realized[asset] += disposal.Gain
+	t.disposals = append(t.disposals, disposal)
+}
+
+// split takes quantity from the front of lots, splitting the last lot taken,
+// and returns the parts taken and the lots left
+func split(lots []Lot, quantity float64) ([]Lot, []Lot) {
+	var taken []Lot
+	for len(lots) > 0 && quantity > dust {
+		part := lots[0]
+		part.Quantity = math.Min(part.Quantity, quantity)
+		taken = append(taken, part)
+		lots[0].Quantity -= part.Quantity
+		quantity -= part.Quantity
+		if lots[0].Quantity <= dust {
+			lots = lots[1:]
+		}
+	}
+	return taken, lots
+}
+
+// setLots stores the lots of asset in set, removing the asset when none are left
+func setLots(set map[string][]Lot, asset string, lots []Lot) {
+	if len(lots) == 0 {
+		delete(set, asset)
+	} else {
+		set[asset] = lots
+	}
+}
+
+// order sorts lots in the order the method consumes them
+func (t *Tracker) order(lots []Lot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		switch t.method {
+		case LIFO:
+			return lots[i].Acquired.After(lots[j].Acquired)
+		case HIFO:
+			return lots[i].UnitCost > lots[j].UnitCost
+		default:
+			return lots[i].Acquired.Before(lots[j].Acquired)
+		}
+	})
+}
+
+// value converts an amount of asset to the quote asset at the close of the day of at
+func (t *Tracker) value(asset string, amount float64, at time.Time) (float64, error) {
+	if amount == 0 || strings.EqualFold(asset, t.quote) {
+		return amount, nil
+	}
+	if t.pricer == nil {
+		return 0, fmt.Errorf("no pricer to value %s in %s", asset, t.quote)
+	}
+	price, err := t.pricer.Price(asset, t.quote, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to price %s: %w", asset, err)
+	}
+	return amount * price, nil
+}
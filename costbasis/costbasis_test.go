@@ -0,0 +1,277 @@
+package costbasis
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// fixedPricer prices assets with fixed rates against any quote
+type fixedPricer map[string]float64
+
+func (p fixedPricer) Price(asset, quote string, day time.Time) (float64, error) {
+	if asset == quote {
+		return 1, nil
+	}
+	price, ok := p[asset]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", asset)
+	}
+	return price, nil
+}
+
+// currentPricer prices assets with fixed current rates
+type currentPricer map[string]float64
+
+func (p currentPricer) Price(asset, quote string) (float64, error) {
+	price, ok := p[asset]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", asset)
+	}
+	return price, nil
+}
+
+func at(day int) time.Time {
+	return time.Date(2024, 1, day, 12, 0, 0, 0, time.UTC)
+}
+
+func buy(id string, day int, quantity, price float64) Trade {
+	return Trade{ID: id, Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Buy: true, Quantity: quantity, QuoteQuantity: quantity * price, Time: at(day)}
+}
+
+func sell(id string, day int, quantity, price float64) Trade {
+	return Trade{ID: id, Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Quantity: quantity, QuoteQuantity: quantity * price, Time: at(day)}
+}
+
+func TestMethods(t *testing.T) {
+	// Lots of 1 BTC at 100, 300 and 200, then a sale of 1.5 BTC at 400
+	trades := []Trade{buy("b1", 1, 1, 100), buy("b2", 2, 1, 300), buy("b3", 3, 1, 200), sell("s1", 4, 1.5, 400)}
+
+	cases := []struct {
+		method   Method
+		cost     float64
+		lots     []string
+		quantity float64 // of the first remaining lot
+	}{
+		{FIFO, 100 + 0.5*300, []string{"b2", "b3"}, 0.5},
+		{LIFO, 200 + 0.5*300, []string{"b2", "b1"}, 0.5},
+		{HIFO, 300 + 0.5*200, []string{"b3", "b1"}, 0.5},
+		{Average, 1.5 * 200, []string{"b1"}, 1.5},
+	}
+	for _, c := range cases {
+		tracker, err := NewTracker(c.method, "USDT", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tracker.Process(nil, nil, trades); err != nil {
+			t.Fatal(err)
+		}
+
+		disposals := tracker.Disposals()
+		if len(disposals) != 1 || !almostEqual(disposals[0].Proceeds, 600) || !almostEqual(disposals[0].Cost, c.cost) || !almostEqual(disposals[0].Gain, 600-c.cost) {
+			t.Errorf("%s: unexpected disposals %+v", c.method, disposals)
+		}
+
+		lots := tracker.Lots("BTC")
+		var ids []string
+		for _, lot := range lots {
+			ids = append(ids, lot.ID)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(c.lots) || !almostEqual(lots[0].Quantity, c.quantity) {
+			t.Errorf("%s: unexpected lots %+v", c.method, lots)
+		}
+
+		report := tracker.Report(currentPricer{"BTC": 500})
+		position := report.Positions[0]
+		if position.Asset != "BTC" || !almostEqual(position.Quantity, 1.5) || !almostEqual(position.Cost, 600-c.cost) ||
+			!almostEqual(position.Unrealized, 750-(600-c.cost)) || !almostEqual(report.Realized, 600-c.cost) {
+			t.Errorf("%s: unexpected position %+v", c.method, position)
+		}
+	}
+
+	if _, err := NewTracker("FILO", "USDT", nil); err == nil {
+		t.Error("Expected error for an unsupported method")
+	}
+}
+
+func TestCommissions(t *testing.T) {
+	tracker, err := NewTracker(FIFO, "USDT", fixedPricer{"BNB": 300, "BTC": 40000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tracker.Process(
+		[]Deposit{{ID: "d1", Asset: "bnb", Quantity: 1, Price: 200, Time: at(1)}},
+		nil,
+		[]Trade{
+			// Commission in BNB: 0.01 BNB worth 3 USDT, bought at 2
+			{ID: "t1", Base: "ETH", Quote: "USDT", Buy: true, Quantity: 1, QuoteQuantity: 2000, Commission: 0.01, CommissionAsset: "BNB", Time: at(2)},
+			// Commission in the bought asset reduces the quantity received
+			{ID: "t2", Base: "ETH", Quote: "USDT", Buy: true, Quantity: 1, QuoteQuantity: 2200, Commission: 0.001, CommissionAsset: "ETH", Time: at(3)},
+			// Sale quoted in BTC with the commission in the quote asset
+			{ID: "t3", Base: "ETH", Quote: "BTC", Quantity: 1, QuoteQuantity: 0.06, Commission: 0.0001, CommissionAsset: "BTC", Time: at(4)},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disposals := tracker.Disposals()
+	if len(disposals) != 2 {
+		t.Fatalf("Expected BNB fee and ETH sale disposals, got %+v", disposals)
+	}
+	fee := disposals[0]
+	if fee.ID != "t1:fee" || fee.Asset != "BNB" || !almostEqual(fee.Proceeds, 3) || !almostEqual(fee.Cost, 2) || !almostEqual(fee.Gain, 1) {
+		t.Errorf("Unexpected fee disposal %+v", fee)
+	}
+	sale := disposals[1]
+	if sale.Asset != "ETH" || !almostEqual(sale.Proceeds, 0.0599*40000) || !almostEqual(sale.Cost, 2003) || sale.Matches[0].LotID != "t1" {
+		t.Errorf("Unexpected sale %+v", sale)
+	}
+
+	eth := tracker.Lots("ETH")
+	if len(eth) != 1 || !almostEqual(eth[0].Quantity, 0.999) || !almostEqual(eth[0].UnitCost, 2200/0.999) {
+		t.Errorf("Unexpected ETH lots %+v", eth)
+	}
+	btc := tracker.Lots("BTC")
+	if len(btc) != 1 || !almostEqual(btc[0].Quantity, 0.0599) || !almostEqual(btc[0].UnitCost, 40000) {
+		t.Errorf("Unexpected BTC lots %+v", btc)
+	}
+
+	report := tracker.Report(currentPricer{"ETH": 2500, "BNB": 300})
+	for _, position := range report.Positions {
+		if position.Asset == "BTC" && position.Priced {
+			t.Errorf("Expected BTC to be unpriced, got %+v", position)
+		}
+		if position.Asset == "ETH" && !almostEqual(position.Unrealized, 0.999*2500-2200) {
+			t.Errorf("Unexpected ETH position %+v", position)
+		}
+	}
+
+	if err := tracker.Trade(Trade{ID: "t4", Base: "XRP", Quote: "DOGE", Buy: true, Quantity: 1, QuoteQuantity: 5, Time: at(5)}); err == nil {
+		t.Error("Expected error for a trade that cannot be priced")
+	}
+}
+
+func TestUnmatchedDisposal(t *testing.T) {
+	tracker, _ := NewTracker(FIFO, "USDT", nil)
+	if err := tracker.Process(nil, nil, []Trade{buy("b1", 1, 1, 100), sell("s1", 2, 3, 200)}); err != nil {
+		t.Fatal(err)
+	}
+	disposal := tracker.Disposals()[0]
+	if !almostEqual(disposal.Unmatched, 2) || !almostEqual(disposal.Cost, 100) || !almostEqual(disposal.Gain, 500) {
+		t.Errorf("Unexpected disposal %+v", disposal)
+	}
+	if len(tracker.Lots("BTC")) != 0 {
+		t.Errorf("Expected no BTC lots, got %+v", tracker.Lots("BTC"))
+	}
+}
+
+func TestWithdrawal(t *testing.T) {
+	tracker, _ := NewTracker(FIFO, "USDT", nil)
+	err := tracker.Process(
+		[]Deposit{
+			// 1.2 BTC comes back from w1 and 0.3 BTC more is acquired
+			{ID: "d1", Asset: "BTC", Quantity: 1.5, Price: 300, Time: at(4), Returns: "w1"},
+			// A plain deposit is acquired at its price even with w2 outstanding
+			{ID: "d2", Asset: "BTC", Quantity: 0.4, Price: 250, Time: at(6)},
+		},
+		[]Withdrawal{
+			// Takes all of b1 and 0.2 BTC of b2, and the fee 0.1 BTC more of b2
+			{ID: "w1", Asset: "BTC", Quantity: 1.2, Fee: 0.1, Time: at(3)},
+			// Never deposited back
+			{ID: "w2", Asset: "BTC", Quantity: 0.5, Time: at(5)},
+		},
+		[]Trade{buy("b1", 1, 1, 100), buy("b2", 2, 1, 200)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracker.Disposals()) != 0 {
+		t.Errorf("Expected no disposals, got %+v", tracker.Disposals())
+	}
+
+	lots := tracker.Lots("BTC")
+	expected := []Lot{
+		{ID: "b1", Quantity: 0.5, UnitCost: 100, Acquired: at(1)},
+		{ID: "b2", Quantity: 0.7, UnitCost: 200, Acquired: at(2)},
+		{ID: "b2", Quantity: 0.2, UnitCost: 200, Acquired: at(2)},
+		{ID: "d1", Quantity: 0.3, UnitCost: 300, Acquired: at(4)},
+		{ID: "d2", Quantity: 0.4, UnitCost: 250, Acquired: at(6)},
+	}
+	if len(lots) != len(expected) {
+		t.Fatalf("Unexpected BTC lots %+v", lots)
+	}
+	for i, lot := range lots {
+		if lot.ID != expected[i].ID || !almostEqual(lot.Quantity, expected[i].Quantity) || !almostEqual(lot.UnitCost, expected[i].UnitCost) || !lot.Acquired.Equal(expected[i].Acquired) {
+			t.Errorf("Unexpected lot %+v, expected %+v", lot, expected[i])
+		}
+	}
+
+	withdrawn := tracker.Withdrawn()
+	if len(withdrawn) != 1 || withdrawn[0].ID != "b1" || !almostEqual(withdrawn[0].Quantity, 0.5) {
+		t.Errorf("Expected 0.5 BTC of b1 still withdrawn, got %+v", withdrawn)
+	}
+
+	if err := tracker.Withdraw(Withdrawal{ID: "w3", Asset: "BTC"}); err == nil {
+		t.Error("Expected error for a withdrawal without quantity")
+	}
+	if err := tracker.Withdraw(Withdrawal{ID: "w2", Asset: "BTC", Quantity: 0.1, Time: at(7)}); err == nil {
+		t.Error("Expected error for a withdrawal applied twice")
+	}
+	if err := tracker.Deposit(Deposit{ID: "d3", Asset: "BTC", Quantity: 0.5, Time: at(7), Returns: "w9"}); err == nil {
+		t.Error("Expected error for a deposit returning an unknown withdrawal")
+	}
+	if err := tracker.Deposit(Deposit{ID: "d3", Asset: "ETH", Quantity: 0.5, Time: at(7), Returns: "w2"}); err == nil {
+		t.Error("Expected error for a deposit returning a withdrawal of another asset")
+	}
+}
+
+func TestFetchTrades(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/exchangeInfo":
+			w.Write([]byte(`{"symbols":[{"symbol":"ETHBTC","status":"TRADING","baseAsset":"ETH","quoteAsset":"BTC","filters":[]}]}`))
+		case "/api/v3/myTrades":
+			if r.URL.Query().Get("symbol") != "ETHBTC" {
+				t.Errorf("Unexpected symbol %s", r.URL.Query().Get("symbol"))
+			}
+			w.Write([]byte(`[{"symbol":"ETHBTC","id":28457,"qty":"12.00000000","quoteQty":"48.000012","commission":"10.10000000","commissionAsset":"BNB","time":1704110400000,"isBuyer":true}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	market := spot.NewMarketClient("", "")
+	market.BaseURL = server.URL
+	rules := spot.NewExchangeRules(market, time.Hour)
+
+	trades, err := FetchTrades(wallet, rules, at(1), at(1).Add(time.Hour), "ETHBTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(trades))
+	}
+	trade := trades[0]
+	if trade.ID != "ETHBTC:28457" || trade.Base != "ETH" || trade.Quote != "BTC" || !trade.Buy || trade.Quantity != 12 || trade.Commission != 10.1 || !trade.Time.Equal(at(1)) {
+		t.Errorf("Unexpected trade %+v", trade)
+	}
+}
@@ -0,0 +1,102 @@
+package costbasis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Trade is a fill of Base against Quote
+type Trade struct {
+	// ID identifies the fill, e.g. "BTCUSDT:12345"
+	ID            string
+	Symbol        string
+	Base          string
+	Quote         string
+	Buy           bool
+	Quantity      float64
+	QuoteQuantity float64
+	Commission    float64
+	// CommissionAsset is the asset the commission is paid in, e.g. BNB
+	CommissionAsset string
+	Time            time.Time
+}
+
+// Deposit is an acquisition at a supplied price, e.g. a deposit of coins bought
+// elsewhere or a transfer from another account
+type Deposit struct {
+	ID       string
+	Asset    string
+	Quantity float64
+	// Price is the cost of one unit in the tracker's quote asset
+	Price float64
+	Time  time.Time
+	// Returns is the ID of an earlier Withdrawal this deposit brings back,
+	// e.g. coins sent to an own wallet and deposited again. Its lots come
+	// back with their cost and acquired date, and only a quantity beyond
+	// them is acquired at Price.
+	Returns string
+}
+
+// Withdrawal is a quantity leaving the tracked holdings without a disposal,
+// e.g. a withdrawal to an own wallet or a transfer to another account
+type Withdrawal struct {
+	ID       string
+	Asset    string
+	Quantity float64
+	// Fee is charged in Asset on top of Quantity
+	Fee  float64
+	Time time.Time
+}
+
+// FromAccountTrade converts a MyTrades record of a symbol with base and quote assets
+func FromAccountTrade(trade spot.AccountTrade, base, quote string) (Trade, error) {
+	values := make([]float64, 3)
+	for i, value := range []string{trade.Qty, trade.QuoteQty, trade.Commission} {
+		parsed, err := utils.ParseAmount(value)
+		if err != nil {
+			return Trade{}, fmt.Errorf("trade %s %d: %w", trade.Symbol, trade.ID, err)
+		}
+		values[i] = parsed
+	}
+	return Trade{
+		ID:              trade.Symbol + ":" + strconv.FormatInt(trade.ID, 10),
+		Symbol:          trade.Symbol,
+		Base:            base,
+		Quote:           quote,
+		Buy:             trade.IsBuyer,
+		Quantity:        values[0],
+		QuoteQuantity:   values[1],
+		Commission:      values[2],
+		CommissionAsset: trade.CommissionAsset,
+		Time:            time.UnixMilli(trade.Time).UTC(),
+	}, nil
+}
+
+// FetchTrades reads the trades of the account owning wallet in the given
+// symbols between start and end, using rules to find each symbol's base and
+// quote asset
+func FetchTrades(wallet *spot.WalletClient, rules *spot.ExchangeRules, start, end time.Time, symbols ...string) ([]Trade, error) {
+	var trades []Trade
+	for _, symbol := range symbols {
+		info, err := rules.Symbol(symbol)
+		if err != nil {
+			return nil, err
+		}
+		records, err := wallet.Trades(symbol, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			trade, err := FromAccountTrade(record, info.BaseAsset, info.QuoteAsset)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
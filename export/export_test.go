@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+func sampleTrades(t *testing.T) []spot.AccountTrade {
+	body := `[{"symbol":"BNBBTC","id":28457,"orderId":100234,"orderListId":-1,"price":"4.00000100","qty":"12.00000000","quoteQty":"48.000012","commission":"10.10000000","commissionAsset":"BNB","time":1499865549590,"isBuyer":true,"isMaker":false,"isBestMatch":true},
+		{"symbol":"BNBBTC","id":28458,"orderId":100235,"orderListId":-1,"price":"0.00000001","qty":"123456789.123456789","quoteQty":"1.23456789","commission":"0","commissionAsset":"BTC","time":1499865549591,"isBuyer":false,"isMaker":true,"isBestMatch":true}]`
+	var trades []spot.AccountTrade
+	if err := client.ParseResponse([]byte(body), &trades); err != nil {
+		t.Fatal(err)
+	}
+	return trades
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, Trades, sampleTrades(t)); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || strings.Join(records[0], ",") != strings.Join(Trades.Names(), ",") {
+		t.Fatalf("Unexpected CSV %v", records)
+	}
+	// Decimals keep every digit the API sent
+	if records[2][5] != "123456789.123456789" || records[2][4] != "0.00000001" || records[1][3] != "-1" || records[1][10] != "true" {
+		t.Errorf("Unexpected rows %v", records[1:])
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSONL, Trades, sampleTrades(t)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], `{"symbol":"BNBBTC","id":28457,"order_id":100234,`) {
+		t.Errorf("Expected keys in column order, got %s", lines[0])
+	}
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &row); err != nil {
+		t.Fatal(err)
+	}
+	if row["qty"] != "123456789.123456789" || row["is_maker"] != true || row["time"] != float64(1499865549591) {
+		t.Errorf("Unexpected row %v", row)
+	}
+}
+
+func TestSnapshotRows(t *testing.T) {
+	body := `{"code":200,"msg":"","snapshotVos":[
+		{"data":{"balances":[{"asset":"BTC","free":"0.09905021","locked":"0.00000000"}],"totalAssetOfBtc":"0.09942700"},"type":"spot","updateTime":1576281599000},
+		{"data":{"userAssets":[{"asset":"XRP","borrowed":"0.00000000","free":"1.00000000","interest":"0.00000000","locked":"0.00000000","netAsset":"1.00000000"}]},"type":"margin","updateTime":1576281599000},
+		{"data":{"assets":[{"asset":"USDT","marginBalance":"118.99782335","walletBalance":"120.23811389"}],"position":[]},"type":"futures","updateTime":1576281599000}]}`
+	var snapshots spot.AccountSnapshots
+	if err := client.ParseResponse([]byte(body), &snapshots); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := SnapshotRows(snapshots.SnapshotVos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0].Free != "0.09905021" || rows[1].NetAsset != "1.00000000" || rows[2].WalletBalance != "120.23811389" {
+		t.Errorf("Unexpected rows %+v", rows)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, Snapshots, rows); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "futures,1576281599000,USDT,,,,,,118.99782335,120.23811389") {
+		t.Errorf("Unexpected CSV %s", buf.String())
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, "xlsx", Deposits); err == nil {
+		t.Error("Expected error for an unsupported format")
+	}
+}
+
+// readStruct decodes a Thrift compact struct into field values, returning it
+// and the number of bytes read
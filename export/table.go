@@ -0,0 +1,90 @@
+// Package export writes typed account history to CSV or JSON Lines
+//
+// A Table fixes the columns of one record type, so every export of deposits,
+// trades or transfers has the same header, field names and column types.
+// Decimals stay the strings Binance sent and are never parsed into floats, so
+// no digits are lost; IDs and millisecond timestamps are integers.
+package export
+
+import (
+	"strconv"
+)
+
+// Kind is the type of a column's values
+type Kind int
+
+const (
+	// String columns hold text, including decimal amounts as sent by the API
+	String Kind = iota
+	// Int columns hold 64-bit integers such as IDs and millisecond timestamps
+	Int
+	// Bool columns hold true or false
+	Bool
+)
+
+// Column is one field of a table's records
+type Column[T any] struct {
+	Name string
+	Kind Kind
+
+	str  func(T) string
+	num  func(T) int64
+	flag func(T) bool
+}
+
+// StringColumn creates a text column read with value
+func StringColumn[T any](name string, value func(T) string) Column[T] {
+	return Column[T]{Name: name, Kind: String, str: value}
+}
+
+// IntColumn creates an integer column read with value
+func IntColumn[T any](name string, value func(T) int64) Column[T] {
+	return Column[T]{Name: name, Kind: Int, num: value}
+}
+
+// BoolColumn creates a boolean column read with value
+func BoolColumn[T any](name string, value func(T) bool) Column[T] {
+	return Column[T]{Name: name, Kind: Bool, flag: value}
+}
+
+// value returns the column of record as a string, int64 or bool
+func (c Column[T]) value(record T) interface{} {
+	switch c.Kind {
+	case Int:
+		return c.num(record)
+	case Bool:
+		return c.flag(record)
+	default:
+		return c.str(record)
+	}
+}
+
+// Table is the stable schema of one record type
+type Table[T any] struct {
+	// Name identifies the table, e.g. "deposits"
+	Name    string
+	Columns []Column[T]
+}
+
+// Names returns the column names in order
+func (t Table[T]) Names() []string {
+	names := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// row returns the values of record in column order
+func (t Table[T]) row(record T) []interface{} {
+	values := make([]interface{}, len(t.Columns))
+	for i, column := range t.Columns {
+		values[i] = column.value(record)
+	}
+	return values
+}
+
+// formatFloat formats a computed amount with the fewest digits that round-trip
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,247 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+// Deposits is the table of WalletClient.DepositHistory records
+var Deposits = Table[spot.Deposit]{
+	Name: "deposits",
+	Columns: []Column[spot.Deposit]{
+		StringColumn("id", func(d spot.Deposit) string { return d.ID }),
+		StringColumn("coin", func(d spot.Deposit) string { return d.Coin }),
+		StringColumn("network", func(d spot.Deposit) string { return d.Network }),
+		StringColumn("amount", func(d spot.Deposit) string { return d.Amount }),
+		IntColumn("status", func(d spot.Deposit) int64 { return int64(d.Status) }),
+		StringColumn("address", func(d spot.Deposit) string { return d.Address }),
+		StringColumn("address_tag", func(d spot.Deposit) string { return d.AddressTag }),
+		StringColumn("tx_id", func(d spot.Deposit) string { return d.TxID }),
+		IntColumn("insert_time", func(d spot.Deposit) int64 { return d.InsertTime }),
+		IntColumn("complete_time", func(d spot.Deposit) int64 { return d.CompleteTime }),
+		IntColumn("transfer_type", func(d spot.Deposit) int64 { return int64(d.TransferType) }),
+		StringColumn("confirm_times", func(d spot.Deposit) string { return d.ConfirmTimes }),
+		IntColumn("wallet_type", func(d spot.Deposit) int64 { return int64(d.WalletType) }),
+	},
+}
+
+// Withdrawals is the table of WalletClient.WithdrawalHistory records
+var Withdrawals = Table[spot.Withdrawal]{
+	Name: "withdrawals",
+	Columns: []Column[spot.Withdrawal]{
+		StringColumn("id", func(w spot.Withdrawal) string { return w.ID }),
+		StringColumn("withdraw_order_id", func(w spot.Withdrawal) string { return w.WithdrawOrderID }),
+		StringColumn("coin", func(w spot.Withdrawal) string { return w.Coin }),
+		StringColumn("network", func(w spot.Withdrawal) string { return w.Network }),
+		StringColumn("amount", func(w spot.Withdrawal) string { return w.Amount }),
+		StringColumn("transaction_fee", func(w spot.Withdrawal) string { return w.TransactionFee }),
+		IntColumn("status", func(w spot.Withdrawal) int64 { return int64(w.Status) }),
+		StringColumn("address", func(w spot.Withdrawal) string { return w.Address }),
+		StringColumn("address_tag", func(w spot.Withdrawal) string { return w.AddressTag }),
+		StringColumn("tx_id", func(w spot.Withdrawal) string { return w.TxID }),
+		StringColumn("apply_time", func(w spot.Withdrawal) string { return w.ApplyTime }),
+		StringColumn("complete_time", func(w spot.Withdrawal) string { return w.CompleteTime }),
+		IntColumn("transfer_type", func(w spot.Withdrawal) int64 { return int64(w.TransferType) }),
+		IntColumn("wallet_type", func(w spot.Withdrawal) int64 { return int64(w.WalletType) }),
+		StringColumn("info", func(w spot.Withdrawal) string { return w.Info }),
+	},
+}
+
+// Trades is the table of WalletClient.MyTrades records
+var Trades = Table[spot.AccountTrade]{
+	Name: "trades",
+	Columns: []Column[spot.AccountTrade]{
+		StringColumn("symbol", func(t spot.AccountTrade) string { return t.Symbol }),
+		IntColumn("id", func(t spot.AccountTrade) int64 { return t.ID }),
+		IntColumn("order_id", func(t spot.AccountTrade) int64 { return t.OrderID }),
+		IntColumn("order_list_id", func(t spot.AccountTrade) int64 { return t.OrderListID }),
+		StringColumn("price", func(t spot.AccountTrade) string { return t.Price }),
+		StringColumn("qty", func(t spot.AccountTrade) string { return t.Qty }),
+		StringColumn("quote_qty", func(t spot.AccountTrade) string { return t.QuoteQty }),
+		StringColumn("commission", func(t spot.AccountTrade) string { return t.Commission }),
+		StringColumn("commission_asset", func(t spot.AccountTrade) string { return t.CommissionAsset }),
+		IntColumn("time", func(t spot.AccountTrade) int64 { return t.Time }),
+		BoolColumn("is_buyer", func(t spot.AccountTrade) bool { return t.IsBuyer }),
+		BoolColumn("is_maker", func(t spot.AccountTrade) bool { return t.IsMaker }),
+		BoolColumn("is_best_match", func(t spot.AccountTrade) bool { return t.IsBestMatch }),
+	},
+}
+
+// UniversalTransfers is the table of WalletClient.UniversalTransferHistory records
+var UniversalTransfers = Table[spot.UniversalTransfer]{
+	Name: "universal_transfers",
+	Columns: []Column[spot.UniversalTransfer]{
+		IntColumn("tran_id", func(t spot.UniversalTransfer) int64 { return t.TranID }),
+		StringColumn("type", func(t spot.UniversalTransfer) string { return t.Type }),
+		StringColumn("asset", func(t spot.UniversalTransfer) string { return t.Asset }),
+		StringColumn("amount", func(t spot.UniversalTransfer) string { return t.Amount }),
+		StringColumn("status", func(t spot.UniversalTransfer) string { return t.Status }),
+		IntColumn("timestamp", func(t spot.UniversalTransfer) int64 { return t.Timestamp }),
+	},
+}
+
+// SubAccountTransfers is the table of WalletClient.SubAccountTransferHistory records
+var SubAccountTransfers = Table[spot.SubAccountTransfer]{
+	Name: "sub_account_transfers",
+	Columns: []Column[spot.SubAccountTransfer]{
+		IntColumn("tran_id", func(t spot.SubAccountTransfer) int64 { return t.TranID }),
+		StringColumn("counter_party", func(t spot.SubAccountTransfer) string { return t.CounterParty }),
+		StringColumn("email", func(t spot.SubAccountTransfer) string { return t.Email }),
+		IntColumn("type", func(t spot.SubAccountTransfer) int64 { return int64(t.Type) }),
+		StringColumn("asset", func(t spot.SubAccountTransfer) string { return t.Asset }),
+		StringColumn("qty", func(t spot.SubAccountTransfer) string { return t.Qty }),
+		StringColumn("from_account_type", func(t spot.SubAccountTransfer) string { return t.FromAccountType }),
+		StringColumn("to_account_type", func(t spot.SubAccountTransfer) string { return t.ToAccountType }),
+		StringColumn("status", func(t spot.SubAccountTransfer) string { return t.Status }),
+		IntColumn("time", func(t spot.SubAccountTransfer) int64 { return t.Time }),
+	},
+}
+
+// SubAccountUniversalTransfers is the table of
+// WalletClient.MasterSubAccountTransferHistory records
+var SubAccountUniversalTransfers = Table[spot.SubAccountUniversalTransfer]{
+	Name: "sub_account_universal_transfers",
+	Columns: []Column[spot.SubAccountUniversalTransfer]{
+		IntColumn("tran_id", func(t spot.SubAccountUniversalTransfer) int64 { return t.TranID }),
+		StringColumn("client_tran_id", func(t spot.SubAccountUniversalTransfer) string { return t.ClientTranID }),
+		StringColumn("from_email", func(t spot.SubAccountUniversalTransfer) string { return t.FromEmail }),
+		StringColumn("from_account_type", func(t spot.SubAccountUniversalTransfer) string { return t.FromAccountType }),
+		StringColumn("to_email", func(t spot.SubAccountUniversalTransfer) string { return t.ToEmail }),
+		StringColumn("to_account_type", func(t spot.SubAccountUniversalTransfer) string { return t.ToAccountType }),
+		StringColumn("asset", func(t spot.SubAccountUniversalTransfer) string { return t.Asset }),
+		StringColumn("amount", func(t spot.SubAccountUniversalTransfer) string { return t.Amount }),
+		StringColumn("status", func(t spot.SubAccountUniversalTransfer) string { return t.Status }),
+		IntColumn("create_time", func(t spot.SubAccountUniversalTransfer) int64 { return t.CreateTimeStamp }),
+	},
+}
+
+// SubAccountInternalTransfers is the table of the spot and futures transfer
+// histories of SubAccountClient
+var SubAccountInternalTransfers = Table[spot.SubAccountInternalTransfer]{
+	Name: "sub_account_internal_transfers",
+	Columns: []Column[spot.SubAccountInternalTransfer]{
+		IntColumn("tran_id", func(t spot.SubAccountInternalTransfer) int64 { return t.TranID }),
+		StringColumn("from", func(t spot.SubAccountInternalTransfer) string { return t.From }),
+		StringColumn("to", func(t spot.SubAccountInternalTransfer) string { return t.To }),
+		StringColumn("asset", func(t spot.SubAccountInternalTransfer) string { return t.Asset }),
+		StringColumn("qty", func(t spot.SubAccountInternalTransfer) string { return t.Qty }),
+		StringColumn("status", func(t spot.SubAccountInternalTransfer) string { return t.Status }),
+		IntColumn("time", func(t spot.SubAccountInternalTransfer) int64 { return t.Time }),
+	},
+}
+
+// ManagedSubAccountTransfers is the table of the managed sub-account transfer
+// logs of SubAccountClient
+var ManagedSubAccountTransfers = Table[spot.ManagedSubAccountTransfer]{
+	Name: "managed_sub_account_transfers",
+	Columns: []Column[spot.ManagedSubAccountTransfer]{
+		IntColumn("tran_id", func(t spot.ManagedSubAccountTransfer) int64 { return t.TranID }),
+		StringColumn("from_email", func(t spot.ManagedSubAccountTransfer) string { return t.FromEmail }),
+		StringColumn("from_account_type", func(t spot.ManagedSubAccountTransfer) string { return t.FromAccountType }),
+		StringColumn("to_email", func(t spot.ManagedSubAccountTransfer) string { return t.ToEmail }),
+		StringColumn("to_account_type", func(t spot.ManagedSubAccountTransfer) string { return t.ToAccountType }),
+		StringColumn("asset", func(t spot.ManagedSubAccountTransfer) string { return t.Asset }),
+		StringColumn("amount", func(t spot.ManagedSubAccountTransfer) string { return t.Amount }),
+		StringColumn("status", func(t spot.ManagedSubAccountTransfer) string { return t.Status }),
+		IntColumn("scheduled_time", func(t spot.ManagedSubAccountTransfer) int64 { return t.ScheduledData }),
+		IntColumn("create_time", func(t spot.ManagedSubAccountTransfer) int64 { return t.CreateTime }),
+	},
+}
+
+// SnapshotRow is one asset of an account snapshot
+// Columns that do not apply to the snapshot's type are empty.
+type SnapshotRow struct {
+	Type          string
+	UpdateTime    int64
+	Asset         string
+	Free          string
+	Locked        string
+	Borrowed      string
+	Interest      string
+	NetAsset      string
+	MarginBalance string
+	WalletBalance string
+}
+
+// SnapshotRows flattens spot, margin and USDⓈ-M futures snapshots into one row per asset
+func SnapshotRows(snapshots []spot.AccountSnapshot) ([]SnapshotRow, error) {
+	var rows []SnapshotRow
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		base := SnapshotRow{Type: snapshot.Type, UpdateTime: snapshot.UpdateTime}
+		switch {
+		case snapshot.IsType(spot.SnapshotSpot):
+			data, err := snapshot.Spot()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse spot snapshot: %w", err)
+			}
+			for _, balance := range data.Balances {
+				row := base
+				row.Asset, row.Free, row.Locked = balance.Asset, balance.Free, balance.Locked
+				rows = append(rows, row)
+			}
+		case snapshot.IsType(spot.SnapshotMargin):
+			data, err := snapshot.Margin()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse margin snapshot: %w", err)
+			}
+			for _, asset := range data.UserAssets {
+				row := base
+				row.Asset, row.Free, row.Locked = asset.Asset, asset.Free, asset.Locked
+				row.Borrowed, row.Interest, row.NetAsset = asset.Borrowed, asset.Interest, asset.NetAsset
+				rows = append(rows, row)
+			}
+		case snapshot.IsType(spot.SnapshotFutures):
+			data, err := snapshot.Futures()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse futures snapshot: %w", err)
+			}
+			for _, asset := range data.Assets {
+				row := base
+				row.Asset, row.MarginBalance, row.WalletBalance = asset.Asset, asset.MarginBalance, asset.WalletBalance
+				rows = append(rows, row)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported snapshot type %q", snapshot.Type)
+		}
+	}
+	return rows, nil
+}
+
+// Snapshots is the table of SnapshotRows
+var Snapshots = Table[SnapshotRow]{
+	Name: "snapshots",
+	Columns: []Column[SnapshotRow]{
+		StringColumn("type", func(r SnapshotRow) string { return r.Type }),
+		IntColumn("update_time", func(r SnapshotRow) int64 { return r.UpdateTime }),
+		StringColumn("asset", func(r SnapshotRow) string { return r.Asset }),
+		StringColumn("free", func(r SnapshotRow) string { return r.Free }),
+		StringColumn("locked", func(r SnapshotRow) string { return r.Locked }),
+		StringColumn("borrowed", func(r SnapshotRow) string { return r.Borrowed }),
+		StringColumn("interest", func(r SnapshotRow) string { return r.Interest }),
+		StringColumn("net_asset", func(r SnapshotRow) string { return r.NetAsset }),
+		StringColumn("margin_balance", func(r SnapshotRow) string { return r.MarginBalance }),
+		StringColumn("wallet_balance", func(r SnapshotRow) string { return r.WalletBalance }),
+	},
+}
+
+// Events is the table of ledger events
+// Amounts are computed floats, written with the fewest digits that round-trip.
+var Events = Table[ledger.Event]{
+	Name: "events",
+	Columns: []Column[ledger.Event]{
+		StringColumn("source", func(e ledger.Event) string { return e.Source }),
+		StringColumn("type", func(e ledger.Event) string { return string(e.Type) }),
+		StringColumn("account", func(e ledger.Event) string { return e.Account }),
+		StringColumn("wallet", func(e ledger.Event) string { return e.Wallet }),
+		StringColumn("asset", func(e ledger.Event) string { return e.Asset }),
+		StringColumn("amount", func(e ledger.Event) string { return formatFloat(e.Amount) }),
+		StringColumn("fee", func(e ledger.Event) string { return formatFloat(e.Fee) }),
+		StringColumn("fee_asset", func(e ledger.Event) string { return e.FeeAsset }),
+		StringColumn("counterparty", func(e ledger.Event) string { return e.Counterparty }),
+		StringColumn("external_id", func(e ledger.Event) string { return e.ExternalID }),
+		IntColumn("time", func(e ledger.Event) int64 { return e.Time.UnixMilli() }),
+	},
+}
@@ -0,0 +1,147 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Format is an output file format
+type Format string
+
+const (
+	CSV   Format = "csv"
+	JSONL Format = "jsonl"
+)
+
+// rowWriter writes rows of column values in one format
+type rowWriter interface {
+	writeRow(values []interface{}) error
+	close() error
+}
+
+// Writer streams records of one table to an io.Writer
+//
+// Records are written as they arrive. Close must be called to flush the
+// output; it does not close the underlying io.Writer.
+type Writer[T any] struct {
+	table Table[T]
+	rows  rowWriter
+}
+
+// NewWriter creates a writer of table's records to w in format
+func NewWriter[T any](w io.Writer, format Format, table Table[T]) (*Writer[T], error) {
+	var rows rowWriter
+	var err error
+	switch format {
+	case CSV:
+		rows, err = newCSVWriter(w, table.Names())
+	case JSONL:
+		rows = newJSONLWriter(w, table.Names())
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Writer[T]{table: table, rows: rows}, nil
+}
+
+// Write writes records
+func (w *Writer[T]) Write(records ...T) error {
+	for _, record := range records {
+		if err := w.rows.writeRow(w.table.row(record)); err != nil {
+			return fmt.Errorf("failed to write %s record: %w", w.table.Name, err)
+		}
+	}
+	return nil
+}
+
+// Close flushes buffered records and writes any format trailer
+func (w *Writer[T]) Close() error {
+	return w.rows.close()
+}
+
+// Write writes records of table to w in format in one call
+func Write[T any](w io.Writer, format Format, table Table[T], records []T) error {
+	writer, err := NewWriter(w, format, table)
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(records...); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+// newCSVWriter writes the header row; integers and booleans are written in Go syntax
+func newCSVWriter(w io.Writer, names []string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(names); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer, record: make([]string, len(names))}, nil
+}
+
+func (c *csvWriter) writeRow(values []interface{}) error {
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			c.record[i] = v
+		case int64:
+			c.record[i] = strconv.FormatInt(v, 10)
+		case bool:
+			c.record[i] = strconv.FormatBool(v)
+		}
+	}
+	return c.writer.Write(c.record)
+}
+
+func (c *csvWriter) close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type jsonlWriter struct {
+	writer *bufio.Writer
+	keys   [][]byte
+}
+
+// newJSONLWriter writes one object per line with the keys in column order
+func newJSONLWriter(w io.Writer, names []string) *jsonlWriter {
+	keys := make([][]byte, len(names))
+	for i, name := range names {
+		keys[i], _ = json.Marshal(name)
+	}
+	return &jsonlWriter{writer: bufio.NewWriter(w), keys: keys}
+}
+
+func (j *jsonlWriter) writeRow(values []interface{}) error {
+	j.writer.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			j.writer.WriteByte(',')
+		}
+		j.writer.Write(j.keys[i])
+		j.writer.WriteByte(':')
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		j.writer.Write(encoded)
+	}
+	j.writer.WriteByte('}')
+	return j.writer.WriteByte('\n')
+}
+
+func (j *jsonlWriter) close() error {
+	return j.writer.Flush()
+}
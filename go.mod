@@ -3,3 +3,7 @@ module github.com/sidan-lab/sidan-binance-go
 go 1.25.2
 
 require github.com/joho/godotenv v1.5.1
+
+require github.com/gorilla/websocket v1.5.3
+
+require github.com/mattn/go-sqlite3 v1.14.33
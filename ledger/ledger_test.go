@@ -0,0 +1,186 @@
+package ledger
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+var base = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFromTrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		trade      spot.AccountTrade
+		wantBase   float64
+		wantQuote  float64
+		wantFeeLeg int
+	}{
+		{
+			name:       "buy paying commission in base",
+			trade:      spot.AccountTrade{Symbol: "BTCUSDT", ID: 1, Qty: "0.5", QuoteQty: "20000", Commission: "0.0005", CommissionAsset: "BTC", IsBuyer: true},
+			wantBase:   0.5,
+			wantQuote:  -20000,
+			wantFeeLeg: 0,
+		},
+		{
+			name:       "sell paying commission in quote",
+			trade:      spot.AccountTrade{Symbol: "BTCUSDT", ID: 2, Qty: "0.5", QuoteQty: "20000", Commission: "20", CommissionAsset: "USDT"},
+			wantBase:   -0.5,
+			wantQuote:  20000,
+			wantFeeLeg: 1,
+		},
+		{
+			name:       "commission in BNB",
+			trade:      spot.AccountTrade{Symbol: "BTCUSDT", ID: 3, Qty: "0.5", QuoteQty: "20000", Commission: "0.03", CommissionAsset: "BNB", IsBuyer: true},
+			wantBase:   0.5,
+			wantQuote:  -20000,
+			wantFeeLeg: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legs, err := fromTrade("master", tt.trade, "BTC", "USDT")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if legs[0].Asset != "BTC" || legs[0].Amount != tt.wantBase || legs[1].Asset != "USDT" || legs[1].Amount != tt.wantQuote {
+				t.Errorf("Unexpected legs %+v", legs)
+			}
+			if legs[tt.wantFeeLeg].FeeAsset != tt.trade.CommissionAsset || legs[1-tt.wantFeeLeg].Fee != 0 {
+				t.Errorf("Expected the fee on leg %d, got %+v", tt.wantFeeLeg, legs)
+			}
+			if legs[0].ExternalID != fmt.Sprintf("BTCUSDT:%d", tt.trade.ID) || legs[0].Key() == legs[1].Key() {
+				t.Errorf("Unexpected IDs %s %s", legs[0].Key(), legs[1].Key())
+			}
+		})
+	}
+}
+
+func TestSplitTransferType(t *testing.T) {
+	tests := []struct {
+		transferType string
+		from, to     string
+	}{
+		{"MAIN_UMFUTURE", WalletSpot, WalletUSDTFuture},
+		{"FUNDING_MAIN", WalletFunding, WalletSpot},
+		{"MAIN_PORTFOLIO_MARGIN", WalletSpot, WalletPortfolioMargin},
+		{"PORTFOLIO_MARGIN_MAIN", WalletPortfolioMargin, WalletSpot},
+		{"ISOLATEDMARGIN_MARGIN", WalletIsolatedMargin, WalletMargin},
+	}
+	for _, tt := range tests {
+		from, to, err := splitTransferType(tt.transferType)
+		if err != nil || from != tt.from || to != tt.to {
+			t.Errorf("%s: expected %s -> %s, got %s -> %s (%v)", tt.transferType, tt.from, tt.to, from, to, err)
+		}
+	}
+	for _, transferType := range UniversalTransferTypes {
+		if _, _, err := splitTransferType(transferType); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, _, err := splitTransferType("MAIN_MOON"); err == nil {
+		t.Error("Expected error for an unknown type")
+	}
+}
+
+func TestMergeAndBalances(t *testing.T) {
+	transfer := transferEvents("a", 7, base.UnixMilli(), "master", WalletSpot, "sub@test.com", WalletSpot, "USDT", 100)
+	duplicate := transferEvents("b", 7, base.UnixMilli(), "master", WalletSpot, "sub@test.com", WalletSpot, "USDT", 100)
+	withdrawal := Event{Type: EventWithdrawal, Account: "sub@test.com", Wallet: WalletSpot, Asset: "USDT", Amount: -50, Fee: 1, FeeAsset: "USDT", ExternalID: "w1", Time: base.Add(time.Hour)}
+	deposit := Event{Type: EventDeposit, Account: "master", Wallet: WalletFunding, Asset: "BTC", Amount: 1, ExternalID: "d1", Time: base.Add(-time.Hour)}
+
+	events := Merge([]Event{withdrawal}, transfer, duplicate, []Event{deposit})
+	if len(events) != 4 {
+		t.Fatalf("Expected duplicates to be dropped, got %d events", len(events))
+	}
+	if events[0].ExternalID != "d1" || events[1].Amount != -100 || events[2].Amount != 100 || events[3].ExternalID != "w1" {
+		t.Errorf("Unexpected order %+v", events)
+	}
+	if events[1].Source != "a" || events[1].Counterparty != "sub@test.com" || events[2].Counterparty != "master" {
+		t.Errorf("Unexpected transfer legs %+v %+v", events[1], events[2])
+	}
+
+	balances := Balances(events)
+	if balances["master"]["SPOT:USDT"] != -100 || balances["master"]["FUNDING:BTC"] != 1 || balances["sub@test.com"]["SPOT:USDT"] != 49 {
+		t.Errorf("Unexpected balances %v", balances)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	at := base.UnixMilli()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/sapi/v1/capital/deposit/hisrec":
+			fmt.Fprintf(w, `[{"id":"d1","amount":"0.5","coin":"BTC","network":"BTC","status":1,"insertTime":%d,"walletType":1},
+				{"id":"d2","amount":"9","coin":"BTC","status":0,"insertTime":%d}]`, at, at)
+		case "/sapi/v1/capital/withdraw/history":
+			fmt.Fprintf(w, `[{"id":"w1","amount":"0.1","transactionFee":"0.0005","coin":"BTC","status":6,"address":"bc1q","applyTime":"%s","walletType":0},
+				{"id":"w2","amount":"1","transactionFee":"0","coin":"BTC","status":1,"applyTime":"%s"}]`,
+				base.Add(2*time.Hour).Format(time.DateTime), base.Format(time.DateTime))
+		case "/sapi/v1/asset/transfer":
+			if query.Get("type") != "MAIN_UMFUTURE" || query.Get("current") != "1" {
+				t.Errorf("Unexpected universal transfer query %s", r.URL.RawQuery)
+			}
+			fmt.Fprintf(w, `{"total":1,"rows":[{"asset":"USDT","amount":"25","type":"MAIN_UMFUTURE","status":"CONFIRMED","tranId":11,"timestamp":%d}]}`, at)
+		case "/sapi/v1/sub-account/universalTransfer":
+			fmt.Fprintf(w, `{"result":[{"tranId":12,"fromEmail":"","toEmail":"sub@test.com","asset":"USDT","amount":"100","createTimeStamp":%d,"fromAccountType":"SPOT","toAccountType":"SPOT","status":"SUCCESS"}],"totalCount":1}`, at)
+		case "/sapi/v1/sub-account/sub/transfer/history":
+			// The same transfer as the universal transfer history
+			fmt.Fprintf(w, `[{"from":"boss@test.com","to":"sub@test.com","asset":"USDT","qty":"100","status":"SUCCESS","tranId":12,"time":%d}]`, at)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	subAccounts := spot.NewSubAccountClient("test_key", "test_secret")
+	subAccounts.BaseURL = server.URL
+
+	events, err := Collect(base.Add(-day), base.Add(day),
+		Deposits(wallet, "master"),
+		Withdrawals(wallet, "master"),
+		UniversalTransfers(wallet, "master", "MAIN_UMFUTURE"),
+		SubAccountUniversalTransfers(wallet, "master"),
+		SubAccountSpotTransfers(subAccounts, "BOSS@test.com", "master"),
+	)
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	// d1, two legs of tranId 11, two legs of tranId 12 and w1
+	if len(events) != 6 {
+		t.Fatalf("Expected 6 events, got %d: %+v", len(events), events)
+	}
+	last := events[len(events)-1]
+	if last.ExternalID != "w1" || last.Amount != -0.1 || last.Fee != 0.0005 || last.Counterparty != "bc1q" {
+		t.Errorf("Unexpected withdrawal %+v", last)
+	}
+	// Events at the same time are ordered by ID
+	if events[0].ExternalID != "11" || events[4].ExternalID != "d1" || events[4].Wallet != WalletFunding {
+		t.Errorf("Unexpected order %+v", events)
+	}
+
+	balances := Balances(events)
+	if math.Abs(balances["master"]["SPOT:BTC"]-(-0.1005)) > 1e-12 {
+		t.Errorf("Unexpected BTC balance %v", balances["master"]["SPOT:BTC"])
+	}
+	if balances["master"]["SPOT:USDT"] != -125 || balances["master"]["USDT_FUTURE:USDT"] != 25 || balances["sub@test.com"]["SPOT:USDT"] != 100 {
+		t.Errorf("Unexpected USDT balances %v", balances)
+	}
+}
@@ -0,0 +1,466 @@
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+const (
+	day = 24 * time.Hour
+
+	// Widest ranges and page sizes accepted by the history endpoints
+	transferSpan = 30 * day
+	transferPage = 500
+	walletPage   = 100
+)
+
+// UniversalTransferTypes are the wallet transfer types read by
+// UniversalTransfers when none are given
+var UniversalTransferTypes = []string{
+	"MAIN_FUNDING", "FUNDING_MAIN",
+	"MAIN_MARGIN", "MARGIN_MAIN",
+	"MAIN_UMFUTURE", "UMFUTURE_MAIN",
+	"MAIN_CMFUTURE", "CMFUTURE_MAIN",
+	"FUNDING_MARGIN", "MARGIN_FUNDING",
+	"FUNDING_UMFUTURE", "UMFUTURE_FUNDING",
+	"FUNDING_CMFUTURE", "CMFUTURE_FUNDING",
+	"MARGIN_UMFUTURE", "UMFUTURE_MARGIN",
+	"MARGIN_CMFUTURE", "CMFUTURE_MARGIN",
+}
+
+// transferWallets maps the wallet names used in universal transfer types to wallets
+var transferWallets = map[string]string{
+	"MAIN":             WalletSpot,
+	"FUNDING":          WalletFunding,
+	"MARGIN":           WalletMargin,
+	"ISOLATEDMARGIN":   WalletIsolatedMargin,
+	"UMFUTURE":         WalletUSDTFuture,
+	"CMFUTURE":         WalletCoinFuture,
+	"OPTION":           WalletOption,
+	"PORTFOLIO_MARGIN": WalletPortfolioMargin,
+}
+
+// Deposits reads credited deposits of the account owning wallet, labelled account
+func Deposits(wallet *spot.WalletClient, account string) Source {
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		deposits, err := wallet.Deposits(start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		var events []Event
+		for _, deposit := range deposits {
+			if !deposit.Status.Credited() {
+				continue
+			}
+			event, err := fromDeposit(account, deposit)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		return events, nil
+	})
+}
+
+func fromDeposit(account string, deposit spot.Deposit) (Event, error) {
+	amount, err := utils.ParseAmount(deposit.Amount)
+	if err != nil {
+		return Event{}, fmt.Errorf("deposit %s: %w", deposit.ID, err)
+	}
+	return Event{
+		Source:     "deposits",
+		Type:       EventDeposit,
+		Account:    account,
+		Wallet:     walletOf(deposit.WalletType),
+		Asset:      deposit.Coin,
+		Amount:     amount,
+		ExternalID: deposit.ID,
+		Time:       time.UnixMilli(deposit.InsertTime).UTC(),
+	}, nil
+}
+
+// Withdrawals reads withdrawals of the account owning wallet, labelled
+// account, except those cancelled, rejected or failed
+func Withdrawals(wallet *spot.WalletClient, account string) Source {
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		withdrawals, err := wallet.Withdrawals(start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		var events []Event
+		for _, withdrawal := range withdrawals {
+			switch withdrawal.Status {
+			case spot.WithdrawStatusCancelled, spot.WithdrawStatusRejected, spot.WithdrawStatusFailure:
+				continue
+			}
+			event, err := fromWithdrawal(account, withdrawal)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		return events, nil
+	})
+}
+
+func fromWithdrawal(account string, withdrawal spot.Withdrawal) (Event, error) {
+	amount, err := utils.ParseAmount(withdrawal.Amount)
+	if err != nil {
+		return Event{}, fmt.Errorf("withdrawal %s: %w", withdrawal.ID, err)
+	}
+	fee, err := utils.ParseAmount(withdrawal.TransactionFee)
+	if err != nil {
+		return Event{}, fmt.Errorf("withdrawal %s: %w", withdrawal.ID, err)
+	}
+	applied, err := time.Parse(time.DateTime, withdrawal.ApplyTime)
+	if err != nil {
+		return Event{}, fmt.Errorf("withdrawal %s: invalid apply time %q: %w", withdrawal.ID, withdrawal.ApplyTime, err)
+	}
+	return Event{
+		Source:       "withdrawals",
+		Type:         EventWithdrawal,
+		Account:      account,
+		Wallet:       walletOf(withdrawal.WalletType),
+		Asset:        withdrawal.Coin,
+		Amount:       -amount,
+		Fee:          fee,
+		FeeAsset:     withdrawal.Coin,
+		Counterparty: withdrawal.Address,
+		ExternalID:   withdrawal.ID,
+		Time:         applied,
+	}, nil
+}
+
+// UniversalTransfers reads confirmed transfers between the wallets of the
+// account owning wallet, labelled account, of the given types, e.g.
+// "MAIN_UMFUTURE", or of UniversalTransferTypes when none are given
+func UniversalTransfers(wallet *spot.WalletClient, account string, transferTypes ...string) Source {
+	if len(transferTypes) == 0 {
+		transferTypes = UniversalTransferTypes
+	}
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		var events []Event
+		for _, transferType := range transferTypes {
+			from, to, err := splitTransferType(transferType)
+			if err != nil {
+				return nil, err
+			}
+			transfers, err := utils.CollectWindows(start, end, transferSpan, 0, func(windowStart, windowEnd time.Time) ([]spot.UniversalTransfer, error) {
+				return utils.CollectPages(walletPage, func(page int) ([]spot.UniversalTransfer, error) {
+					response, err := wallet.UniversalTransferHistory(transferType, map[string]interface{}{
+						"startTime": windowStart.UnixMilli(),
+						"endTime":   windowEnd.UnixMilli(),
+						"current":   page,
+						"size":      walletPage,
+					})
+					if err != nil {
+						return nil, err
+					}
+					var result spot.UniversalTransfers
+					if err := client.ParseResponse(response, &result); err != nil {
+						return nil, fmt.Errorf("failed to parse universal transfer history: %w", err)
+					}
+					return result.Rows, nil
+				})
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, transfer := range transfers {
+				if transfer.Status != "CONFIRMED" {
+					continue
+				}
+				amount, err := utils.ParseAmount(transfer.Amount)
+				if err != nil {
+					return nil, fmt.Errorf("transfer %d: %w", transfer.TranID, err)
+				}
+				events = append(events, transferEvents("universalTransfers", transfer.TranID, transfer.Timestamp,
+					account, from, account, to, transfer.Asset, amount)...)
+			}
+		}
+		return events, nil
+	})
+}
+
+// SubAccountUniversalTransfers reads successful transfers between the master
+// account and its sub-accounts from the master's universal transfer history;
+// the master account is labelled master
+func SubAccountUniversalTransfers(wallet *spot.WalletClient, master string) Source {
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		transfers, err := utils.CollectWindows(start, end, transferSpan, 0, func(from, to time.Time) ([]spot.SubAccountUniversalTransfer, error) {
+			return utils.CollectPages(transferPage, func(page int) ([]spot.SubAccountUniversalTransfer, error) {
+				response, err := wallet.MasterSubAccountTransferHistory(map[string]interface{}{
+					"startTime": from.UnixMilli(),
+					"endTime":   to.UnixMilli(),
+					"page":      page,
+					"limit":     transferPage,
+				})
+				if err != nil {
+					return nil, err
+				}
+				var result spot.SubAccountUniversalTransfers
+				if err := client.ParseResponse(response, &result); err != nil {
+					return nil, fmt.Errorf("failed to parse sub-account universal transfer history: %w", err)
+				}
+				return result.Result, nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var events []Event
+		for _, transfer := range transfers {
+			if transfer.Status != "SUCCESS" {
+				continue
+			}
+			amount, err := utils.ParseAmount(transfer.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("transfer %d: %w", transfer.TranID, err)
+			}
+			events = append(events, transferEvents("subAccountUniversalTransfers", transfer.TranID, transfer.CreateTimeStamp,
+				accountOr(transfer.FromEmail, master), transfer.FromAccountType,
+				accountOr(transfer.ToEmail, master), transfer.ToAccountType,
+				transfer.Asset, amount)...)
+		}
+		return events, nil
+	})
+}
+
+// SubAccountFuturesTransfers reads futures transfers of a sub-account, where
+// futuresType is 1 for USDⓈ-M and 2 for COIN-M futures
+//
+// The history names the master account by its email; transfers from or to
+// masterEmail are labelled master, as in SubAccountUniversalTransfers.
+func SubAccountFuturesTransfers(subAccounts *spot.SubAccountClient, email string, futuresType int, masterEmail, master string) Source {
+	wallet := WalletUSDTFuture
+	if futuresType == 2 {
+		wallet = WalletCoinFuture
+	}
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		transfers, err := utils.CollectWindows(start, end, transferSpan, 0, func(from, to time.Time) ([]spot.SubAccountInternalTransfer, error) {
+			return utils.CollectPages(transferPage, func(page int) ([]spot.SubAccountInternalTransfer, error) {
+				response, err := subAccounts.SubAccountFuturesAssetTransferHistory(email, futuresType, map[string]interface{}{
+					"startTime": from.UnixMilli(),
+					"endTime":   to.UnixMilli(),
+					"page":      page,
+					"limit":     transferPage,
+				})
+				if err != nil {
+					return nil, err
+				}
+				var result spot.SubAccountFuturesTransfers
+				if err := client.ParseResponse(response, &result); err != nil {
+					return nil, fmt.Errorf("failed to parse sub-account futures transfer history: %w", err)
+				}
+				return result.Transfers, nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return internalTransferEvents("subAccountFuturesTransfers", transfers, wallet, masterEmail, master)
+	})
+}
+
+// SubAccountSpotTransfers reads successful spot transfers between the master
+// account and sub-accounts
+//
+// The history names the master account by its email; transfers from or to
+// masterEmail are labelled master, so a transfer also read by
+// SubAccountUniversalTransfers with the same label is kept once.
+func SubAccountSpotTransfers(subAccounts *spot.SubAccountClient, masterEmail, master string) Source {
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		transfers, err := utils.CollectWindows(start, end, transferSpan, 0, func(from, to time.Time) ([]spot.SubAccountInternalTransfer, error) {
+			return utils.CollectPages(transferPage, func(page int) ([]spot.SubAccountInternalTransfer, error) {
+				response, err := subAccounts.SubAccountSpotTransferHistory(map[string]interface{}{
+					"startTime": from.UnixMilli(),
+					"endTime":   to.UnixMilli(),
+					"page":      page,
+					"limit":     transferPage,
+				})
+				if err != nil {
+					return nil, err
+				}
+				var records []spot.SubAccountInternalTransfer
+				if err := client.ParseResponse(response, &records); err != nil {
+					return nil, fmt.Errorf("failed to parse sub-account spot transfer history: %w", err)
+				}
+				return records, nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return internalTransferEvents("subAccountSpotTransfers", transfers, WalletSpot, masterEmail, master)
+	})
+}
+
+func internalTransferEvents(source string, transfers []spot.SubAccountInternalTransfer, wallet, masterEmail, master string) ([]Event, error) {
+	label := func(email string) string {
+		if masterEmail != "" && strings.EqualFold(email, masterEmail) {
+			return master
+		}
+		return email
+	}
+
+	var events []Event
+	for _, transfer := range transfers {
+		if transfer.Status != "" && transfer.Status != "SUCCESS" {
+			continue
+		}
+		amount, err := utils.ParseAmount(transfer.Qty)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", transfer.TranID, err)
+		}
+		events = append(events, transferEvents(source, transfer.TranID, transfer.Time,
+			label(transfer.From), wallet, label(transfer.To), wallet, transfer.Asset, amount)...)
+	}
+	return events, nil
+}
+
+// transferEvents returns the debit and credit of a transfer
+func transferEvents(source string, tranID, timestamp int64, fromAccount, fromWallet, toAccount, toWallet, asset string, amount float64) []Event {
+	id := strconv.FormatInt(tranID, 10)
+	at := time.UnixMilli(timestamp).UTC()
+
+	// The counterparty of a move between wallets of one account is the other wallet
+	fromCounterparty, toCounterparty := toAccount, fromAccount
+	if fromAccount == toAccount {
+		fromCounterparty, toCounterparty = toWallet, fromWallet
+	}
+	return []Event{
+		{
+			Source:       source,
+			Type:         EventTransfer,
+			Account:      fromAccount,
+			Wallet:       fromWallet,
+			Asset:        asset,
+			Amount:       -amount,
+			Counterparty: fromCounterparty,
+			ExternalID:   id,
+			Time:         at,
+		},
+		{
+			Source:       source,
+			Type:         EventTransfer,
+			Account:      toAccount,
+			Wallet:       toWallet,
+			Asset:        asset,
+			Amount:       amount,
+			Counterparty: toCounterparty,
+			ExternalID:   id,
+			Time:         at,
+		},
+	}
+}
+
+// Trades reads spot trades of the account owning wallet, labelled account, in
+// the given symbols, using rules to find each symbol's base and quote asset
+func Trades(wallet *spot.WalletClient, rules *spot.ExchangeRules, account string, symbols ...string) Source {
+	return SourceFunc(func(start, end time.Time) ([]Event, error) {
+		var events []Event
+		for _, symbol := range symbols {
+			info, err := rules.Symbol(symbol)
+			if err != nil {
+				return nil, err
+			}
+			trades, err := wallet.Trades(symbol, start, end)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, trade := range trades {
+				legs, err := fromTrade(account, trade, info.BaseAsset, info.QuoteAsset)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, legs...)
+			}
+		}
+		return events, nil
+	})
+}
+
+// fromTrade returns the base and quote legs of a trade
+// The commission is charged on the leg of its asset, or the base leg when it
+// is paid in a third asset such as BNB.
+func fromTrade(account string, trade spot.AccountTrade, base, quote string) ([]Event, error) {
+	qty, err := utils.ParseAmount(trade.Qty)
+	if err != nil {
+		return nil, fmt.Errorf("trade %s %d: %w", trade.Symbol, trade.ID, err)
+	}
+	quoteQty, err := utils.ParseAmount(trade.QuoteQty)
+	if err != nil {
+		return nil, fmt.Errorf("trade %s %d: %w", trade.Symbol, trade.ID, err)
+	}
+	commission, err := utils.ParseAmount(trade.Commission)
+	if err != nil {
+		return nil, fmt.Errorf("trade %s %d: %w", trade.Symbol, trade.ID, err)
+	}
+	if !trade.IsBuyer {
+		qty, quoteQty = -qty, -quoteQty
+	}
+
+	id := trade.Symbol + ":" + strconv.FormatInt(trade.ID, 10)
+	at := time.UnixMilli(trade.Time).UTC()
+	legs := []Event{
+		{Asset: base, Amount: qty},
+		{Asset: quote, Amount: -quoteQty},
+	}
+	feeLeg := 0
+	if strings.EqualFold(trade.CommissionAsset, quote) {
+		feeLeg = 1
+	}
+	for i := range legs {
+		legs[i].Source = "trades"
+		legs[i].Type = EventTrade
+		legs[i].Account = account
+		legs[i].Wallet = WalletSpot
+		legs[i].Counterparty = trade.Symbol
+		legs[i].ExternalID = id
+		legs[i].Time = at
+	}
+	legs[feeLeg].Fee = commission
+	legs[feeLeg].FeeAsset = trade.CommissionAsset
+	return legs, nil
+}
+
+// splitTransferType returns the source and destination wallets of a universal transfer type
+func splitTransferType(transferType string) (string, string, error) {
+	for name, from := range transferWallets {
+		rest, ok := strings.CutPrefix(transferType, name+"_")
+		if !ok {
+			continue
+		}
+		if to, ok := transferWallets[rest]; ok {
+			return from, to, nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown universal transfer type %q", transferType)
+}
+
+// walletOf returns the wallet of a deposit or withdrawal walletType
+func walletOf(walletType int) string {
+	if walletType == spot.WalletTypeFunding {
+		return WalletFunding
+	}
+	return WalletSpot
+}
+
+// accountOr returns email, or fallback when it is empty
+func accountOr(email, fallback string) string {
+	if email == "" {
+		return fallback
+	}
+	return email
+}
@@ -0,0 +1,208 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/sidan-lab/sidan-binance-go/websocket"
+)
+
+// ErrGap is returned when a depth update does not continue from the book's last update ID
+var ErrGap = errors.New("depth update sequence gap")
+
+// Level is a price level of the order book
+type Level struct {
+	Price    float64
+	Quantity float64
+}
+
+// Book is a local order book of one symbol, safe for concurrent use
+type Book struct {
+	symbol string
+
+	mu           sync.RWMutex
+	lastUpdateID int64
+	synced       bool
+	bids         []Level // sorted by price descending
+	asks         []Level // sorted by price ascending
+}
+
+// NewBook creates an empty, unsynced book
+func NewBook(symbol string) *Book {
+	return &Book{symbol: symbol}
+}
+
+// Symbol returns the symbol of the book
+func (b *Book) Symbol() string {
+	return b.symbol
+}
+
+// Reset replaces the book with a REST depth snapshot and marks it synced
+func (b *Book) Reset(snapshot *websocket.PartialDepth) error {
+	bids, err := parseLevels(snapshot.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseLevels(snapshot.Asks)
+	if err != nil {
+		return err
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids = bids
+	b.asks = asks
+	b.lastUpdateID = snapshot.LastUpdateID
+	b.synced = true
+	return nil
+}
+
+// Apply applies a diff depth update
+//
+// Updates whose final update ID u is not newer than the book are ignored.
+// An update whose first update ID U skips past the book's last update ID + 1
+// returns ErrGap and marks the book unsynced; it must be reset from a new
+// snapshot before further updates are applied.
+func (b *Book) Apply(update *websocket.DepthUpdate) error {
+	bids, err := parseLevels(update.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseLevels(update.Asks)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.synced {
+		return fmt.Errorf("order book %s is not synced", b.symbol)
+	}
+	if update.FinalUpdateID <= b.lastUpdateID {
+		return nil
+	}
+	if update.FirstUpdateID > b.lastUpdateID+1 {
+		b.synced = false
+		return fmt.Errorf("%w: %s expected update %d, got %d-%d",
+			ErrGap, b.symbol, b.lastUpdateID+1, update.FirstUpdateID, update.FinalUpdateID)
+	}
+
+	for _, level := range bids {
+		b.bids = setLevel(b.bids, level, func(a, b float64) bool { return a > b })
+	}
+	for _, level := range asks {
+		b.asks = setLevel(b.asks, level, func(a, b float64) bool { return a < b })
+	}
+	b.lastUpdateID = update.FinalUpdateID
+	return nil
+}
+
+// Synced reports whether the book reflects a snapshot and every update since
+func (b *Book) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// LastUpdateID returns the ID of the last snapshot or update applied
+func (b *Book) LastUpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastUpdateID
+}
+
+// BestBid returns the highest bid, or false if there is none
+func (b *Book) BestBid() (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return Level{}, false
+	}
+	return b.bids[0], true
+}
+
+// BestAsk returns the lowest ask, or false if there is none
+func (b *Book) BestAsk() (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return Level{}, false
+	}
+	return b.asks[0], true
+}
+
+// Bids returns up to depth bids from best to worst; depth <= 0 returns all
+func (b *Book) Bids(depth int) []Level {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return topLevels(b.bids, depth)
+}
+
+// Asks returns up to depth asks from best to worst; depth <= 0 returns all
+func (b *Book) Asks(depth int) []Level {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return topLevels(b.asks, depth)
+}
+
+// MidPrice returns the average of the best bid and ask, or false if either side is empty
+func (b *Book) MidPrice() (float64, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 || len(b.asks) == 0 {
+		return 0, false
+	}
+	return (b.bids[0].Price + b.asks[0].Price) / 2, true
+}
+
+// setLevel inserts, updates or, for a zero quantity, removes a level in a sorted side
+func setLevel(levels []Level, level Level, before func(a, b float64) bool) []Level {
+	i := sort.Search(len(levels), func(i int) bool { return !before(levels[i].Price, level.Price) })
+	found := i < len(levels) && levels[i].Price == level.Price
+
+	switch {
+	case level.Quantity == 0 && found:
+		return append(levels[:i], levels[i+1:]...)
+	case level.Quantity == 0:
+		return levels
+	case found:
+		levels[i].Quantity = level.Quantity
+		return levels
+	default:
+		levels = append(levels, Level{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = level
+		return levels
+	}
+}
+
+func topLevels(levels []Level, depth int) []Level {
+	if depth <= 0 || depth > len(levels) {
+		depth = len(levels)
+	}
+	out := make([]Level, depth)
+	copy(out, levels[:depth])
+	return out
+}
+
+func parseLevels(levels []websocket.PriceLevel) ([]Level, error) {
+	out := make([]Level, 0, len(levels))
+	for _, l := range levels {
+		price, err := strconv.ParseFloat(l.Price(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %q: %w", l.Price(), err)
+		}
+		quantity, err := strconv.ParseFloat(l.Quantity(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q: %w", l.Quantity(), err)
+		}
+		out = append(out, Level{Price: price, Quantity: quantity})
+	}
+	return out, nil
+}
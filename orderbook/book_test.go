@@ -0,0 +1,94 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/websocket"
+)
+
+func testSnapshot() *websocket.PartialDepth {
+	return &websocket.PartialDepth{
+		LastUpdateID: 100,
+		Bids:         []websocket.PriceLevel{{"99.0", "1"}, {"100.0", "2"}, {"98.0", "3"}},
+		Asks:         []websocket.PriceLevel{{"102.0", "1"}, {"101.0", "2"}},
+	}
+}
+
+func TestBookReset(t *testing.T) {
+	book := NewBook("BTCUSDT")
+	if book.Synced() {
+		t.Error("Expected new book to be unsynced")
+	}
+
+	if err := book.Reset(testSnapshot()); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	if bid, _ := book.BestBid(); bid.Price != 100 || bid.Quantity != 2 {
+		t.Errorf("Expected best bid 100@2, got %+v", bid)
+	}
+	if ask, _ := book.BestAsk(); ask.Price != 101 || ask.Quantity != 2 {
+		t.Errorf("Expected best ask 101@2, got %+v", ask)
+	}
+	if mid, _ := book.MidPrice(); mid != 100.5 {
+		t.Errorf("Expected mid price 100.5, got %v", mid)
+	}
+	if bids := book.Bids(2); len(bids) != 2 || bids[1].Price != 99 {
+		t.Errorf("Unexpected top bids: %+v", bids)
+	}
+	if book.LastUpdateID() != 100 || !book.Synced() {
+		t.Errorf("Expected synced book at update 100, got %d", book.LastUpdateID())
+	}
+}
+
+func TestBookApply(t *testing.T) {
+	book := NewBook("BTCUSDT")
+	if err := book.Apply(&websocket.DepthUpdate{FirstUpdateID: 1, FinalUpdateID: 2}); err == nil {
+		t.Error("Expected error applying to an unsynced book, got nil")
+	}
+	book.Reset(testSnapshot())
+
+	// Fully outdated updates are ignored
+	if err := book.Apply(&websocket.DepthUpdate{FirstUpdateID: 90, FinalUpdateID: 100, Bids: []websocket.PriceLevel{{"100.0", "0"}}}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if bid, _ := book.BestBid(); bid.Price != 100 {
+		t.Errorf("Expected outdated update to be ignored, best bid %+v", bid)
+	}
+
+	// An update straddling the last update ID is applied
+	err := book.Apply(&websocket.DepthUpdate{
+		FirstUpdateID: 95,
+		FinalUpdateID: 105,
+		Bids:          []websocket.PriceLevel{{"100.0", "0"}, {"100.5", "4"}, {"98.0", "5"}},
+		Asks:          []websocket.PriceLevel{{"101.0", "0"}, {"103.0", "1"}, {"97.0", "0"}},
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	bids := book.Bids(0)
+	expectedBids := []Level{{100.5, 4}, {99, 1}, {98, 5}}
+	if len(bids) != len(expectedBids) {
+		t.Fatalf("Expected %d bids, got %+v", len(expectedBids), bids)
+	}
+	for i := range bids {
+		if bids[i] != expectedBids[i] {
+			t.Errorf("Expected bid %+v at %d, got %+v", expectedBids[i], i, bids[i])
+		}
+	}
+	asks := book.Asks(0)
+	if len(asks) != 2 || asks[0].Price != 102 || asks[1].Price != 103 {
+		t.Errorf("Unexpected asks: %+v", asks)
+	}
+
+	// A gap marks the book unsynced
+	err = book.Apply(&websocket.DepthUpdate{FirstUpdateID: 107, FinalUpdateID: 110})
+	if !errors.Is(err, ErrGap) {
+		t.Errorf("Expected ErrGap, got %v", err)
+	}
+	if book.Synced() {
+		t.Error("Expected book to be unsynced after a gap")
+	}
+}
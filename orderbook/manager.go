@@ -0,0 +1,302 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/websocket"
+)
+
+const (
+	defaultSnapshotLimit = 1000
+	defaultRetryDelay    = time.Second
+)
+
+// Manager maintains local order books from REST snapshots and diff depth streams
+//
+// For each symbol it follows the documented procedure: buffer diff depth
+// events, fetch a depth snapshot, discard buffered events with u <= the
+// snapshot's lastUpdateId, then apply the rest in sequence. A sequence gap,
+// including one caused by a stream reconnect, triggers a resync.
+type Manager struct {
+	// SnapshotLimit is the depth snapshot size. Default 1000.
+	SnapshotLimit int
+	// Fast subscribes to 100ms depth updates instead of 1000ms.
+	Fast bool
+	// RetryDelay is the wait before retrying a failed snapshot. Default 1s.
+	RetryDelay time.Duration
+	// OnError receives snapshot, sequencing and stream errors. Optional.
+	OnError func(err error)
+	// Stream is the underlying market stream, exposed for configuration before Start.
+	Stream *websocket.MarketStream
+
+	market *spot.MarketClient
+
+	mu    sync.RWMutex
+	books map[string]*Book
+	syncs map[string]*bookSync
+
+	started   bool
+	snapshots chan snapshotResult
+	closed    chan struct{}
+	done      chan struct{}
+}
+
+// bookSync is the synchronization state of one book
+type bookSync struct {
+	buffer   []*websocket.DepthUpdate
+	snapshot *websocket.PartialDepth
+	fetching bool
+}
+
+type snapshotResult struct {
+	symbol   string
+	snapshot *websocket.PartialDepth
+	err      error
+}
+
+// NewManager creates an order book manager fetching snapshots with market
+func NewManager(market *spot.MarketClient) *Manager {
+	return &Manager{
+		SnapshotLimit: defaultSnapshotLimit,
+		RetryDelay:    defaultRetryDelay,
+		Stream:        websocket.NewMarketStream(),
+		market:        market,
+		books:         make(map[string]*Book),
+		syncs:         make(map[string]*bookSync),
+		snapshots:     make(chan snapshotResult),
+		closed:        make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start subscribes to the diff depth streams of symbols and starts syncing their books
+func (m *Manager) Start(symbols ...string) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("at least one symbol is required")
+	}
+
+	streams := make([]string, 0, len(symbols))
+	m.mu.Lock()
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		m.books[symbol] = NewBook(symbol)
+		m.syncs[symbol] = &bookSync{}
+		streams = append(streams, websocket.DiffDepthStream(symbol, m.Fast))
+	}
+	m.mu.Unlock()
+
+	if m.Stream.Options.OnError == nil {
+		m.Stream.Options.OnError = m.reportError
+	}
+	events, err := m.Stream.Start(streams...)
+	if err != nil {
+		return err
+	}
+
+	m.started = true
+	go m.run(events)
+	return nil
+}
+
+// Close stops the stream and the sync loop
+func (m *Manager) Close() {
+	select {
+	case <-m.closed:
+		return
+	default:
+	}
+	close(m.closed)
+	if !m.started {
+		return
+	}
+	m.Stream.Close()
+	<-m.done
+}
+
+// Book returns the book of a symbol, or nil if it is not managed
+func (m *Manager) Book(symbol string) *Book {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.books[strings.ToUpper(symbol)]
+}
+
+// BestBid returns the highest bid of a synced book
+func (m *Manager) BestBid(symbol string) (Level, error) {
+	book, err := m.syncedBook(symbol)
+	if err != nil {
+		return Level{}, err
+	}
+	level, ok := book.BestBid()
+	if !ok {
+		return Level{}, fmt.Errorf("order book %s has no bids", book.Symbol())
+	}
+	return level, nil
+}
+
+// BestAsk returns the lowest ask of a synced book
+func (m *Manager) BestAsk(symbol string) (Level, error) {
+	book, err := m.syncedBook(symbol)
+	if err != nil {
+		return Level{}, err
+	}
+	level, ok := book.BestAsk()
+	if !ok {
+		return Level{}, fmt.Errorf("order book %s has no asks", book.Symbol())
+	}
+	return level, nil
+}
+
+// Depth returns up to depth bids and asks of a synced book
+func (m *Manager) Depth(symbol string, depth int) (bids, asks []Level, err error) {
+	book, err := m.syncedBook(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	return book.Bids(depth), book.Asks(depth), nil
+}
+
+func (m *Manager) syncedBook(symbol string) (*Book, error) {
+	book := m.Book(symbol)
+	if book == nil {
+		return nil, fmt.Errorf("order book %s is not managed", symbol)
+	}
+	if !book.Synced() {
+		return nil, fmt.Errorf("order book %s is not synced", book.Symbol())
+	}
+	return book, nil
+}
+
+// run processes depth events and snapshots on a single goroutine
+func (m *Manager) run(events <-chan websocket.MarketEvent) {
+	defer close(m.done)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if update, ok := event.Data.(*websocket.DepthUpdate); ok {
+				m.handleUpdate(update)
+			}
+		case result := <-m.snapshots:
+			m.handleSnapshot(result)
+		case <-m.closed:
+			return
+		}
+	}
+}
+
+func (m *Manager) handleUpdate(update *websocket.DepthUpdate) {
+	symbol := strings.ToUpper(update.Symbol)
+	book, state := m.Book(symbol), m.syncs[symbol]
+	if book == nil || state == nil {
+		return
+	}
+
+	if book.Synced() {
+		err := book.Apply(update)
+		if err == nil {
+			return
+		}
+		m.reportError(err)
+		if !errors.Is(err, ErrGap) {
+			return
+		}
+		state.snapshot = nil
+	}
+
+	state.buffer = append(state.buffer, update)
+	m.trySync(symbol, book, state)
+}
+
+func (m *Manager) handleSnapshot(result snapshotResult) {
+	book, state := m.Book(result.symbol), m.syncs[result.symbol]
+	state.fetching = false
+	if result.err != nil {
+		m.reportError(fmt.Errorf("failed to fetch %s depth snapshot: %w", result.symbol, result.err))
+		m.fetchSnapshot(result.symbol, state, m.RetryDelay)
+		return
+	}
+	state.snapshot = result.snapshot
+	m.trySync(result.symbol, book, state)
+}
+
+// trySync initializes the book once a snapshot and buffered events are both available
+func (m *Manager) trySync(symbol string, book *Book, state *bookSync) {
+	if state.snapshot == nil {
+		m.fetchSnapshot(symbol, state, 0)
+		return
+	}
+	if len(state.buffer) == 0 {
+		return
+	}
+
+	// The snapshot must not be older than the first buffered event
+	if state.snapshot.LastUpdateID < state.buffer[0].FirstUpdateID {
+		state.snapshot = nil
+		m.fetchSnapshot(symbol, state, 0)
+		return
+	}
+
+	snapshot, buffer := state.snapshot, state.buffer
+	state.snapshot, state.buffer = nil, nil
+
+	if err := book.Reset(snapshot); err != nil {
+		m.reportError(err)
+		m.fetchSnapshot(symbol, state, m.RetryDelay)
+		return
+	}
+	for i, update := range buffer {
+		if err := book.Apply(update); err != nil {
+			m.reportError(err)
+			state.buffer = buffer[i:]
+			m.fetchSnapshot(symbol, state, 0)
+			return
+		}
+	}
+}
+
+// fetchSnapshot requests a depth snapshot in the background after delay
+func (m *Manager) fetchSnapshot(symbol string, state *bookSync, delay time.Duration) {
+	if state.fetching {
+		return
+	}
+	state.fetching = true
+
+	go func() {
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-m.closed:
+				return
+			}
+		}
+
+		result := snapshotResult{symbol: symbol}
+		response, err := m.market.Depth(symbol, map[string]interface{}{
+			"limit": m.SnapshotLimit,
+		})
+		if err == nil {
+			result.snapshot = &websocket.PartialDepth{}
+			err = client.ParseResponse(response, result.snapshot)
+		}
+		result.err = err
+
+		select {
+		case m.snapshots <- result:
+		case <-m.closed:
+		}
+	}()
+}
+
+func (m *Manager) reportError(err error) {
+	if err != nil && m.OnError != nil {
+		m.OnError(err)
+	}
+}
@@ -0,0 +1,103 @@
+package orderbook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func TestManager(t *testing.T) {
+	var mu sync.Mutex
+	snapshots := 0
+
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		snapshots++
+		n := snapshots
+		mu.Unlock()
+
+		if n == 1 {
+			// The first snapshot is older than the first buffered event
+			w.Write([]byte(`{"lastUpdateId":5,"bids":[["100.0","1"]],"asks":[["101.0","1"]]}`))
+			return
+		}
+		w.Write([]byte(`{"lastUpdateId":12,"bids":[["100.0","1"],["99.0","2"]],"asks":[["101.0","1"]]}`))
+	}))
+	defer rest.Close()
+
+	upgrader := gorilla.Upgrader{}
+	events := make(chan string, 10)
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for event := range events {
+			conn.WriteMessage(gorilla.TextMessage, []byte(`{"stream":"btcusdt@depth","data":`+event+`}`))
+		}
+	}))
+	defer ws.Close()
+
+	market := spot.NewMarketClient("", "")
+	market.BaseURL = rest.URL
+
+	manager := NewManager(market)
+	manager.Stream.BaseURL = "ws" + strings.TrimPrefix(ws.URL, "http")
+	if err := manager.Start("BTCUSDT"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer manager.Close()
+
+	if _, err := manager.BestBid("BTCUSDT"); err == nil {
+		t.Error("Expected error before the book is synced, got nil")
+	}
+
+	events <- `{"e":"depthUpdate","E":1,"s":"BTCUSDT","U":10,"u":11,"b":[["98.0","1"]],"a":[]}`
+	events <- `{"e":"depthUpdate","E":2,"s":"BTCUSDT","U":12,"u":13,"b":[["99.0","0"]],"a":[["101.5","3"]]}`
+
+	waitFor(t, func() bool {
+		book := manager.Book("BTCUSDT")
+		return book.Synced() && book.LastUpdateID() == 13
+	})
+
+	bids, asks, err := manager.Depth("BTCUSDT", 10)
+	if err != nil {
+		t.Fatalf("Depth() error = %v", err)
+	}
+	// The event ending at 11 predates the snapshot and is discarded
+	if len(bids) != 1 || bids[0].Price != 100 {
+		t.Errorf("Unexpected bids: %+v", bids)
+	}
+	if len(asks) != 2 || asks[1].Price != 101.5 {
+		t.Errorf("Unexpected asks: %+v", asks)
+	}
+
+	// A gap triggers a resync from a new snapshot
+	events <- `{"e":"depthUpdate","E":3,"s":"BTCUSDT","U":20,"u":21,"b":[],"a":[]}`
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return snapshots >= 3
+	})
+
+	close(events)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Timed out waiting for condition")
+}
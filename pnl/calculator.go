@@ -0,0 +1,221 @@
+package pnl
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+const day = 24 * time.Hour
+
+// Calculator builds PnL reports of the account owning its WalletClient
+//
+// Create one per sub-account with the sub-account's own API key: transfers
+// come from SubAccountTransferHistory, which only answers sub-account keys.
+// AccountSnapshot only covers the last month, which bounds the report period.
+type Calculator struct {
+	// Quote is the asset equity and flows are valued in. Default "BTC".
+	Quote string
+	// AccountTypes are the snapshot account types summed into equity, e.g.
+	// spot.SnapshotSpot and spot.SnapshotFutures. Default spot only.
+	AccountTypes []string
+
+	wallet *spot.WalletClient
+	pricer Pricer
+}
+
+// NewCalculator creates a calculator querying wallet and pricing with pricer
+func NewCalculator(wallet *spot.WalletClient, pricer Pricer) *Calculator {
+	return &Calculator{
+		Quote:        "BTC",
+		AccountTypes: []string{spot.SnapshotSpot},
+		wallet:       wallet,
+		pricer:       pricer,
+	}
+}
+
+// Report computes the PnL from the close of the day before start to the last
+// snapshot before end
+func (c *Calculator) Report(start, end time.Time) (*Report, error) {
+	valuations, err := c.Valuations(start.UTC().Truncate(day).Add(-day), end)
+	if err != nil {
+		return nil, err
+	}
+	if len(valuations) == 0 {
+		return nil, fmt.Errorf("no account snapshots between %s and %s", start.Format(time.DateOnly), end.Format(time.DateOnly))
+	}
+
+	flows, err := c.Flows(valuations[0].Time.Add(time.Millisecond), end)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := Compute(valuations, flows)
+	if err != nil {
+		return nil, err
+	}
+	report.Quote = c.Quote
+	return report, nil
+}
+
+// Valuations returns the end of day equity between start and end, summed over
+// AccountTypes
+//
+// Days missing a snapshot of any account type are skipped rather than valued
+// as if that account were empty.
+func (c *Calculator) Valuations(start, end time.Time) ([]Valuation, error) {
+	totals := make(map[int64]float64)
+	counts := make(map[int64]int)
+	for _, accountType := range c.AccountTypes {
+		snapshots, err := c.wallet.Snapshots(accountType, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snapshot := range snapshots {
+			equity, err := c.equity(accountType, &snapshot)
+			if err != nil {
+				return nil, err
+			}
+			key := snapshot.Day().UnixMilli()
+			totals[key] += equity
+			counts[key]++
+		}
+	}
+
+	var valuations []Valuation
+	for key, total := range totals {
+		if counts[key] != len(c.AccountTypes) {
+			continue
+		}
+		valuations = append(valuations, Valuation{
+			Time:   time.UnixMilli(key).UTC().Add(day - time.Millisecond),
+			Equity: total,
+		})
+	}
+	sort.Slice(valuations, func(i, j int) bool {
+		return valuations[i].Time.Before(valuations[j].Time)
+	})
+	return valuations, nil
+}
+
+// equity values a snapshot in Quote
+func (c *Calculator) equity(accountType string, snapshot *spot.AccountSnapshot) (float64, error) {
+	switch {
+	case snapshot.IsType(spot.SnapshotSpot):
+		data, err := snapshot.Spot()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse spot snapshot: %w", err)
+		}
+		return c.value("BTC", data.TotalAssetOfBtc, snapshot.Day())
+	case snapshot.IsType(spot.SnapshotMargin):
+		data, err := snapshot.Margin()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse margin snapshot: %w", err)
+		}
+		return c.value("BTC", data.TotalNetAssetOfBtc, snapshot.Day())
+	case snapshot.IsType(spot.SnapshotFutures):
+		data, err := snapshot.Futures()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse futures snapshot: %w", err)
+		}
+		var total float64
+		for _, asset := range data.Assets {
+			value, err := c.value(asset.Asset, asset.MarginBalance, snapshot.Day())
+			if err != nil {
+				return 0, err
+			}
+			total += value
+		}
+		return total, nil
+	}
+	return 0, fmt.Errorf("unsupported snapshot type %q for account type %s", snapshot.Type, accountType)
+}
+
+// Flows returns the transfers, credited deposits and withdrawals between
+// start and end, valued in Quote
+func (c *Calculator) Flows(start, end time.Time) ([]Flow, error) {
+	var flows []Flow
+
+	transfers, err := c.wallet.SubAccountTransfers(start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, transfer := range transfers {
+		if transfer.Status != "SUCCESS" {
+			continue
+		}
+		amount, err := utils.ParseAmount(transfer.Qty)
+		if err != nil {
+			return nil, err
+		}
+		kind := FlowTransferIn
+		if transfer.Type == spot.SubAccountTransferOut {
+			kind = FlowTransferOut
+		}
+		flows = append(flows, Flow{
+			ID:     "transfer:" + strconv.FormatInt(transfer.TranID, 10),
+			Time:   time.UnixMilli(transfer.Time).UTC(),
+			Kind:   kind,
+			Asset:  transfer.Asset,
+			Amount: amount,
+		})
+	}
+
+	deposits, err := ledger.Deposits(c.wallet, "").Events(start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, deposit := range deposits {
+		flows = append(flows, Flow{
+			ID:     "deposit:" + deposit.ExternalID,
+			Time:   deposit.Time,
+			Kind:   FlowDeposit,
+			Asset:  deposit.Asset,
+			Amount: deposit.Amount,
+		})
+	}
+
+	withdrawals, err := ledger.Withdrawals(c.wallet, "").Events(start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, withdrawal := range withdrawals {
+		flows = append(flows, Flow{
+			ID:     "withdrawal:" + withdrawal.ExternalID,
+			Time:   withdrawal.Time,
+			Kind:   FlowWithdrawal,
+			Asset:  withdrawal.Asset,
+			Amount: -withdrawal.Amount,
+			Fee:    withdrawal.Fee,
+		})
+	}
+
+	for i := range flows {
+		price, err := c.pricer.Price(flows[i].Asset, c.Quote, flows[i].Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price %s: %w", flows[i].ID, err)
+		}
+		flows[i].Value = flows[i].Amount * price
+		flows[i].FeeValue = flows[i].Fee * price
+	}
+	return flows, nil
+}
+
+// value converts an amount of asset to Quote at the close of day
+func (c *Calculator) value(asset, amount string, day time.Time) (float64, error) {
+	quantity, err := utils.ParseAmount(amount)
+	if err != nil || quantity == 0 {
+		return 0, err
+	}
+	price, err := c.pricer.Price(asset, c.Quote, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to price %s: %w", asset, err)
+	}
+	return quantity * price, nil
+}
@@ -0,0 +1,144 @@
+package pnl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+// fixedPricer prices assets with fixed rates against the quote
+type fixedPricer map[string]float64
+
+func (p fixedPricer) Price(asset, quote string, day time.Time) (float64, error) {
+	if asset == quote {
+		return 1, nil
+	}
+	price, ok := p[asset]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", asset)
+	}
+	return price, nil
+}
+
+func TestCalculatorReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/sapi/v1/accountSnapshot":
+			if query.Get("type") != spot.SnapshotSpot {
+				t.Errorf("Expected spot snapshots, got %s", query.Get("type"))
+			}
+			fmt.Fprintf(w, `{"code":200,"msg":"","snapshotVos":[
+				{"type":"spot","updateTime":%d,"data":{"totalAssetOfBtc":"0.1","balances":[]}},
+				{"type":"spot","updateTime":%d,"data":{"totalAssetOfBtc":"0.2","balances":[]}},
+				{"type":"spot","updateTime":%d,"data":{"totalAssetOfBtc":"0.21","balances":[]}}]}`,
+				endOfDay(1).Add(-time.Second).UnixMilli(), endOfDay(2).Add(-time.Second).UnixMilli(), endOfDay(3).Add(-time.Second).UnixMilli())
+		case "/sapi/v1/sub-account/transfer/subUserHistory":
+			fmt.Fprintf(w, `[{"counterParty":"master","email":"master@test.com","type":1,"asset":"USDT","qty":"4000","status":"SUCCESS","tranId":1,"time":%d},
+				{"counterParty":"master","email":"master@test.com","type":2,"asset":"USDT","qty":"100","status":"FAILURE","tranId":2,"time":%d}]`,
+				endOfDay(2).Add(-time.Hour).UnixMilli(), endOfDay(2).Add(-time.Hour).UnixMilli())
+		case "/sapi/v1/capital/deposit/hisrec":
+			w.Write([]byte(`[]`))
+		case "/sapi/v1/capital/withdraw/history":
+			fmt.Fprintf(w, `[{"id":"w1","amount":"0.01","transactionFee":"0.0001","coin":"BTC","status":6,"applyTime":"%s"},
+				{"id":"w2","amount":"1","transactionFee":"0","coin":"BTC","status":1,"applyTime":"%s"}]`,
+				endOfDay(3).Add(-time.Hour).Format(time.DateTime), endOfDay(3).Add(-time.Hour).Format(time.DateTime))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	calculator := NewCalculator(wallet, fixedPricer{"USDT": 0.000025})
+	report, err := calculator.Report(endOfDay(2), endOfDay(3))
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if report.Quote != "BTC" || !almostEqual(report.StartEquity, 0.1) || !almostEqual(report.EndEquity, 0.21) {
+		t.Errorf("Unexpected equity %+v", report)
+	}
+	if len(report.Flows) != 2 {
+		t.Fatalf("Expected the successful transfer and withdrawal, got %+v", report.Flows)
+	}
+	// 4000 USDT in at 0.000025 is 0.1 BTC; 0.01 BTC withdrawn
+	if !almostEqual(report.Inflows, 0.1) || !almostEqual(report.Outflows, 0.01) || !almostEqual(report.Fees, 0.0001) {
+		t.Errorf("Unexpected flows in %v out %v fees %v", report.Inflows, report.Outflows, report.Fees)
+	}
+	if !almostEqual(report.PnL, 0.02) {
+		t.Errorf("Expected PnL 0.02, got %v", report.PnL)
+	}
+	if len(report.Curve) != 3 || !report.Curve[1].Time.Equal(endOfDay(2)) {
+		t.Errorf("Unexpected curve %+v", report.Curve)
+	}
+}
+
+func TestKlinePricer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		closes := map[string]string{"BTCUSDT": "40000", "ETHBTC": "0.05"}
+		price, ok := closes[symbol]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		open, _ := strconv.ParseInt(r.URL.Query().Get("startTime"), 10, 64)
+		fmt.Fprintf(w, `[[%d,"1","1","1","%s","1",%d,"1",1,"1","1","0"]]`, open, price, open+day.Milliseconds()-1)
+	}))
+	defer server.Close()
+
+	market := spot.NewMarketClient("", "")
+	market.BaseURL = server.URL
+	pricer := NewKlinePricer(market)
+
+	tests := []struct {
+		asset, quote string
+		want         float64
+	}{
+		{"BTC", "BTC", 1},
+		{"BTC", "USDT", 40000},
+		{"USDT", "BTC", 1.0 / 40000},
+		{"ETH", "BTC", 0.05},
+		{"ETH", "USDT", 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.asset+tt.quote, func(t *testing.T) {
+			price, err := pricer.Price(tt.asset, tt.quote, endOfDay(1))
+			if err != nil {
+				t.Fatalf("Price() error = %v", err)
+			}
+			if !almostEqual(price, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, price)
+			}
+		})
+	}
+
+	if _, err := pricer.Price("XYZ", "USDT", endOfDay(1)); err == nil {
+		t.Error("Expected error for an asset without markets")
+	}
+}
+
+func TestCalculatorLive(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	secretKey := os.Getenv("BINANCE_SECRET_KEY")
+	if apiKey == "" || secretKey == "" {
+		t.Skip("Skipping live test: BINANCE_API_KEY or BINANCE_SECRET_KEY not set")
+	}
+
+	calculator := NewCalculator(spot.NewWalletClient(apiKey, secretKey), NewKlinePricer(spot.NewMarketClient("", "")))
+	report, err := calculator.Report(time.Now().AddDate(0, 0, -7), time.Now())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	t.Logf("PnL %.8f %s, TWR %.4f%% over %d days", report.PnL, report.Quote, report.TimeWeightedReturn*100, len(report.Curve)-1)
+}
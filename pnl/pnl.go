@@ -0,0 +1,174 @@
+// Package pnl computes the profit and loss of an account over a period
+//
+// Equity comes from the daily AccountSnapshot valuations and external flows
+// from sub-account transfers, deposits and withdrawals. Profit is the change
+// in equity not explained by flows, and the time-weighted return chains daily
+// returns so that the timing and size of flows do not distort it.
+package pnl
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FlowKind is the source of a Flow
+type FlowKind string
+
+const (
+	FlowTransferIn  FlowKind = "TRANSFER_IN"
+	FlowTransferOut FlowKind = "TRANSFER_OUT"
+	FlowDeposit     FlowKind = "DEPOSIT"
+	FlowWithdrawal  FlowKind = "WITHDRAWAL"
+)
+
+// Inflow reports whether flows of this kind add capital to the account
+func (k FlowKind) Inflow() bool {
+	return k == FlowTransferIn || k == FlowDeposit
+}
+
+// Flow is capital moved into or out of the account
+type Flow struct {
+	ID     string // e.g. "transfer:11798835829"
+	Time   time.Time
+	Kind   FlowKind
+	Asset  string
+	Amount float64 // always positive, in Asset
+	// Fee is the withdrawal fee in Asset. It is paid on top of Amount, so it
+	// reduces profit rather than counting as a flow.
+	Fee float64
+	// Value is Amount in the report's quote asset, priced at the close of the flow's day
+	Value float64
+	// FeeValue is Fee in the report's quote asset
+	FeeValue float64
+}
+
+// Net returns Value signed by direction: positive for inflows, negative for outflows
+func (f Flow) Net() float64 {
+	if f.Kind.Inflow() {
+		return f.Value
+	}
+	return -f.Value
+}
+
+// Valuation is the equity of the account at the end of a day, in the report's quote asset
+type Valuation struct {
+	Time   time.Time
+	Equity float64
+}
+
+// EquityPoint is one day of the equity curve
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+	// NetFlow is the net flow since the previous point
+	NetFlow float64
+	// CumulativeFlow is the net flow since the start of the report
+	CumulativeFlow float64
+	// PnL is the profit since the start of the report
+	PnL float64
+	// Return is the return since the previous point
+	Return float64
+	// CumulativeReturn is the time-weighted return since the start of the report
+	CumulativeReturn float64
+}
+
+// Report is the profit and loss of an account between two valuations
+//
+// Start and End are the times of the opening and closing valuations. Flows at
+// or before Start are part of the opening equity and are not counted.
+type Report struct {
+	Start       time.Time
+	End         time.Time
+	Quote       string
+	StartEquity float64
+	EndEquity   float64
+	Inflows     float64
+	Outflows    float64
+	NetFlow     float64
+	// Fees is the value of withdrawal fees paid, already included in PnL
+	Fees float64
+	// PnL is the absolute return: EndEquity - StartEquity - NetFlow
+	PnL float64
+	// TimeWeightedReturn chains the daily returns of Curve, e.g. 0.05 for 5%
+	TimeWeightedReturn float64
+	Curve              []EquityPoint
+	Flows              []Flow
+}
+
+// Compute builds a report from end of day valuations and the flows between them
+//
+// Flows are assumed to arrive at the start of the day they fall in, so a day's
+// return is its closing equity over the previous closing equity plus the flows.
+// Days that start with no capital have a return of 0.
+func Compute(valuations []Valuation, flows []Flow) (*Report, error) {
+	if len(valuations) == 0 {
+		return nil, fmt.Errorf("at least one valuation is required")
+	}
+
+	valuations = append([]Valuation(nil), valuations...)
+	sort.Slice(valuations, func(i, j int) bool {
+		return valuations[i].Time.Before(valuations[j].Time)
+	})
+	flows = append([]Flow(nil), flows...)
+	sort.SliceStable(flows, func(i, j int) bool {
+		return flows[i].Time.Before(flows[j].Time)
+	})
+
+	opening := valuations[0]
+	closing := valuations[len(valuations)-1]
+	report := &Report{
+		Start:       opening.Time,
+		End:         closing.Time,
+		StartEquity: opening.Equity,
+		EndEquity:   closing.Equity,
+	}
+
+	curve := []EquityPoint{{Time: opening.Time, Equity: opening.Equity}}
+	growth := 1.0
+	next := 0
+	// Skip flows already reflected in the opening equity
+	for next < len(flows) && !flows[next].Time.After(opening.Time) {
+		next++
+	}
+	for _, valuation := range valuations[1:] {
+		previous := curve[len(curve)-1]
+
+		var netFlow float64
+		for next < len(flows) && !flows[next].Time.After(valuation.Time) {
+			flow := flows[next]
+			netFlow += flow.Net()
+			if flow.Kind.Inflow() {
+				report.Inflows += flow.Value
+			} else {
+				report.Outflows += flow.Value
+			}
+			report.Fees += flow.FeeValue
+			report.Flows = append(report.Flows, flow)
+			next++
+		}
+
+		var dailyReturn float64
+		if capital := previous.Equity + netFlow; capital > 0 {
+			dailyReturn = valuation.Equity/capital - 1
+		}
+		growth *= 1 + dailyReturn
+
+		cumulativeFlow := previous.CumulativeFlow + netFlow
+		curve = append(curve, EquityPoint{
+			Time:             valuation.Time,
+			Equity:           valuation.Equity,
+			NetFlow:          netFlow,
+			CumulativeFlow:   cumulativeFlow,
+			PnL:              valuation.Equity - opening.Equity - cumulativeFlow,
+			Return:           dailyReturn,
+			CumulativeReturn: growth - 1,
+		})
+	}
+
+	report.NetFlow = report.Inflows - report.Outflows
+	report.PnL = report.EndEquity - report.StartEquity - report.NetFlow
+	report.TimeWeightedReturn = growth - 1
+	report.Curve = curve
+	return report, nil
+}
@@ -0,0 +1,100 @@
+package pnl
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+// endOfDay returns the time of the snapshot of the nth day of January 2024
+func endOfDay(n int) time.Time {
+	return time.Date(2024, 1, n, 23, 59, 59, 999e6, time.UTC)
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCompute(t *testing.T) {
+	valuations := []Valuation{
+		{Time: endOfDay(3), Equity: 1.0},
+		{Time: endOfDay(1), Equity: 1.0},
+		{Time: endOfDay(2), Equity: 1.65},
+		{Time: endOfDay(4), Equity: 1.0},
+	}
+	flows := []Flow{
+		// Already part of the opening equity
+		{ID: "deposit:0", Time: endOfDay(1).Add(-time.Hour), Kind: FlowDeposit, Value: 5},
+		{ID: "deposit:1", Time: endOfDay(2).Add(-time.Hour), Kind: FlowDeposit, Value: 0.5},
+		{ID: "withdrawal:1", Time: endOfDay(4).Add(-time.Hour), Kind: FlowWithdrawal, Value: 0.7, FeeValue: 0.01},
+	}
+
+	report, err := Compute(valuations, flows)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+
+	if !report.Start.Equal(endOfDay(1)) || !report.End.Equal(endOfDay(4)) {
+		t.Errorf("Unexpected period %s - %s", report.Start, report.End)
+	}
+	if !almostEqual(report.Inflows, 0.5) || !almostEqual(report.Outflows, 0.7) || !almostEqual(report.NetFlow, -0.2) {
+		t.Errorf("Unexpected flows in %.4f out %.4f net %.4f", report.Inflows, report.Outflows, report.NetFlow)
+	}
+	if !almostEqual(report.PnL, 0.2) {
+		t.Errorf("Expected PnL 0.2, got %v", report.PnL)
+	}
+	if !almostEqual(report.Fees, 0.01) {
+		t.Errorf("Expected fees 0.01, got %v", report.Fees)
+	}
+	if len(report.Flows) != 2 {
+		t.Errorf("Expected 2 flows in the period, got %d", len(report.Flows))
+	}
+
+	// Day 2: deposit at the start, 1.0 + 0.5 grows to 1.65 (+10%)
+	// Day 3: 1.65 falls to 1.0 (-39.39%)
+	// Day 4: 1.0 - 0.7 withdrawn grows to 1.0
+	wantReturns := []float64{0, 0.1, 1.0/1.65 - 1, 1.0/0.3 - 1}
+	if len(report.Curve) != len(wantReturns) {
+		t.Fatalf("Expected %d points, got %d", len(wantReturns), len(report.Curve))
+	}
+	growth := 1.0
+	for i, want := range wantReturns {
+		point := report.Curve[i]
+		growth *= 1 + want
+		if !almostEqual(point.Return, want) || !almostEqual(point.CumulativeReturn, growth-1) {
+			t.Errorf("Point %d: expected return %v cumulative %v, got %v %v", i, want, growth-1, point.Return, point.CumulativeReturn)
+		}
+	}
+	if !almostEqual(report.TimeWeightedReturn, growth-1) {
+		t.Errorf("Expected TWR %v, got %v", growth-1, report.TimeWeightedReturn)
+	}
+	last := report.Curve[len(report.Curve)-1]
+	if !almostEqual(last.PnL, report.PnL) || !almostEqual(last.CumulativeFlow, report.NetFlow) {
+		t.Errorf("Expected the last point to match the report, got %+v", last)
+	}
+}
+
+func TestComputeFundedFromZero(t *testing.T) {
+	report, err := Compute(
+		[]Valuation{{Time: endOfDay(1)}, {Time: endOfDay(2), Equity: 2}, {Time: endOfDay(3), Equity: 2.2}},
+		[]Flow{{Time: endOfDay(2).Add(-time.Hour), Kind: FlowTransferIn, Value: 2}},
+	)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if !almostEqual(report.TimeWeightedReturn, 0.1) || !almostEqual(report.PnL, 0.2) {
+		t.Errorf("Expected TWR 0.1 and PnL 0.2, got %v %v", report.TimeWeightedReturn, report.PnL)
+	}
+
+	if _, err := Compute(nil, nil); err == nil {
+		t.Error("Expected error without valuations")
+	}
+}
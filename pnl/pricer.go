@@ -0,0 +1,131 @@
+package pnl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+// Pricer prices an asset in a quote asset at the close of a UTC day
+type Pricer interface {
+	Price(asset, quote string, day time.Time) (float64, error)
+}
+
+// bridgeAsset is used to price pairs without a market of their own
+const bridgeAsset = "BTC"
+
+// KlinePricer prices assets with the daily close of spot markets
+//
+// An asset is priced with the ASSETQUOTE market, the inverse of QUOTEASSET,
+// or through BTC when neither exists. Closes are cached, so pricing many
+// flows of the same asset costs one klines request per 1000 days.
+type KlinePricer struct {
+	market *spot.MarketClient
+
+	mu     sync.Mutex
+	closes map[string]map[int64]float64 // symbol -> day in milliseconds -> close
+}
+
+// NewKlinePricer creates a pricer querying klines with market
+func NewKlinePricer(market *spot.MarketClient) *KlinePricer {
+	return &KlinePricer{
+		market: market,
+		closes: make(map[string]map[int64]float64),
+	}
+}
+
+// Price returns the close of asset in quote on day
+func (p *KlinePricer) Price(asset, quote string, day time.Time) (float64, error) {
+	asset, quote = strings.ToUpper(asset), strings.ToUpper(quote)
+	if asset == quote {
+		return 1, nil
+	}
+
+	price, err := p.pair(asset, quote, day)
+	if !isInvalidSymbol(err) {
+		return price, err
+	}
+	if asset == bridgeAsset || quote == bridgeAsset {
+		return 0, fmt.Errorf("no market to price %s in %s", asset, quote)
+	}
+
+	bridge, err := p.pair(asset, bridgeAsset, day)
+	if err != nil {
+		return 0, fmt.Errorf("no market to price %s in %s: %w", asset, quote, err)
+	}
+	rate, err := p.pair(bridgeAsset, quote, day)
+	if err != nil {
+		return 0, fmt.Errorf("no market to price %s in %s: %w", asset, quote, err)
+	}
+	return bridge * rate, nil
+}
+
+// pair prices asset in quote with the direct or inverse market
+func (p *KlinePricer) pair(asset, quote string, day time.Time) (float64, error) {
+	price, err := p.close(asset+quote, day)
+	if !isInvalidSymbol(err) {
+		return price, err
+	}
+	inverse, err := p.close(quote+asset, day)
+	if err != nil {
+		return 0, err
+	}
+	if inverse == 0 {
+		return 0, fmt.Errorf("%s%s closed at 0 on %s", quote, asset, day.Format(time.DateOnly))
+	}
+	return 1 / inverse, nil
+}
+
+// close returns the daily close of a symbol, fetching up to 1000 days from day on a miss
+func (p *KlinePricer) close(symbol string, day time.Time) (float64, error) {
+	start := day.UTC().Truncate(24 * time.Hour).UnixMilli()
+
+	p.mu.Lock()
+	price, ok := p.closes[symbol][start]
+	p.mu.Unlock()
+	if ok {
+		return price, nil
+	}
+
+	response, err := p.market.Klines(symbol, "1d", map[string]interface{}{
+		"startTime": start,
+		"limit":     1000,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var klines []spot.Kline
+	if err := client.ParseResponse(response, &klines); err != nil {
+		return 0, fmt.Errorf("failed to parse klines: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closes[symbol] == nil {
+		p.closes[symbol] = make(map[int64]float64)
+	}
+	for _, kline := range klines {
+		value, err := strconv.ParseFloat(kline.Close, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid close %q of %s: %w", kline.Close, symbol, err)
+		}
+		p.closes[symbol][kline.OpenTime] = value
+	}
+	price, ok = p.closes[symbol][start]
+	if !ok {
+		return 0, fmt.Errorf("no %s kline on %s", symbol, day.Format(time.DateOnly))
+	}
+	return price, nil
+}
+
+// isInvalidSymbol reports whether err is Binance rejecting an unknown symbol
+func isInvalidSymbol(err error) bool {
+	var apiErr *client.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == -1121
+}
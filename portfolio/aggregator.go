@@ -0,0 +1,349 @@
+package portfolio
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Largest page sizes of the sub-account summaries
+const (
+	summaryPageSize = 20
+	managedPageSize = 20
+)
+
+// Futures types of SubAccountFuturesAccountSummary
+const (
+	futuresUSDT = 1
+	futuresCoin = 2
+)
+
+// balanceWallets maps the wallet names of WalletClient.Balance to wallets
+var balanceWallets = map[string]string{
+	"Spot":            ledger.WalletSpot,
+	"Funding":         ledger.WalletFunding,
+	"Cross Margin":    ledger.WalletMargin,
+	"Isolated Margin": ledger.WalletIsolatedMargin,
+	"USDⓈ-M Futures":  ledger.WalletUSDTFuture,
+	"COIN-M Futures":  ledger.WalletCoinFuture,
+	"Options":         ledger.WalletOption,
+	"Earn":            WalletEarn,
+	"Trading Bots":    WalletTradingBots,
+	"Copy Trading":    WalletCopyTrading,
+}
+
+// Aggregator values the master account and its sub-accounts
+//
+// The master spot wallet is read asset by asset with UserAsset and its other
+// wallets as totals with Balance. Sub-accounts are read from the spot, margin
+// and futures summaries, which only report totals, and managed sub-accounts
+// asset by asset with ManagedSubAccountAssets.
+type Aggregator struct {
+	// Quote is the asset everything is valued in. Default "USDT".
+	Quote string
+	// Master labels the master account's holdings. Default "master".
+	Master string
+	// SubAccounts includes the sub-account summaries. Default true.
+	SubAccounts bool
+	// ManagedSubAccounts includes the managed sub-accounts of an investor
+	// account. Default true.
+	ManagedSubAccounts bool
+
+	wallet      *spot.WalletClient
+	subAccounts *spot.SubAccountClient
+	pricer      Pricer
+	now         func() time.Time
+}
+
+// NewAggregator creates an aggregator querying the master account with wallet
+// and subAccounts and pricing with pricer
+func NewAggregator(wallet *spot.WalletClient, subAccounts *spot.SubAccountClient, pricer Pricer) *Aggregator {
+	return &Aggregator{
+		Quote:              "USDT",
+		Master:             "master",
+		SubAccounts:        true,
+		ManagedSubAccounts: true,
+		wallet:             wallet,
+		subAccounts:        subAccounts,
+		pricer:             pricer,
+		now:                time.Now,
+	}
+}
+
+// Report reads every holding and values it in Quote
+//
+// A balance without a market in Quote is valued with the BTC valuation the
+// API reports for it when there is one, and listed in Unpriced otherwise.
+func (a *Aggregator) Report() (*Report, error) {
+	holdings, err := a.Holdings()
+	if err != nil {
+		return nil, err
+	}
+
+	var valued, unpriced []Holding
+	for _, holding := range holdings {
+		value, ok, err := a.value(holding)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			unpriced = append(unpriced, holding)
+			continue
+		}
+		holding.Value = value
+		valued = append(valued, holding)
+	}
+	return Aggregate(a.Quote, a.now(), valued, unpriced), nil
+}
+
+// Holdings reads the non-zero holdings of every account, not yet valued
+func (a *Aggregator) Holdings() ([]Holding, error) {
+	holdings, err := a.masterHoldings()
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make(map[string]bool)
+	if a.ManagedSubAccounts {
+		records, err := a.managedHoldings()
+		if err != nil {
+			return nil, err
+		}
+		for _, holding := range records {
+			managed[holding.Account] = true
+		}
+		holdings = append(holdings, records...)
+	}
+
+	if a.SubAccounts {
+		records, err := a.subAccountHoldings()
+		if err != nil {
+			return nil, err
+		}
+		for _, holding := range records {
+			// Managed sub-accounts are already read asset by asset
+			if managed[holding.Account] && holding.Wallet == ledger.WalletSpot {
+				continue
+			}
+			holdings = append(holdings, holding)
+		}
+	}
+	return holdings, nil
+}
+
+// masterHoldings reads the master spot balances and the totals of its other wallets
+func (a *Aggregator) masterHoldings() ([]Holding, error) {
+	var holdings []Holding
+
+	response, err := a.wallet.UserAsset(map[string]interface{}{"needBtcValuation": "true"})
+	if err != nil {
+		return nil, err
+	}
+	var assets []spot.UserAsset
+	if err := client.ParseResponse(response, &assets); err != nil {
+		return nil, fmt.Errorf("failed to parse user assets: %w", err)
+	}
+	for _, asset := range assets {
+		amount, err := sum(asset.Free, asset.Locked, asset.Freeze, asset.Withdrawing)
+		if err != nil {
+			return nil, err
+		}
+		btcValue, err := utils.ParseAmount(asset.BtcValuation)
+		if err != nil {
+			return nil, err
+		}
+		holdings = appendHolding(holdings, Holding{Account: a.Master, Wallet: ledger.WalletSpot, Asset: asset.Asset, Amount: amount, btcValue: btcValue})
+	}
+
+	response, err = a.wallet.Balance(map[string]interface{}{"quoteAsset": "BTC"})
+	if err != nil {
+		return nil, err
+	}
+	var balances []spot.WalletBalance
+	if err := client.ParseResponse(response, &balances); err != nil {
+		return nil, fmt.Errorf("failed to parse wallet balances: %w", err)
+	}
+	for _, balance := range balances {
+		wallet := walletOf(balance.WalletName)
+		if wallet == ledger.WalletSpot || !balance.Activate {
+			continue
+		}
+		amount, err := utils.ParseAmount(balance.Balance)
+		if err != nil {
+			return nil, err
+		}
+		holdings = appendHolding(holdings, Holding{Account: a.Master, Wallet: wallet, Asset: "BTC", Amount: amount, Total: true})
+	}
+	return holdings, nil
+}
+
+// subAccountHoldings reads the spot, margin and futures totals of every sub-account
+func (a *Aggregator) subAccountHoldings() ([]Holding, error) {
+	var holdings []Holding
+
+	spotTotals, err := utils.CollectPages(summaryPageSize, func(page int) ([]spot.SubAccountSpotTotal, error) {
+		response, err := a.subAccounts.SubAccountSpotSummary(map[string]interface{}{"page": page, "size": summaryPageSize})
+		if err != nil {
+			return nil, err
+		}
+		var summary spot.SubAccountSpotSummary
+		if err := client.ParseResponse(response, &summary); err != nil {
+			return nil, fmt.Errorf("failed to parse sub-account spot summary: %w", err)
+		}
+		return summary.SpotSubUserAssetBtcVoList, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, total := range spotTotals {
+		amount, err := utils.ParseAmount(total.TotalAsset)
+		if err != nil {
+			return nil, err
+		}
+		holdings = appendHolding(holdings, Holding{Account: total.Email, Wallet: ledger.WalletSpot, Asset: "BTC", Amount: amount, Total: true})
+	}
+
+	response, err := a.subAccounts.SubAccountMarginAccountSummary(nil)
+	if err != nil {
+		return nil, err
+	}
+	var margin spot.SubAccountMarginSummary
+	if err := client.ParseResponse(response, &margin); err != nil {
+		return nil, fmt.Errorf("failed to parse sub-account margin summary: %w", err)
+	}
+	for _, total := range margin.SubAccountList {
+		amount, err := utils.ParseAmount(total.TotalNetAssetOfBtc)
+		if err != nil {
+			return nil, err
+		}
+		holdings = appendHolding(holdings, Holding{Account: total.Email, Wallet: ledger.WalletMargin, Asset: "BTC", Amount: amount, Total: true})
+	}
+
+	for _, futuresType := range []int{futuresUSDT, futuresCoin} {
+		totals, err := utils.CollectPages(summaryPageSize, func(page int) ([]spot.SubAccountFuturesTotal, error) {
+			response, err := a.subAccounts.SubAccountFuturesAccountSummary(futuresType, map[string]interface{}{"page": page, "limit": summaryPageSize})
+			if err != nil {
+				return nil, err
+			}
+			var summary spot.SubAccountFuturesSummary
+			if err := client.ParseResponse(response, &summary); err != nil {
+				return nil, fmt.Errorf("failed to parse sub-account futures summary: %w", err)
+			}
+			totals := summary.FutureAccountSummaryResp
+			if futuresType == futuresCoin {
+				totals = summary.DeliveryAccountSummaryResp
+			}
+			if totals == nil {
+				return nil, nil
+			}
+			return totals.SubAccountList, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		wallet := ledger.WalletUSDTFuture
+		if futuresType == futuresCoin {
+			wallet = ledger.WalletCoinFuture
+		}
+		for _, total := range totals {
+			amount, err := utils.ParseAmount(total.TotalMarginBalance)
+			if err != nil {
+				return nil, err
+			}
+			holdings = appendHolding(holdings, Holding{Account: total.Email, Wallet: wallet, Asset: total.Asset, Amount: amount, Total: true})
+		}
+	}
+	return holdings, nil
+}
+
+// managedHoldings reads the balances of every managed sub-account
+func (a *Aggregator) managedHoldings() ([]Holding, error) {
+	accounts, err := utils.CollectPages(managedPageSize, func(page int) ([]spot.ManagedSubAccount, error) {
+		response, err := a.subAccounts.QueryManagedSubAccountList(map[string]interface{}{"page": page, "limit": managedPageSize})
+		if err != nil {
+			return nil, err
+		}
+		var list spot.ManagedSubAccounts
+		if err := client.ParseResponse(response, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse managed sub-accounts: %w", err)
+		}
+		return list.ManagerSubUserInfoVoList, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var holdings []Holding
+	for _, account := range accounts {
+		response, err := a.subAccounts.ManagedSubAccountAssets(account.Email, nil)
+		if err != nil {
+			return nil, err
+		}
+		var assets []spot.ManagedSubAccountAsset
+		if err := client.ParseResponse(response, &assets); err != nil {
+			return nil, fmt.Errorf("failed to parse managed sub-account assets of %s: %w", account.Email, err)
+		}
+		for _, asset := range assets {
+			amount, err := utils.ParseAmount(asset.TotalBalance)
+			if err != nil {
+				return nil, err
+			}
+			btcValue, err := utils.ParseAmount(asset.BtcValue)
+			if err != nil {
+				return nil, err
+			}
+			holdings = appendHolding(holdings, Holding{Account: account.Email, Wallet: ledger.WalletSpot, Asset: asset.Coin, Amount: amount, btcValue: btcValue})
+		}
+	}
+	return holdings, nil
+}
+
+// value converts a holding to Quote, falling back to its BTC valuation
+func (a *Aggregator) value(holding Holding) (float64, bool, error) {
+	price, err := a.pricer.Price(holding.Asset, a.Quote)
+	if err == nil {
+		return holding.Amount * price, true, nil
+	}
+	if holding.btcValue == 0 {
+		return 0, false, nil
+	}
+	btc, err := a.pricer.Price("BTC", a.Quote)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to price BTC in %s: %w", a.Quote, err)
+	}
+	return holding.btcValue * btc, true, nil
+}
+
+// appendHolding appends holding unless it is empty
+func appendHolding(holdings []Holding, holding Holding) []Holding {
+	if holding.Amount == 0 {
+		return holdings
+	}
+	return append(holdings, holding)
+}
+
+// walletOf maps a Balance wallet name to a wallet, e.g. "Cross Margin" to ledger.WalletMargin
+func walletOf(name string) string {
+	if wallet, ok := balanceWallets[name]; ok {
+		return wallet
+	}
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+}
+
+// sum parses and adds decimal strings
+func sum(values ...string) (float64, error) {
+	var total float64
+	for _, value := range values {
+		amount, err := utils.ParseAmount(value)
+		if err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+	return total, nil
+}
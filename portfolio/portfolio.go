@@ -0,0 +1,102 @@
+// Package portfolio values the holdings of a master account and its sub-accounts
+//
+// An Aggregator reads every wallet balance and sub-account summary the API
+// offers, values them in one quote asset at current market prices and breaks
+// the total down by account, wallet and asset. Some endpoints only report a
+// wallet's total valued in BTC or USD rather than its balances, so that part
+// of the total cannot be attributed to an asset.
+package portfolio
+
+import (
+	"sort"
+	"time"
+)
+
+// Wallets without a ledger counterpart, named like the ledger wallets
+const (
+	WalletEarn        = "EARN"
+	WalletTradingBots = "TRADING_BOTS"
+	WalletCopyTrading = "COPY_TRADING"
+)
+
+// Holding is a balance of one account wallet
+type Holding struct {
+	Account string // sub-account email, or Aggregator.Master for the master account
+	Wallet  string // a ledger wallet such as ledger.WalletSpot, or one of the wallets above
+	Asset   string
+	Amount  float64
+	// Total is set when the endpoint only reports the wallet's total: Amount
+	// is then the whole wallet valued in Asset, e.g. BTC, not a balance of it
+	Total bool
+	// Value is Amount in the report's quote asset
+	Value float64
+
+	// btcValue is the API's own BTC valuation, used when Asset has no market
+	btcValue float64
+}
+
+// Report is the valuation of every holding in one quote asset
+type Report struct {
+	Quote string
+	Time  time.Time
+	Total float64
+	// ByAccount, ByWallet and ByAsset break Total down. ByAsset only covers
+	// holdings that are balances; the wallet totals are in Unattributed.
+	ByAccount    map[string]float64
+	ByWallet     map[string]float64
+	ByAsset      map[string]float64
+	Unattributed float64
+	Holdings     []Holding
+	// Unpriced are the holdings without a market in Quote, left out of Total
+	Unpriced []Holding
+}
+
+// Accounts returns the accounts in the report, largest value first
+func (r *Report) Accounts() []string {
+	return ranked(r.ByAccount)
+}
+
+// Assets returns the assets in the report, largest value first
+func (r *Report) Assets() []string {
+	return ranked(r.ByAsset)
+}
+
+// Aggregate sums valued holdings into a report
+// Holdings in unpriced are listed in the report but not counted.
+func Aggregate(quote string, at time.Time, holdings, unpriced []Holding) *Report {
+	report := &Report{
+		Quote:     quote,
+		Time:      at,
+		ByAccount: make(map[string]float64),
+		ByWallet:  make(map[string]float64),
+		ByAsset:   make(map[string]float64),
+		Holdings:  holdings,
+		Unpriced:  unpriced,
+	}
+	for _, holding := range holdings {
+		report.Total += holding.Value
+		report.ByAccount[holding.Account] += holding.Value
+		report.ByWallet[holding.Wallet] += holding.Value
+		if holding.Total {
+			report.Unattributed += holding.Value
+		} else {
+			report.ByAsset[holding.Asset] += holding.Value
+		}
+	}
+	return report
+}
+
+// ranked returns the keys of values, largest value first
+func ranked(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if values[keys[i]] != values[keys[j]] {
+			return values[keys[i]] > values[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,199 @@
+package portfolio
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// newTestServer serves the wallet, sub-account and ticker endpoints of a
+// master with one sub-account and one managed sub-account
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v3/ticker/price":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","price":"50000"},{"symbol":"ETHBTC","price":"0.05"},{"symbol":"USDCUSDT","price":"1"},{"symbol":"DEADBTC","price":"0"}]`))
+		case "/sapi/v3/asset/getUserAsset":
+			if query.Get("needBtcValuation") != "true" {
+				t.Errorf("Expected BTC valuations, got %s", query.Get("needBtcValuation"))
+			}
+			w.Write([]byte(`[{"asset":"BTC","free":"1","locked":"0.5","freeze":"0","withdrawing":"0","btcValuation":"1.5"},
+				{"asset":"ETH","free":"10","locked":"0","freeze":"0","withdrawing":"0","btcValuation":"0.5"},
+				{"asset":"DEAD","free":"100","locked":"0","freeze":"0","withdrawing":"0","btcValuation":"0.001"},
+				{"asset":"NOPE","free":"3","locked":"0","freeze":"0","withdrawing":"0","btcValuation":"0"}]`))
+		case "/sapi/v1/asset/wallet/balance":
+			w.Write([]byte(`[{"activate":true,"balance":"1.5","walletName":"Spot"},
+				{"activate":true,"balance":"0.1","walletName":"Funding"},
+				{"activate":true,"balance":"0","walletName":"Cross Margin"},
+				{"activate":true,"balance":"0.2","walletName":"Trading Bots"},
+				{"activate":false,"balance":"9","walletName":"Options"}]`))
+		case "/sapi/v1/sub-account/spotSummary":
+			w.Write([]byte(`{"totalCount":2,"masterAccountTotalAsset":"2","spotSubUserAssetBtcVoList":[
+				{"email":"sub@test.com","totalAsset":"0.3"},{"email":"managed@test.com","totalAsset":"0.4"}]}`))
+		case "/sapi/v1/sub-account/margin/accountSummary":
+			w.Write([]byte(`{"totalAssetOfBtc":"0.2","totalLiabilityOfBtc":"0.1","totalNetAssetOfBtc":"0.1","subAccountList":[
+				{"email":"sub@test.com","totalAssetOfBtc":"0.2","totalLiabilityOfBtc":"0.1","totalNetAssetOfBtc":"0.1"}]}`))
+		case "/sapi/v2/sub-account/futures/accountSummary":
+			if query.Get("futuresType") == "1" {
+				w.Write([]byte(`{"futureAccountSummaryResp":{"totalMarginBalance":"1000","asset":"USD","subAccountList":[
+					{"email":"sub@test.com","totalMarginBalance":"1000","totalWalletBalance":"990","asset":"USD"}]}}`))
+			} else {
+				w.Write([]byte(`{"deliveryAccountSummaryResp":{"totalMarginBalanceOfBTC":"0","asset":"BTC","subAccountList":[]}}`))
+			}
+		case "/sapi/v1/managed-subaccount/info":
+			w.Write([]byte(`{"total":1,"managerSubUserInfoVoList":[{"email":"managed@test.com","isSubUserEnabled":true}]}`))
+		case "/sapi/v1/managed-subaccount/asset":
+			if query.Get("email") != "managed@test.com" {
+				t.Errorf("Unexpected managed sub-account %s", query.Get("email"))
+			}
+			w.Write([]byte(`[{"coin":"USDC","name":"USD Coin","totalBalance":"2000","availableBalance":"2000","inOrder":"0","btcValue":"0.04"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestAggregatorReport(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	subAccounts := spot.NewSubAccountClient("test_key", "test_secret")
+	subAccounts.BaseURL = server.URL
+	market := spot.NewMarketClient("", "")
+	market.BaseURL = server.URL
+
+	aggregator := NewAggregator(wallet, subAccounts, NewTickerPricer(market))
+	report, err := aggregator.Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Master spot: 1.5 BTC + 10 ETH + DEAD at its BTC valuation; NOPE is unpriced
+	masterSpot := 1.5*50000 + 10*0.05*50000 + 0.001*50000
+	// Master totals: funding and trading bots
+	masterTotals := (0.1 + 0.2) * 50000
+	// Sub-account: spot, margin, USDⓈ-M futures in USD
+	sub := 0.3*50000 + 0.1*50000 + 1000
+	// Managed sub-account replaces its spot summary entry
+	managed := 2000.0
+
+	if report.Quote != "USDT" || !almostEqual(report.Total, masterSpot+masterTotals+sub+managed) {
+		t.Errorf("Unexpected total %.2f %s", report.Total, report.Quote)
+	}
+	if !almostEqual(report.ByAccount["master"], masterSpot+masterTotals) || !almostEqual(report.ByAccount["sub@test.com"], sub) || !almostEqual(report.ByAccount["managed@test.com"], managed) {
+		t.Errorf("Unexpected accounts %v", report.ByAccount)
+	}
+	if !almostEqual(report.ByWallet[ledger.WalletUSDTFuture], 1000) || !almostEqual(report.ByWallet[WalletTradingBots], 0.2*50000) || report.ByWallet[ledger.WalletOption] != 0 {
+		t.Errorf("Unexpected wallets %v", report.ByWallet)
+	}
+	if !almostEqual(report.ByAsset["ETH"], 25000) || !almostEqual(report.ByAsset["USDC"], 2000) || !almostEqual(report.Unattributed, masterTotals+sub) {
+		t.Errorf("Unexpected assets %v, unattributed %.2f", report.ByAsset, report.Unattributed)
+	}
+	if len(report.Unpriced) != 1 || report.Unpriced[0].Asset != "NOPE" {
+		t.Errorf("Expected NOPE to be unpriced, got %+v", report.Unpriced)
+	}
+	if accounts := report.Accounts(); accounts[0] != "master" {
+		t.Errorf("Expected master first, got %v", accounts)
+	}
+}
+
+func TestAggregatorMasterOnly(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	market := spot.NewMarketClient("", "")
+	market.BaseURL = server.URL
+
+	aggregator := NewAggregator(wallet, nil, NewTickerPricer(market))
+	aggregator.Quote = "BTC"
+	aggregator.SubAccounts = false
+	aggregator.ManagedSubAccounts = false
+	report, err := aggregator.Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.ByAccount) != 1 || !almostEqual(report.Total, 1.5+0.5+0.001+0.3) {
+		t.Errorf("Unexpected master-only report %.8f %v", report.Total, report.ByAccount)
+	}
+}
+
+func TestTickerPricer(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"symbol":"BTCUSDT","price":"50000"},{"symbol":"ETHBTC","price":"0.05"},{"symbol":"BNBUSDT","price":"500"}]`))
+	}))
+	defer server.Close()
+
+	market := spot.NewMarketClient("", "")
+	market.BaseURL = server.URL
+	pricer := NewTickerPricer(market)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pricer.now = func() time.Time { return now }
+
+	cases := []struct {
+		asset, quote string
+		price        float64
+	}{
+		{"BTC", "USDT", 50000},
+		{"USDT", "BTC", 1.0 / 50000},
+		{"eth", "usdt", 2500},
+		{"BNB", "BTC", 500.0 / 50000},
+		{"USD", "USDT", 1},
+		{"BTC", "USD", 50000},
+	}
+	for _, c := range cases {
+		price, err := pricer.Price(c.asset, c.quote)
+		if err != nil || !almostEqual(price, c.price) {
+			t.Errorf("Price(%s, %s) = %v, %v; expected %v", c.asset, c.quote, price, err, c.price)
+		}
+	}
+	if _, err := pricer.Price("XYZ", "USDT"); err == nil {
+		t.Error("Expected error for an asset without a market")
+	}
+	if requests != 1 {
+		t.Errorf("Expected prices to be fetched once, got %d requests", requests)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := pricer.Price("BTC", "USDT"); err != nil || requests != 2 {
+		t.Errorf("Expected stale prices to be refetched, got %d requests, err %v", requests, err)
+	}
+}
+
+func TestAggregatorLive(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping live test: BINANCE_API_KEY or BINANCE_SECRET_KEY not set")
+	}
+
+	aggregator := NewAggregator(spot.NewWalletClient(apiKey, apiSecret), spot.NewSubAccountClient(apiKey, apiSecret), NewTickerPricer(spot.NewMarketClient("", "")))
+	report, err := aggregator.Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Total %.2f %s across %d accounts", report.Total, report.Quote, len(report.ByAccount))
+}
@@ -0,0 +1,134 @@
+package portfolio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+// Pricer prices an asset in a quote asset at the current market price
+type Pricer interface {
+	Price(asset, quote string) (float64, error)
+}
+
+// bridgeAssets price pairs without a market of their own, in order of preference
+var bridgeAssets = []string{"BTC", "USDT"}
+
+// aliases are assets reported by the API that trade under another name
+// The USDⓈ-M futures summaries are in "USD", which is valued as USDT.
+var aliases = map[string]string{
+	"USD": "USDT",
+}
+
+// TickerPricer prices assets with the latest price of spot markets
+//
+// An asset is priced with the ASSETQUOTE market, the inverse of QUOTEASSET,
+// or through BTC or USDT when neither exists. Every symbol's price is fetched
+// in one request and reused until it is older than MaxAge.
+type TickerPricer struct {
+	// MaxAge is how long fetched prices are used. Default 1 minute.
+	MaxAge time.Duration
+
+	market *spot.MarketClient
+	now    func() time.Time
+
+	mu      sync.Mutex
+	prices  map[string]float64
+	fetched time.Time
+}
+
+// NewTickerPricer creates a pricer querying ticker prices with market
+func NewTickerPricer(market *spot.MarketClient) *TickerPricer {
+	return &TickerPricer{
+		MaxAge: time.Minute,
+		market: market,
+		now:    time.Now,
+	}
+}
+
+// Price returns the latest price of asset in quote
+func (p *TickerPricer) Price(asset, quote string) (float64, error) {
+	asset, quote = normalize(asset), normalize(quote)
+	if asset == quote {
+		return 1, nil
+	}
+	prices, err := p.load()
+	if err != nil {
+		return 0, err
+	}
+
+	if price, ok := pair(prices, asset, quote); ok {
+		return price, nil
+	}
+	for _, bridge := range bridgeAssets {
+		if bridge == asset || bridge == quote {
+			continue
+		}
+		first, ok := pair(prices, asset, bridge)
+		if !ok {
+			continue
+		}
+		if second, ok := pair(prices, bridge, quote); ok {
+			return first * second, nil
+		}
+	}
+	return 0, fmt.Errorf("no market to price %s in %s", asset, quote)
+}
+
+// load returns every symbol's price, fetching them when missing or older than MaxAge
+func (p *TickerPricer) load() (map[string]float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.prices != nil && p.now().Sub(p.fetched) < p.MaxAge {
+		return p.prices, nil
+	}
+
+	response, err := p.market.TickerPrice(nil)
+	if err != nil {
+		return nil, err
+	}
+	var tickers []spot.SymbolPrice
+	if err := client.ParseResponse(response, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to parse ticker prices: %w", err)
+	}
+	prices := make(map[string]float64, len(tickers))
+	for _, ticker := range tickers {
+		price, err := strconv.ParseFloat(ticker.Price, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %q of %s: %w", ticker.Price, ticker.Symbol, err)
+		}
+		if price > 0 {
+			prices[ticker.Symbol] = price
+		}
+	}
+	p.prices = prices
+	p.fetched = p.now()
+	return prices, nil
+}
+
+// pair prices asset in quote with the direct or inverse market
+func pair(prices map[string]float64, asset, quote string) (float64, bool) {
+	if asset == quote {
+		return 1, true
+	}
+	if price, ok := prices[asset+quote]; ok {
+		return price, true
+	}
+	if inverse, ok := prices[quote+asset]; ok {
+		return 1 / inverse, true
+	}
+	return 0, false
+}
+
+func normalize(asset string) string {
+	asset = strings.ToUpper(asset)
+	if alias, ok := aliases[asset]; ok {
+		return alias
+	}
+	return asset
+}
@@ -0,0 +1,260 @@
+// Package preflight reports which SDK methods an API key is allowed to call
+//
+// Binance rejects calls the key lacks a permission for only at request time,
+// e.g. SubAccountUniversalTransfer without "internal transfer". Run fetches
+// the key's restrictions and status once, at startup, and checks every REST
+// client method against the permissions it requires.
+package preflight
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/cmfutures"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/umfutures"
+)
+
+// clientTypes are the REST clients whose methods are checked, with the
+// permissions their methods require unless listed in requirements
+var clientTypes = []struct {
+	name     string
+	typ      reflect.Type
+	defaults []spot.Permission
+}{
+	{"spot.MarketClient", reflect.TypeOf(&spot.MarketClient{}), nil},
+	{"spot.TradeClient", reflect.TypeOf(&spot.TradeClient{}), []spot.Permission{spot.PermissionReading}},
+	{"spot.MarginClient", reflect.TypeOf(&spot.MarginClient{}), []spot.Permission{spot.PermissionReading}},
+	{"spot.WalletClient", reflect.TypeOf(&spot.WalletClient{}), []spot.Permission{spot.PermissionReading}},
+	{"spot.SubAccountClient", reflect.TypeOf(&spot.SubAccountClient{}), []spot.Permission{spot.PermissionReading}},
+	{"spot.UserDataStreamClient", reflect.TypeOf(&spot.UserDataStreamClient{}), nil},
+	{"umfutures.UMFuturesClient", reflect.TypeOf(&umfutures.UMFuturesClient{}), []spot.Permission{spot.PermissionReading, spot.PermissionFutures}},
+	{"cmfutures.CMFuturesClient", reflect.TypeOf(&cmfutures.CMFuturesClient{}), []spot.Permission{spot.PermissionReading, spot.PermissionFutures}},
+}
+
+var (
+	none      = []spot.Permission{}
+	spotTrade = []spot.Permission{spot.PermissionReading, spot.PermissionSpotAndMarginTrading}
+	// Margin orders need trading; borrowing and repaying need the margin loan permission
+	marginTrade   = []spot.Permission{spot.PermissionReading, spot.PermissionSpotAndMarginTrading, spot.PermissionMargin}
+	marginLoan    = []spot.Permission{spot.PermissionReading, spot.PermissionMargin}
+	futuresTrade  = []spot.Permission{spot.PermissionReading, spot.PermissionFutures}
+	withdraw      = []spot.Permission{spot.PermissionReading, spot.PermissionWithdrawals}
+	internalMoves = []spot.Permission{spot.PermissionReading, spot.PermissionInternalTransfer}
+)
+
+// requirements are the methods whose permissions differ from their client's defaults
+var requirements = map[string][]spot.Permission{
+	"spot.WalletClient.SystemStatus": none,
+	"spot.WalletClient.Withdraw":     withdraw,
+
+	"spot.TradeClient.NewOrder":          spotTrade,
+	"spot.TradeClient.NewOrderTest":      spotTrade,
+	"spot.TradeClient.CancelOrder":       spotTrade,
+	"spot.TradeClient.CancelOpenOrders":  spotTrade,
+	"spot.TradeClient.CancelAndReplace":  spotTrade,
+	"spot.TradeClient.NewOCOOrder":       spotTrade,
+	"spot.TradeClient.NewOrderListOCO":   spotTrade,
+	"spot.TradeClient.NewOrderListOTO":   spotTrade,
+	"spot.TradeClient.NewOrderListOTOCO": spotTrade,
+	"spot.TradeClient.CancelOrderList":   spotTrade,
+
+	"spot.MarginClient.NewOrder":         marginTrade,
+	"spot.MarginClient.CancelOrder":      marginTrade,
+	"spot.MarginClient.CancelOpenOrders": marginTrade,
+	"spot.MarginClient.Borrow":           marginLoan,
+	"spot.MarginClient.Repay":            marginLoan,
+
+	"spot.SubAccountClient.SubAccountFuturesTransfer":      internalMoves,
+	"spot.SubAccountClient.SubAccountMarginTransfer":       internalMoves,
+	"spot.SubAccountClient.SubAccountTransferToSub":        internalMoves,
+	"spot.SubAccountClient.SubAccountTransferToMaster":     internalMoves,
+	"spot.SubAccountClient.SubAccountFuturesAssetTransfer": internalMoves,
+	"spot.SubAccountClient.SubAccountUniversalTransfer":    internalMoves,
+	"spot.SubAccountClient.ManagedSubAccountDeposit":       internalMoves,
+	"spot.SubAccountClient.ManagedSubAccountWithdraw":      internalMoves,
+
+	"umfutures.UMFuturesClient.FundingRateHistory": none,
+	"umfutures.UMFuturesClient.PremiumIndex":       none,
+	"umfutures.UMFuturesClient.FundingInfo":        none,
+	"umfutures.UMFuturesClient.NewOrder":           futuresTrade,
+	"umfutures.UMFuturesClient.NewOrderTest":       futuresTrade,
+	"umfutures.UMFuturesClient.CancelOrder":        futuresTrade,
+	"umfutures.UMFuturesClient.CancelOpenOrders":   futuresTrade,
+
+	"cmfutures.CMFuturesClient.ExchangeInfo":       none,
+	"cmfutures.CMFuturesClient.Contract":           none,
+	"cmfutures.CMFuturesClient.PremiumIndex":       none,
+	"cmfutures.CMFuturesClient.FundingRateHistory": none,
+	"cmfutures.CMFuturesClient.Basis":              none,
+	"cmfutures.CMFuturesClient.ContinuousKlines":   none,
+	"cmfutures.CMFuturesClient.NewOrder":           futuresTrade,
+	"cmfutures.CMFuturesClient.CancelOrder":        futuresTrade,
+	"cmfutures.CMFuturesClient.CancelOpenOrders":   futuresTrade,
+}
+
+// Requirements returns the permissions required by every REST client method,
+// keyed by "package.Client.Method", e.g. "spot.WalletClient.Withdraw"
+func Requirements() map[string][]spot.Permission {
+	// Methods promoted from the embedded client.Client are transport helpers, not endpoints
+	embedded := make(map[string]bool)
+	clientType := reflect.TypeOf(&client.Client{})
+	for i := 0; i < clientType.NumMethod(); i++ {
+		embedded[clientType.Method(i).Name] = true
+	}
+
+	methods := make(map[string][]spot.Permission)
+	for _, c := range clientTypes {
+		for i := 0; i < c.typ.NumMethod(); i++ {
+			name := c.typ.Method(i).Name
+			if embedded[name] {
+				continue
+			}
+			key := c.name + "." + name
+			if required, ok := requirements[key]; ok {
+				methods[key] = required
+			} else {
+				methods[key] = c.defaults
+			}
+		}
+	}
+	return methods
+}
+
+// MethodCheck is the result of checking one method against the key's permissions
+type MethodCheck struct {
+	Method   string
+	Required []spot.Permission
+	Missing  []spot.Permission
+}
+
+// Allowed reports whether the key has every permission the method requires
+func (c MethodCheck) Allowed() bool {
+	return len(c.Missing) == 0
+}
+
+// Report is the result of Run
+type Report struct {
+	Restrictions  spot.APIRestrictions
+	AccountStatus string
+	// TradingLocked is set while the quantitative trading rules lock the key
+	// out of spot and margin trading, until PlannedRecoverTime.
+	TradingLocked      bool
+	PlannedRecoverTime int64
+	SystemMaintenance  bool
+	// Methods holds a check of every REST client method, ordered by name
+	Methods []MethodCheck
+}
+
+// Allowed returns the names of the methods the key can call
+func (r *Report) Allowed() []string {
+	var methods []string
+	for _, check := range r.Methods {
+		if check.Allowed() {
+			methods = append(methods, check.Method)
+		}
+	}
+	return methods
+}
+
+// Denied returns the checks of the methods the key cannot call
+func (r *Report) Denied() []MethodCheck {
+	var denied []MethodCheck
+	for _, check := range r.Methods {
+		if !check.Allowed() {
+			denied = append(denied, check)
+		}
+	}
+	return denied
+}
+
+// Can reports whether the key can call a method, named as in Requirements
+func (r *Report) Can(method string) bool {
+	for _, check := range r.Methods {
+		if check.Method == method {
+			return check.Allowed()
+		}
+	}
+	return false
+}
+
+// Check checks every REST client method against restrictions
+func Check(restrictions spot.APIRestrictions) []MethodCheck {
+	return check(restrictions.Has)
+}
+
+// check checks every REST client method against the permissions reported by has
+func check(has func(spot.Permission) bool) []MethodCheck {
+	var checks []MethodCheck
+	for method, required := range Requirements() {
+		result := MethodCheck{Method: method, Required: required}
+		for _, permission := range required {
+			if !has(permission) {
+				result.Missing = append(result.Missing, permission)
+			}
+		}
+		checks = append(checks, result)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Method < checks[j].Method
+	})
+	return checks
+}
+
+// Run fetches the key's restrictions, account and trading status and the
+// system status, and checks every REST client method against them
+//
+// While the key is locked out of trading, methods requiring spot and margin
+// trading are reported as denied.
+func Run(wallet *spot.WalletClient) (*Report, error) {
+	report := &Report{}
+
+	response, err := wallet.APIRestrictions(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ParseResponse(response, &report.Restrictions); err != nil {
+		return nil, fmt.Errorf("failed to parse API restrictions: %w", err)
+	}
+
+	response, err = wallet.AccountStatus(nil)
+	if err != nil {
+		return nil, err
+	}
+	var account spot.AccountStatus
+	if err := client.ParseResponse(response, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse account status: %w", err)
+	}
+	report.AccountStatus = account.Data
+
+	response, err = wallet.APITradingStatus(nil)
+	if err != nil {
+		return nil, err
+	}
+	var trading spot.APITradingStatus
+	if err := client.ParseResponse(response, &trading); err != nil {
+		return nil, fmt.Errorf("failed to parse API trading status: %w", err)
+	}
+	report.TradingLocked = trading.Data.IsLocked
+	report.PlannedRecoverTime = trading.Data.PlannedRecoverTime
+
+	response, err = wallet.SystemStatus()
+	if err != nil {
+		return nil, err
+	}
+	var system spot.SystemStatus
+	if err := client.ParseResponse(response, &system); err != nil {
+		return nil, fmt.Errorf("failed to parse system status: %w", err)
+	}
+	report.SystemMaintenance = system.Status == spot.SystemStatusMaintenance
+
+	report.Methods = check(func(permission spot.Permission) bool {
+		if permission == spot.PermissionSpotAndMarginTrading && report.TradingLocked {
+			return false
+		}
+		return report.Restrictions.Has(permission)
+	})
+	return report, nil
+}
@@ -0,0 +1,105 @@
+package preflight
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func TestRequirementsCoverMethods(t *testing.T) {
+	methods := Requirements()
+
+	// Every override must name an existing method, so renames cannot silently drop it
+	for method := range requirements {
+		if _, ok := methods[method]; !ok {
+			t.Errorf("Requirement for unknown method %s", method)
+		}
+	}
+
+	tests := []struct {
+		method string
+		want   []spot.Permission
+	}{
+		{"spot.MarketClient.Depth", nil},
+		{"spot.WalletClient.Balance", []spot.Permission{spot.PermissionReading}},
+		{"spot.WalletClient.Withdraw", []spot.Permission{spot.PermissionReading, spot.PermissionWithdrawals}},
+		{"spot.SubAccountClient.SubAccountUniversalTransfer", []spot.Permission{spot.PermissionReading, spot.PermissionInternalTransfer}},
+		{"umfutures.UMFuturesClient.Account", []spot.Permission{spot.PermissionReading, spot.PermissionFutures}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			required, ok := methods[tt.method]
+			if !ok {
+				t.Fatalf("Expected %s to be checked", tt.method)
+			}
+			if !slices.Equal(required, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, required)
+			}
+		})
+	}
+
+	if _, ok := methods["spot.WalletClient.SignRequest"]; ok {
+		t.Error("Expected methods of the embedded client to be excluded")
+	}
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sapi/v1/account/apiRestrictions":
+			w.Write([]byte(`{"ipRestrict":false,"createTime":1698645219000,"enableReading":true,"enableSpotAndMarginTrading":true,"enableWithdrawals":false,"enableInternalTransfer":false,"enableMargin":false,"enableFutures":true,"permitsUniversalTransfer":true,"enableVanillaOptions":false,"enablePortfolioMarginTrading":false}`))
+		case "/sapi/v1/account/status":
+			w.Write([]byte(`{"data":"Normal"}`))
+		case "/sapi/v1/account/apiTradingStatus":
+			w.Write([]byte(`{"data":{"isLocked":true,"plannedRecoverTime":1700000000000,"triggerCondition":{"GCR":150,"IFER":150,"UFR":300},"indicators":{"BTCUSDT":[{"i":"UFR","c":20,"v":0.05,"t":0.995}]},"updateTime":1699999000000}}`))
+		case "/sapi/v1/system/status":
+			if r.URL.Query().Get("signature") != "" {
+				t.Error("Expected system status to be unsigned")
+			}
+			w.Write([]byte(`{"status":0,"msg":"normal"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	report, err := Run(wallet)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.AccountStatus != "Normal" || !report.TradingLocked || report.SystemMaintenance {
+		t.Errorf("Unexpected report status %+v", report)
+	}
+
+	allowed := []string{"spot.WalletClient.Balance", "umfutures.UMFuturesClient.NewOrder", "spot.MarketClient.Ping"}
+	denied := []string{"spot.WalletClient.Withdraw", "spot.SubAccountClient.SubAccountUniversalTransfer", "spot.MarginClient.Borrow", "spot.TradeClient.NewOrder"}
+	for _, method := range allowed {
+		if !report.Can(method) {
+			t.Errorf("Expected %s to be allowed", method)
+		}
+	}
+	for _, method := range denied {
+		if report.Can(method) {
+			t.Errorf("Expected %s to be denied", method)
+		}
+	}
+	if report.Can("spot.WalletClient.Unknown") {
+		t.Error("Expected unknown method to be denied")
+	}
+	if len(report.Allowed())+len(report.Denied()) != len(Requirements()) {
+		t.Error("Expected every method to be either allowed or denied")
+	}
+
+	// Without the trading lock, spot orders are allowed by the same restrictions
+	for _, check := range Check(report.Restrictions) {
+		if check.Method == "spot.TradeClient.NewOrder" && !check.Allowed() {
+			t.Errorf("Expected NewOrder to be allowed, missing %v", check.Missing)
+		}
+	}
+}
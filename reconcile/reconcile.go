@@ -0,0 +1,275 @@
+// Package reconcile checks recorded history against daily account snapshots
+//
+// Each day's expected balance of an asset is the previous snapshot's balance
+// plus the ledger events between the two snapshots. A difference from the next
+// snapshot beyond the tolerance is activity the ledger did not see, e.g. a
+// transfer type without an adapter, dust conversions, interest or rewards.
+package reconcile
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+const defaultTolerance = 1e-8
+
+// snapshotTypes maps the ledger wallets that have snapshots to their AccountSnapshot type
+var snapshotTypes = map[string]string{
+	ledger.WalletSpot:       spot.SnapshotSpot,
+	ledger.WalletMargin:     spot.SnapshotMargin,
+	ledger.WalletUSDTFuture: spot.SnapshotFutures,
+}
+
+// Snapshot is the balance of each asset at the end of a day
+type Snapshot struct {
+	Time     time.Time
+	Balances map[string]float64
+}
+
+// SnapshotBalances returns the balance of each asset in an AccountSnapshot:
+// free plus locked for spot, the net asset for margin and the wallet balance
+// for futures
+func SnapshotBalances(snapshot *spot.AccountSnapshot) (Snapshot, error) {
+	result := Snapshot{
+		Time:     time.UnixMilli(snapshot.UpdateTime).UTC(),
+		Balances: make(map[string]float64),
+	}
+	add := func(asset string, amounts ...string) error {
+		for _, amount := range amounts {
+			value, err := utils.ParseAmount(amount)
+			if err != nil {
+				return fmt.Errorf("%s balance in snapshot: %w", asset, err)
+			}
+			result.Balances[asset] += value
+		}
+		return nil
+	}
+
+	switch {
+	case snapshot.IsType(spot.SnapshotSpot):
+		data, err := snapshot.Spot()
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("failed to parse spot snapshot: %w", err)
+		}
+		for _, balance := range data.Balances {
+			if err := add(balance.Asset, balance.Free, balance.Locked); err != nil {
+				return Snapshot{}, err
+			}
+		}
+	case snapshot.IsType(spot.SnapshotMargin):
+		data, err := snapshot.Margin()
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("failed to parse margin snapshot: %w", err)
+		}
+		for _, asset := range data.UserAssets {
+			if err := add(asset.Asset, asset.NetAsset); err != nil {
+				return Snapshot{}, err
+			}
+		}
+	case snapshot.IsType(spot.SnapshotFutures):
+		data, err := snapshot.Futures()
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("failed to parse futures snapshot: %w", err)
+		}
+		for _, asset := range data.Assets {
+			if err := add(asset.Asset, asset.WalletBalance); err != nil {
+				return Snapshot{}, err
+			}
+		}
+	default:
+		return Snapshot{}, fmt.Errorf("unsupported snapshot type %q", snapshot.Type)
+	}
+	return result, nil
+}
+
+// Difference is an unexplained change of one asset between two snapshots
+type Difference struct {
+	// Start and End are the times of the snapshots the day runs between
+	Start time.Time
+	End   time.Time
+	Asset string
+	// Opening is the balance in the snapshot at Start
+	Opening float64
+	// Recorded is the net change of the ledger events in the day, fees included
+	Recorded float64
+	// Expected is Opening plus Recorded
+	Expected float64
+	// Actual is the balance in the snapshot at End
+	Actual float64
+	// Unexplained is Actual minus Expected
+	Unexplained float64
+}
+
+// Report is the result of reconciling a range of snapshots
+type Report struct {
+	Account string
+	Wallet  string
+	Start   time.Time
+	End     time.Time
+	// Days is the number of snapshot pairs compared
+	Days        int
+	Differences []Difference
+}
+
+// Balanced reports whether every day was explained by the ledger
+func (r *Report) Balanced() bool {
+	return len(r.Differences) == 0
+}
+
+// Reconciler compares ledger events of one account wallet to its snapshots
+type Reconciler struct {
+	// Account and Wallet select the ledger events to replay, e.g. the label
+	// given to the ledger sources and ledger.WalletSpot
+	Account string
+	Wallet  string
+	// Tolerance is the largest absolute difference ignored. Default 1e-8.
+	Tolerance float64
+	// Tolerances overrides Tolerance per asset
+	Tolerances map[string]float64
+}
+
+// NewReconciler creates a reconciler of a wallet of account
+func NewReconciler(account, wallet string) *Reconciler {
+	return &Reconciler{
+		Account:    account,
+		Wallet:     wallet,
+		Tolerance:  defaultTolerance,
+		Tolerances: make(map[string]float64),
+	}
+}
+
+// Reconcile compares each consecutive pair of snapshots with the events
+// between them, the first exclusive and the second inclusive
+func (r *Reconciler) Reconcile(snapshots []Snapshot, events []ledger.Event) *Report {
+	snapshots = append([]Snapshot(nil), snapshots...)
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	report := &Report{Account: r.Account, Wallet: r.Wallet}
+	if len(snapshots) == 0 {
+		return report
+	}
+	report.Start = snapshots[0].Time
+	report.End = snapshots[len(snapshots)-1].Time
+	report.Days = len(snapshots) - 1
+
+	var relevant []ledger.Event
+	for _, event := range events {
+		if event.Account == r.Account && event.Wallet == r.Wallet {
+			relevant = append(relevant, event)
+		}
+	}
+	relevant = ledger.Merge(relevant)
+
+	next := 0
+	for next < len(relevant) && !relevant[next].Time.After(report.Start) {
+		next++
+	}
+	for i := 1; i < len(snapshots); i++ {
+		opening, closing := snapshots[i-1], snapshots[i]
+
+		recorded := make(map[string]float64)
+		for next < len(relevant) && !relevant[next].Time.After(closing.Time) {
+			event := relevant[next]
+			recorded[event.Asset] += event.Amount
+			if event.Fee != 0 {
+				recorded[event.FeeAsset] -= event.Fee
+			}
+			next++
+		}
+
+		for _, asset := range assets(opening.Balances, closing.Balances, recorded) {
+			expected := opening.Balances[asset] + recorded[asset]
+			unexplained := closing.Balances[asset] - expected
+			if math.Abs(unexplained) <= r.tolerance(asset) {
+				continue
+			}
+			report.Differences = append(report.Differences, Difference{
+				Start:       opening.Time,
+				End:         closing.Time,
+				Asset:       asset,
+				Opening:     opening.Balances[asset],
+				Recorded:    recorded[asset],
+				Expected:    expected,
+				Actual:      closing.Balances[asset],
+				Unexplained: unexplained,
+			})
+		}
+	}
+	return report
+}
+
+// Run fetches the wallet's snapshots between start and end with wallet,
+// collects the events between the first and last snapshot from sources and
+// reconciles them
+func (r *Reconciler) Run(wallet *spot.WalletClient, start, end time.Time, sources ...ledger.Source) (*Report, error) {
+	snapshots, err := Snapshots(wallet, r.Wallet, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) < 2 {
+		return nil, fmt.Errorf("at least two snapshots are required, got %d", len(snapshots))
+	}
+
+	events, err := ledger.Collect(snapshots[0].Time.Add(time.Millisecond), snapshots[len(snapshots)-1].Time, sources...)
+	if err != nil {
+		return nil, err
+	}
+	return r.Reconcile(snapshots, events), nil
+}
+
+// Snapshots fetches the daily snapshots of a ledger wallet between start and end,
+// oldest first. Only spot, margin and USDⓈ-M futures wallets have snapshots.
+func Snapshots(wallet *spot.WalletClient, ledgerWallet string, start, end time.Time) ([]Snapshot, error) {
+	accountType, ok := snapshotTypes[ledgerWallet]
+	if !ok {
+		return nil, fmt.Errorf("wallet %s has no account snapshots", ledgerWallet)
+	}
+
+	records, err := wallet.Snapshots(accountType, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(records))
+	for i := range records {
+		snapshot, err := SnapshotBalances(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+func (r *Reconciler) tolerance(asset string) float64 {
+	if tolerance, ok := r.Tolerances[asset]; ok {
+		return tolerance
+	}
+	return r.Tolerance
+}
+
+// assets returns the sorted union of the assets in balances
+func assets(balances ...map[string]float64) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, b := range balances {
+		for asset := range b {
+			if !seen[asset] {
+				seen[asset] = true
+				names = append(names, asset)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
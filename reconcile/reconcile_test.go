@@ -0,0 +1,146 @@
+package reconcile
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+// endOfDay returns the snapshot time of the nth day of January 2024
+func endOfDay(n int) time.Time {
+	return time.Date(2024, 1, n, 23, 59, 59, 0, time.UTC)
+}
+
+func event(asset string, amount float64, at time.Time) ledger.Event {
+	return ledger.Event{Type: ledger.EventDeposit, Account: "master", Wallet: ledger.WalletSpot, Asset: asset, Amount: amount, ExternalID: fmt.Sprint(asset, amount, at.Unix()), Time: at}
+}
+
+func TestReconcile(t *testing.T) {
+	snapshots := []Snapshot{
+		{Time: endOfDay(2), Balances: map[string]float64{"BTC": 1.05, "USDT": 500, "BNB": 0.97}},
+		{Time: endOfDay(1), Balances: map[string]float64{"BTC": 1, "USDT": 2500, "BNB": 1}},
+		{Time: endOfDay(3), Balances: map[string]float64{"BTC": 1.05, "USDT": 500.0000000001, "BNB": 0.97, "ETH": 0.2}},
+	}
+	buy := ledger.Merge([]ledger.Event{
+		{Type: ledger.EventTrade, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: 0.05, Fee: 0.03, FeeAsset: "BNB", ExternalID: "BTCUSDT:1", Time: endOfDay(2).Add(-time.Hour)},
+		{Type: ledger.EventTrade, Account: "master", Wallet: ledger.WalletSpot, Asset: "USDT", Amount: -2000, ExternalID: "BTCUSDT:1", Time: endOfDay(2).Add(-time.Hour)},
+	})
+	events := append(buy,
+		// Before the first snapshot, already in its balances
+		event("BTC", 5, endOfDay(1).Add(-time.Hour)),
+		// Another account and wallet are ignored
+		ledger.Event{Account: "sub@test.com", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: 3, ExternalID: "x", Time: endOfDay(2).Add(-time.Hour)},
+		ledger.Event{Account: "master", Wallet: ledger.WalletFunding, Asset: "BTC", Amount: 3, ExternalID: "y", Time: endOfDay(2).Add(-time.Hour)},
+	)
+
+	reconciler := NewReconciler("master", ledger.WalletSpot)
+	report := reconciler.Reconcile(snapshots, events)
+	if report.Days != 2 || !report.Start.Equal(endOfDay(1)) || !report.End.Equal(endOfDay(3)) {
+		t.Errorf("Unexpected period %+v", report)
+	}
+
+	// Day 2 is explained by the trade; on day 3 0.2 ETH arrived without a ledger event
+	if len(report.Differences) != 1 {
+		t.Fatalf("Expected 1 difference, got %+v", report.Differences)
+	}
+	difference := report.Differences[0]
+	if difference.Asset != "ETH" || !difference.End.Equal(endOfDay(3)) || difference.Expected != 0 || difference.Unexplained != 0.2 {
+		t.Errorf("Unexpected difference %+v", difference)
+	}
+	if report.Balanced() {
+		t.Error("Expected an unbalanced report")
+	}
+
+	reconciler.Tolerances["ETH"] = 0.5
+	if report := reconciler.Reconcile(snapshots, events); !report.Balanced() {
+		t.Errorf("Expected ETH to be within its tolerance, got %+v", report.Differences)
+	}
+
+	// Without the trade both legs and the commission are unexplained
+	delete(reconciler.Tolerances, "ETH")
+	report = reconciler.Reconcile(snapshots, nil)
+	if len(report.Differences) != 4 {
+		t.Errorf("Expected BNB, BTC and USDT on day 2 and ETH on day 3, got %+v", report.Differences)
+	}
+}
+
+func TestSnapshotBalances(t *testing.T) {
+	tests := []struct {
+		snapshot spot.AccountSnapshot
+		want     map[string]float64
+	}{
+		{
+			spot.AccountSnapshot{Type: "spot", Data: []byte(`{"balances":[{"asset":"BTC","free":"0.5","locked":"0.25"}],"totalAssetOfBtc":"0.75"}`)},
+			map[string]float64{"BTC": 0.75},
+		},
+		{
+			spot.AccountSnapshot{Type: "margin", Data: []byte(`{"userAssets":[{"asset":"XRP","borrowed":"1","free":"3","interest":"0","locked":"0","netAsset":"2"}]}`)},
+			map[string]float64{"XRP": 2},
+		},
+		{
+			spot.AccountSnapshot{Type: "futures", Data: []byte(`{"assets":[{"asset":"USDT","marginBalance":"118.99","walletBalance":"120.23"}],"position":[]}`)},
+			map[string]float64{"USDT": 120.23},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.snapshot.Type, func(t *testing.T) {
+			snapshot, err := SnapshotBalances(&tt.snapshot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for asset, want := range tt.want {
+				if snapshot.Balances[asset] != want {
+					t.Errorf("Expected %s %v, got %v", asset, want, snapshot.Balances[asset])
+				}
+			}
+		})
+	}
+
+	if _, err := SnapshotBalances(&spot.AccountSnapshot{Type: "options"}); err == nil {
+		t.Error("Expected error for an unsupported type")
+	}
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sapi/v1/accountSnapshot":
+			fmt.Fprintf(w, `{"code":200,"msg":"","snapshotVos":[
+				{"type":"spot","updateTime":%d,"data":{"balances":[{"asset":"USDT","free":"100","locked":"0"}]}},
+				{"type":"spot","updateTime":%d,"data":{"balances":[{"asset":"USDT","free":"149.5","locked":"0.5"}]}}]}`,
+				endOfDay(1).UnixMilli(), endOfDay(2).UnixMilli())
+		case "/sapi/v1/capital/deposit/hisrec":
+			fmt.Fprintf(w, `[{"id":"d1","amount":"50","coin":"USDT","status":1,"insertTime":%d}]`, endOfDay(2).Add(-time.Hour).UnixMilli())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := spot.NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	report, err := NewReconciler("master", ledger.WalletSpot).Run(wallet, endOfDay(1).Add(-time.Hour), endOfDay(2), ledger.Deposits(wallet, "master"))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Days != 1 || !report.Balanced() {
+		t.Errorf("Expected the deposit to explain the day, got %+v", report)
+	}
+
+	if _, err := Snapshots(wallet, ledger.WalletFunding, endOfDay(1), endOfDay(2)); err == nil {
+		t.Error("Expected error for a wallet without snapshots")
+	}
+}
@@ -0,0 +1,184 @@
+package spot
+
+// Permission is a capability granted to an API key in its restrictions
+type Permission string
+
+const (
+	PermissionReading              Permission = "READING"
+	PermissionSpotAndMarginTrading Permission = "SPOT_AND_MARGIN_TRADING"
+	PermissionMargin               Permission = "MARGIN"
+	PermissionFutures              Permission = "FUTURES"
+	PermissionWithdrawals          Permission = "WITHDRAWALS"
+	PermissionInternalTransfer     Permission = "INTERNAL_TRANSFER"
+	PermissionUniversalTransfer    Permission = "UNIVERSAL_TRANSFER"
+	PermissionVanillaOptions       Permission = "VANILLA_OPTIONS"
+	PermissionPortfolioMargin      Permission = "PORTFOLIO_MARGIN"
+)
+
+// System status values of SystemStatus
+const (
+	SystemStatusNormal      = 0
+	SystemStatusMaintenance = 1
+)
+
+// APIRestrictions is the response of WalletClient.APIRestrictions
+type APIRestrictions struct {
+	IPRestrict                     bool  `json:"ipRestrict"`
+	CreateTime                     int64 `json:"createTime"`
+	EnableReading                  bool  `json:"enableReading"`
+	EnableSpotAndMarginTrading     bool  `json:"enableSpotAndMarginTrading"`
+	EnableMargin                   bool  `json:"enableMargin"`
+	EnableFutures                  bool  `json:"enableFutures"`
+	EnableWithdrawals              bool  `json:"enableWithdrawals"`
+	EnableInternalTransfer         bool  `json:"enableInternalTransfer"`
+	PermitsUniversalTransfer       bool  `json:"permitsUniversalTransfer"`
+	EnableVanillaOptions           bool  `json:"enableVanillaOptions"`
+	EnablePortfolioMarginTrading   bool  `json:"enablePortfolioMarginTrading"`
+	TradingAuthorityExpirationTime int64 `json:"tradingAuthorityExpirationTime"` // 0 if spot and margin trading does not expire
+}
+
+// Has reports whether the key has a permission
+func (r *APIRestrictions) Has(permission Permission) bool {
+	switch permission {
+	case PermissionReading:
+		return r.EnableReading
+	case PermissionSpotAndMarginTrading:
+		return r.EnableSpotAndMarginTrading
+	case PermissionMargin:
+		return r.EnableMargin
+	case PermissionFutures:
+		return r.EnableFutures
+	case PermissionWithdrawals:
+		return r.EnableWithdrawals
+	case PermissionInternalTransfer:
+		return r.EnableInternalTransfer
+	case PermissionUniversalTransfer:
+		return r.PermitsUniversalTransfer
+	case PermissionVanillaOptions:
+		return r.EnableVanillaOptions
+	case PermissionPortfolioMargin:
+		return r.EnablePortfolioMarginTrading
+	}
+	return false
+}
+
+// Permissions returns the permissions granted to the key
+func (r *APIRestrictions) Permissions() []Permission {
+	var granted []Permission
+	for _, permission := range []Permission{
+		PermissionReading,
+		PermissionSpotAndMarginTrading,
+		PermissionMargin,
+		PermissionFutures,
+		PermissionWithdrawals,
+		PermissionInternalTransfer,
+		PermissionUniversalTransfer,
+		PermissionVanillaOptions,
+		PermissionPortfolioMargin,
+	} {
+		if r.Has(permission) {
+			granted = append(granted, permission)
+		}
+	}
+	return granted
+}
+
+// AccountStatus is the response of WalletClient.AccountStatus
+type AccountStatus struct {
+	Data string `json:"data"` // "Normal" unless the account is restricted
+}
+
+// APITradingStatus is the response of WalletClient.APITradingStatus
+type APITradingStatus struct {
+	Data APITradingStatusData `json:"data"`
+}
+
+// APITradingStatusData reports whether the key is locked out of trading by the
+// quantitative trading rules, and the indicators that trigger a lock
+type APITradingStatusData struct {
+	IsLocked           bool                          `json:"isLocked"`
+	PlannedRecoverTime int64                         `json:"plannedRecoverTime"`
+	TriggerCondition   map[string]int                `json:"triggerCondition"`
+	Indicators         map[string][]TradingIndicator `json:"indicators"` // keyed by symbol
+	UpdateTime         int64                         `json:"updateTime"`
+}
+
+// TradingIndicator is the current value of a trading rule indicator of a symbol
+type TradingIndicator struct {
+	Indicator    string  `json:"i"` // e.g. "UFR" (unfilled ratio), "IFER", "GCR"
+	Count        int     `json:"c"`
+	CurrentValue float64 `json:"v"`
+	Threshold    float64 `json:"t"`
+}
+
+// SystemStatus is the response of WalletClient.SystemStatus
+type SystemStatus struct {
+	Status int    `json:"status"` // SystemStatusNormal or SystemStatusMaintenance
+	Msg    string `json:"msg"`
+}
+
+// APIRestrictions queries the permissions of the API key (USER_DATA)
+// Response can be decoded into APIRestrictions with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/account/apiRestrictions
+//
+// https://developers.binance.com/docs/wallet/account/api-key-permission
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) APIRestrictions(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/account/apiRestrictions", params)
+}
+
+// AccountStatus queries the account status (USER_DATA)
+// Response can be decoded into AccountStatus with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/account/status
+//
+// https://developers.binance.com/docs/wallet/account/account-status
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) AccountStatus(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/account/status", params)
+}
+
+// APITradingStatus queries whether the API key is locked out of trading (USER_DATA)
+// Response can be decoded into APITradingStatus with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/account/apiTradingStatus
+//
+// https://developers.binance.com/docs/wallet/account/account-api-trading-status
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) APITradingStatus(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/account/apiTradingStatus", params)
+}
+
+// SystemStatus queries whether the system is under maintenance (MARKET_DATA)
+// Response can be decoded into SystemStatus with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/system/status
+//
+// https://developers.binance.com/docs/wallet/others/system-status
+func (w *WalletClient) SystemStatus() ([]byte, error) {
+	return w.Query("GET", "/sapi/v1/system/status", nil)
+}
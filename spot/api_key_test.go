@@ -0,0 +1,91 @@
+package spot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestAPIRestrictionsPermissions(t *testing.T) {
+	restrictions := APIRestrictions{EnableReading: true, EnableWithdrawals: true, PermitsUniversalTransfer: true}
+
+	want := []Permission{PermissionReading, PermissionWithdrawals, PermissionUniversalTransfer}
+	if got := restrictions.Permissions(); !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if restrictions.Has(PermissionSpotAndMarginTrading) {
+		t.Error("Expected trading permission to be missing")
+	}
+	if restrictions.Has(Permission("UNKNOWN")) {
+		t.Error("Expected unknown permission to be missing")
+	}
+}
+
+func TestAPIKeyEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signed := r.URL.Query().Get("signature") != ""
+		switch r.URL.Path {
+		case "/sapi/v1/account/apiRestrictions":
+			if !signed {
+				t.Error("Expected signed request")
+			}
+			w.Write([]byte(`{"ipRestrict":false,"createTime":1698645219000,"enableReading":true,"enableSpotAndMarginTrading":false,"enableWithdrawals":false,"enableInternalTransfer":true,"enableMargin":false,"enableFutures":false,"permitsUniversalTransfer":true,"enableVanillaOptions":false,"enablePortfolioMarginTrading":false}`))
+		case "/sapi/v1/account/apiTradingStatus":
+			if !signed {
+				t.Error("Expected signed request")
+			}
+			w.Write([]byte(`{"data":{"isLocked":false,"plannedRecoverTime":0,"triggerCondition":{"GCR":150,"IFER":150,"UFR":300},"indicators":{"BTCUSDT":[{"i":"UFR","c":20,"v":0.05,"t":0.995}]},"updateTime":1547630471725}}`))
+		case "/sapi/v1/system/status":
+			if signed {
+				t.Error("Expected unsigned request")
+			}
+			w.Write([]byte(`{"status":1,"msg":"system maintenance"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	response, err := wallet.APIRestrictions(nil)
+	if err != nil {
+		t.Fatalf("APIRestrictions() error = %v", err)
+	}
+	var restrictions APIRestrictions
+	if err := client.ParseResponse(response, &restrictions); err != nil {
+		t.Fatal(err)
+	}
+	if !restrictions.Has(PermissionInternalTransfer) || restrictions.Has(PermissionSpotAndMarginTrading) {
+		t.Errorf("Unexpected restrictions %+v", restrictions)
+	}
+
+	response, err = wallet.APITradingStatus(nil)
+	if err != nil {
+		t.Fatalf("APITradingStatus() error = %v", err)
+	}
+	var trading APITradingStatus
+	if err := client.ParseResponse(response, &trading); err != nil {
+		t.Fatal(err)
+	}
+	indicators := trading.Data.Indicators["BTCUSDT"]
+	if len(indicators) != 1 || indicators[0].Indicator != "UFR" || indicators[0].Threshold != 0.995 {
+		t.Errorf("Unexpected indicators %+v", indicators)
+	}
+
+	response, err = wallet.SystemStatus()
+	if err != nil {
+		t.Fatalf("SystemStatus() error = %v", err)
+	}
+	var status SystemStatus
+	if err := client.ParseResponse(response, &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != SystemStatusMaintenance {
+		t.Errorf("Expected maintenance, got %+v", status)
+	}
+}
@@ -0,0 +1,198 @@
+package spot
+
+import (
+	"encoding/json"
+
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// AssetDetail holds the deposit and withdrawal settings of an asset
+// The response of WalletClient.AssetDetail is a map[string]AssetDetail keyed by asset.
+type AssetDetail struct {
+	MinWithdrawAmount string `json:"minWithdrawAmount"`
+	DepositStatus     bool   `json:"depositStatus"`
+	WithdrawFee       string `json:"withdrawFee"`
+	WithdrawStatus    bool   `json:"withdrawStatus"`
+	DepositTip        string `json:"depositTip"` // reason deposits are suspended, if any
+}
+
+// UnmarshalJSON decodes an asset detail, keeping the digits of withdrawFee,
+// which the API sends as a JSON number
+func (d *AssetDetail) UnmarshalJSON(data []byte) error {
+	type assetDetail AssetDetail
+	var raw struct {
+		assetDetail
+		WithdrawFee json.Number `json:"withdrawFee"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = AssetDetail(raw.assetDetail)
+	d.WithdrawFee = raw.WithdrawFee.String()
+	return nil
+}
+
+// TradeFee is the maker and taker commission rate of a symbol
+type TradeFee struct {
+	Symbol          string `json:"symbol"`
+	MakerCommission string `json:"makerCommission"`
+	TakerCommission string `json:"takerCommission"`
+}
+
+// AssetDividends is the response of WalletClient.AssetDividend
+type AssetDividends struct {
+	Rows  []AssetDividend `json:"rows"`
+	Total int             `json:"total"`
+}
+
+// AssetDividend is a distribution such as an airdrop, staking reward or token swap
+type AssetDividend struct {
+	ID      int64  `json:"id"`
+	Amount  string `json:"amount"`
+	Asset   string `json:"asset"`
+	DivTime int64  `json:"divTime"`
+	EnInfo  string `json:"enInfo"` // distribution type, e.g. "BNB Vault"
+	TranID  int64  `json:"tranId"`
+}
+
+// AccountCommission is the response of WalletClient.Commission
+type AccountCommission struct {
+	Symbol             string             `json:"symbol"`
+	StandardCommission CommissionRates    `json:"standardCommission"`
+	TaxCommission      CommissionRates    `json:"taxCommission"`
+	SpecialCommission  CommissionRates    `json:"specialCommission"`
+	Discount           CommissionDiscount `json:"discount"`
+}
+
+// CommissionRates are the commission rates applied to each side of a trade
+type CommissionRates struct {
+	Maker  string `json:"maker"`
+	Taker  string `json:"taker"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
+}
+
+// CommissionDiscount is the discount applied when commissions are paid in DiscountAsset
+type CommissionDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"`
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`
+	DiscountAsset     string `json:"discountAsset"`
+	Discount          string `json:"discount"`
+}
+
+// FundingAsset is a balance of the funding wallet
+type FundingAsset struct {
+	Asset        string `json:"asset"`
+	Free         string `json:"free"`
+	Locked       string `json:"locked"`
+	Freeze       string `json:"freeze"`
+	Withdrawing  string `json:"withdrawing"`
+	BtcValuation string `json:"btcValuation"`
+}
+
+// AssetDetail queries deposit and withdrawal settings of assets (USER_DATA)
+// Response can be decoded into map[string]AssetDetail with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/asset/assetDetail
+//
+// https://developers.binance.com/docs/wallet/asset
+//
+// Optional parameters:
+//   - asset: Asset symbol; all assets if not sent
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) AssetDetail(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/asset/assetDetail", params)
+}
+
+// TradeFee queries the maker and taker commission rates of symbols (USER_DATA)
+// Response can be decoded into []TradeFee with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/asset/tradeFee
+//
+// https://developers.binance.com/docs/wallet/asset/trade-fee
+//
+// Optional parameters:
+//   - symbol: Trading symbol; all symbols if not sent
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) TradeFee(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/asset/tradeFee", params)
+}
+
+// AssetDividend queries distributions such as airdrops and staking rewards (USER_DATA)
+// Response can be decoded into AssetDividends with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/asset/assetDividend
+//
+// https://developers.binance.com/docs/wallet/asset/assets-divided-record
+//
+// Only the last 180 days can be queried; the range between startTime and
+// endTime can be at most 180 days.
+//
+// Optional parameters:
+//   - asset: Asset symbol
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - limit: Default 20, max 500
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) AssetDividend(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/asset/assetDividend", params)
+}
+
+// Commission queries the current commission rates of a symbol for the account (USER_DATA)
+// Response can be decoded into AccountCommission with client.ParseResponse.
+//
+// Weight(IP): 20
+//
+// GET /api/v3/account/commission
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-commission-rates-user_data
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+func (w *WalletClient) Commission(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+
+	return w.SignRequest("GET", "/api/v3/account/commission", params)
+}
+
+// FundingAsset queries funding wallet balances (USER_DATA)
+// Response can be decoded into []FundingAsset with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// POST /sapi/v1/asset/get-funding-asset
+//
+// https://developers.binance.com/docs/wallet/asset/funding-wallet
+//
+// Optional parameters:
+//   - asset: Asset symbol; all assets with a balance if not sent
+//   - needBtcValuation: "true" or "false"
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) FundingAsset(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("POST", "/sapi/v1/asset/get-funding-asset", params)
+}
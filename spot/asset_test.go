@@ -0,0 +1,88 @@
+package spot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestAssetEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("signature") == "" {
+			t.Errorf("Expected signed request, got %s", r.URL.RawQuery)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /sapi/v1/asset/assetDetail":
+			w.Write([]byte(`{"CTR":{"minWithdrawAmount":"70.00000000","depositStatus":false,"withdrawFee":35,"withdrawStatus":true,"depositTip":"Delisted, Deposit Suspended"}}`))
+		case "GET /sapi/v1/asset/tradeFee":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","makerCommission":"0.001","takerCommission":"0.001"}]`))
+		case "GET /sapi/v1/asset/assetDividend":
+			w.Write([]byte(`{"rows":[{"id":1637366104,"amount":"10.00000000","asset":"BHFT","divTime":1563189166000,"enInfo":"BHFT distribution","tranId":2968885920}],"total":1}`))
+		case "GET /api/v3/account/commission":
+			w.Write([]byte(`{"symbol":"BTCUSDT","standardCommission":{"maker":"0.00000010","taker":"0.00000020","buyer":"0.00000030","seller":"0.00000040"},"taxCommission":{"maker":"0.00000112","taker":"0.00000114","buyer":"0.00000118","seller":"0.00000116"},"discount":{"enabledForAccount":true,"enabledForSymbol":true,"discountAsset":"BNB","discount":"0.75000000"}}`))
+		case "POST /sapi/v1/asset/get-funding-asset":
+			w.Write([]byte(`[{"asset":"USDT","free":"1","locked":"0","freeze":"0","withdrawing":"0","btcValuation":"0.00000091"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	response, err := wallet.AssetDetail(map[string]interface{}{"asset": "CTR"})
+	if err != nil {
+		t.Fatalf("AssetDetail() error = %v", err)
+	}
+	var details map[string]AssetDetail
+	if err := client.ParseResponse(response, &details); err != nil || details["CTR"].WithdrawFee != "35" || details["CTR"].DepositStatus {
+		t.Errorf("Unexpected asset details %+v, error %v", details, err)
+	}
+	var detail AssetDetail
+	if err := client.ParseResponse([]byte(`{"minWithdrawAmount":"0.001","withdrawFee":0.00050000,"withdrawStatus":true}`), &detail); err != nil || detail.WithdrawFee != "0.00050000" || !detail.WithdrawStatus {
+		t.Errorf("Unexpected asset detail %+v, error %v", detail, err)
+	}
+
+	response, err = wallet.TradeFee(nil)
+	if err != nil {
+		t.Fatalf("TradeFee() error = %v", err)
+	}
+	var fees []TradeFee
+	if err := client.ParseResponse(response, &fees); err != nil || len(fees) != 1 || fees[0].TakerCommission != "0.001" {
+		t.Errorf("Unexpected trade fees %+v, error %v", fees, err)
+	}
+
+	response, err = wallet.AssetDividend(nil)
+	if err != nil {
+		t.Fatalf("AssetDividend() error = %v", err)
+	}
+	var dividends AssetDividends
+	if err := client.ParseResponse(response, &dividends); err != nil || dividends.Total != 1 || dividends.Rows[0].Asset != "BHFT" {
+		t.Errorf("Unexpected dividends %+v, error %v", dividends, err)
+	}
+
+	response, err = wallet.Commission("BTCUSDT", nil)
+	if err != nil {
+		t.Fatalf("Commission() error = %v", err)
+	}
+	var commission AccountCommission
+	if err := client.ParseResponse(response, &commission); err != nil || commission.StandardCommission.Taker != "0.00000020" || commission.Discount.DiscountAsset != "BNB" {
+		t.Errorf("Unexpected commission %+v, error %v", commission, err)
+	}
+
+	response, err = wallet.FundingAsset(map[string]interface{}{"needBtcValuation": "true"})
+	if err != nil {
+		t.Fatalf("FundingAsset() error = %v", err)
+	}
+	var funding []FundingAsset
+	if err := client.ParseResponse(response, &funding); err != nil || len(funding) != 1 || funding[0].BtcValuation != "0.00000091" {
+		t.Errorf("Unexpected funding assets %+v, error %v", funding, err)
+	}
+
+	if _, err := wallet.Commission("", nil); err == nil {
+		t.Error("Expected error for empty symbol, got nil")
+	}
+}
@@ -0,0 +1,178 @@
+package spot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+const (
+	day = 24 * time.Hour
+
+	// Widest ranges and page sizes accepted by the history endpoints
+	depositSpan             = 90 * day
+	depositLimit            = 1000
+	subAccountDepositLimit  = 500
+	withdrawalSpan          = 90 * day
+	withdrawalLimit         = 1000
+	subAccountTransferSpan  = 30 * day
+	subAccountTransferLimit = 500
+	snapshotSpan            = 30 * day
+	snapshotLimit           = 30
+	tradeSpan               = day
+	tradeLimit              = 1000
+)
+
+// Deposits reads the deposit history between start and end, in as many
+// DepositHistory queries as its range and page size require
+func (w *WalletClient) Deposits(start, end time.Time) ([]Deposit, error) {
+	return utils.CollectWindows(start, end, depositSpan, depositLimit, func(from, to time.Time) ([]Deposit, error) {
+		response, err := w.DepositHistory(map[string]interface{}{
+			"startTime": from.UnixMilli(),
+			"endTime":   to.UnixMilli(),
+			"limit":     depositLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return parseDeposits(response)
+	})
+}
+
+// SubAccountDeposits reads the deposit history of a sub-account between start
+// and end, in as many SubAccountDepositHistory queries as its range and page
+// size require
+func (s *SubAccountClient) SubAccountDeposits(email string, start, end time.Time) ([]Deposit, error) {
+	return utils.CollectWindows(start, end, depositSpan, subAccountDepositLimit, func(from, to time.Time) ([]Deposit, error) {
+		response, err := s.SubAccountDepositHistory(email, map[string]interface{}{
+			"startTime": from.UnixMilli(),
+			"endTime":   to.UnixMilli(),
+			"limit":     subAccountDepositLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return parseDeposits(response)
+	})
+}
+
+// Withdrawals reads the withdrawal history between start and end, in as many
+// WithdrawalHistory queries as its range and page size require
+func (w *WalletClient) Withdrawals(start, end time.Time) ([]Withdrawal, error) {
+	return utils.CollectWindows(start, end, withdrawalSpan, withdrawalLimit, func(from, to time.Time) ([]Withdrawal, error) {
+		response, err := w.WithdrawalHistory(map[string]interface{}{
+			"startTime": from.UnixMilli(),
+			"endTime":   to.UnixMilli(),
+			"limit":     withdrawalLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var withdrawals []Withdrawal
+		if err := client.ParseResponse(response, &withdrawals); err != nil {
+			return nil, fmt.Errorf("failed to parse withdrawal history: %w", err)
+		}
+		return withdrawals, nil
+	})
+}
+
+// SubAccountTransfers reads the transfers of the sub-account owning w between
+// start and end, in as many SubAccountTransferHistory queries as its range
+// and page size require
+func (w *WalletClient) SubAccountTransfers(start, end time.Time) ([]SubAccountTransfer, error) {
+	return utils.CollectWindows(start, end, subAccountTransferSpan, subAccountTransferLimit, func(from, to time.Time) ([]SubAccountTransfer, error) {
+		response, err := w.SubAccountTransferHistory(map[string]interface{}{
+			"startTime": from.UnixMilli(),
+			"endTime":   to.UnixMilli(),
+			"limit":     subAccountTransferLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var transfers []SubAccountTransfer
+		if err := client.ParseResponse(response, &transfers); err != nil {
+			return nil, fmt.Errorf("failed to parse sub-account transfers: %w", err)
+		}
+		return transfers, nil
+	})
+}
+
+// Snapshots reads the daily snapshots of accountType, e.g. SnapshotSpot,
+// between start and end, in as many AccountSnapshot queries as its range
+// requires
+func (w *WalletClient) Snapshots(accountType string, start, end time.Time) ([]AccountSnapshot, error) {
+	// A window holds at most one snapshot per day, so it never fills a page
+	return utils.CollectWindows(start, end, snapshotSpan, 0, func(from, to time.Time) ([]AccountSnapshot, error) {
+		response, err := w.AccountSnapshot(accountType, map[string]interface{}{
+			"startTime": from.UnixMilli(),
+			"endTime":   to.UnixMilli(),
+			"limit":     snapshotLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var result AccountSnapshots
+		if err := client.ParseResponse(response, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse account snapshots: %w", err)
+		}
+		return result.SnapshotVos, nil
+	})
+}
+
+// Trades reads the trades of symbol between start and end, in as many
+// MyTrades queries as its range and page size require
+//
+// A day filling a page is read on by trade ID, since MyTrades does not
+// combine fromId with a time range.
+func (w *WalletClient) Trades(symbol string, start, end time.Time) ([]AccountTrade, error) {
+	return utils.CollectWindows(start, end, tradeSpan, 0, func(from, to time.Time) ([]AccountTrade, error) {
+		trades, err := w.trades(symbol, map[string]interface{}{
+			"startTime": from.UnixMilli(),
+			"endTime":   to.UnixMilli(),
+			"limit":     tradeLimit,
+		})
+		if err != nil || len(trades) < tradeLimit {
+			return trades, err
+		}
+		for {
+			next, err := w.trades(symbol, map[string]interface{}{
+				"fromId": trades[len(trades)-1].ID + 1,
+				"limit":  tradeLimit,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, trade := range next {
+				if trade.Time > to.UnixMilli() {
+					return trades, nil
+				}
+				trades = append(trades, trade)
+			}
+			if len(next) < tradeLimit {
+				return trades, nil
+			}
+		}
+	})
+}
+
+func (w *WalletClient) trades(symbol string, params map[string]interface{}) ([]AccountTrade, error) {
+	response, err := w.MyTrades(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	var trades []AccountTrade
+	if err := client.ParseResponse(response, &trades); err != nil {
+		return nil, fmt.Errorf("failed to parse trades: %w", err)
+	}
+	return trades, nil
+}
+
+func parseDeposits(response []byte) ([]Deposit, error) {
+	var deposits []Deposit
+	if err := client.ParseResponse(response, &deposits); err != nil {
+		return nil, fmt.Errorf("failed to parse deposit history: %w", err)
+	}
+	return deposits, nil
+}
@@ -0,0 +1,64 @@
+package spot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTradesPagesFullDayByID(t *testing.T) {
+	// Trades 1 to 1400 were filled in one millisecond, more than a page holds,
+	// and the rest a second later
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const total = 1500
+	filled := func(id int64) int64 {
+		if id <= 1400 {
+			return base.UnixMilli()
+		}
+		return base.UnixMilli() + 2000
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		first, last := int64(1), int64(total)
+		var startTime, endTime int64
+		if fromID := query.Get("fromId"); fromID != "" {
+			if query.Get("startTime") != "" || query.Get("endTime") != "" {
+				t.Errorf("Expected fromId without a time range in %s", r.URL.RawQuery)
+			}
+			first, _ = strconv.ParseInt(fromID, 10, 64)
+			endTime = filled(total)
+		} else {
+			startTime, _ = strconv.ParseInt(query.Get("startTime"), 10, 64)
+			endTime, _ = strconv.ParseInt(query.Get("endTime"), 10, 64)
+		}
+		var trades []string
+		for id := first; id <= last && len(trades) < limit; id++ {
+			if filled(id) >= startTime && filled(id) <= endTime {
+				trades = append(trades, fmt.Sprintf(`{"symbol":"BTCUSDT","id":%d,"time":%d}`, id, filled(id)))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(trades, ",") + "]"))
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	trades, err := wallet.Trades("BTCUSDT", base, base.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1400 {
+		t.Fatalf("Expected 1400 trades, got %d", len(trades))
+	}
+	for i, trade := range trades {
+		if trade.ID != int64(i+1) {
+			t.Fatalf("Expected trade %d at position %d, got %d", i+1, i, trade.ID)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package spot
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// DepositAddress is the response of WalletClient.DepositAddress
+// Tag is the memo that must accompany deposits on shared-address networks.
+type DepositAddress struct {
+	Coin    string `json:"coin"`
+	Address string `json:"address"`
+	Tag     string `json:"tag"`
+	URL     string `json:"url"`
+}
+
+// DepositAddressInfo is an address returned by WalletClient.DepositAddressList
+type DepositAddressInfo struct {
+	Coin      string `json:"coin"`
+	Address   string `json:"address"`
+	Tag       string `json:"tag"`
+	IsDefault int    `json:"isDefault"` // 1 for the coin's default address
+}
+
+// DepositAddress fetches the deposit address of a coin (USER_DATA)
+// Response can be decoded into DepositAddress with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/capital/deposit/address
+//
+// https://developers.binance.com/docs/wallet/capital/deposite-address
+//
+// Parameters:
+//   - coin: Coin symbol
+//
+// Optional parameters:
+//   - network: Network, the coin's default network if not sent
+//   - amount: Amount, for networks such as Lightning that issue per-amount addresses
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) DepositAddress(coin string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(coin, "coin"); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["coin"] = coin
+
+	return w.SignRequest("GET", "/sapi/v1/capital/deposit/address", params)
+}
+
+// DepositAddressList fetches every deposit address of a coin (USER_DATA)
+// Response can be decoded into []DepositAddressInfo with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/capital/deposit/address/list
+//
+// https://developers.binance.com/docs/wallet/capital/fetch-deposit-address-list-with-network
+//
+// Parameters:
+//   - coin: Coin symbol
+//
+// Optional parameters:
+//   - network: Network
+func (w *WalletClient) DepositAddressList(coin string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(coin, "coin"); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["coin"] = coin
+
+	return w.SignRequest("GET", "/sapi/v1/capital/deposit/address/list", params)
+}
@@ -0,0 +1,417 @@
+package spot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDepositPollInterval = 30 * time.Second
+	defaultDepositLookback     = 24 * time.Hour
+)
+
+// DepositStatus is the status code of a deposit in DepositHistory
+type DepositStatus int
+
+const (
+	// DepositStatusUnknown is a watched deposit not yet seen in the history
+	DepositStatusUnknown DepositStatus = -1
+	DepositStatusPending DepositStatus = 0
+	DepositStatusSuccess DepositStatus = 1
+	// DepositStatusRejected is a deposit refused by Binance
+	DepositStatusRejected DepositStatus = 2
+	// DepositStatusCredited is credited to the balance but cannot be withdrawn yet
+	DepositStatusCredited           DepositStatus = 6
+	DepositStatusWrongDeposit       DepositStatus = 7
+	DepositStatusWaitingUserConfirm DepositStatus = 8
+)
+
+// String returns the status name shown in the Binance documentation
+func (s DepositStatus) String() string {
+	switch s {
+	case DepositStatusUnknown:
+		return "Unknown"
+	case DepositStatusPending:
+		return "Pending"
+	case DepositStatusSuccess:
+		return "Success"
+	case DepositStatusRejected:
+		return "Rejected"
+	case DepositStatusCredited:
+		return "Credited"
+	case DepositStatusWrongDeposit:
+		return "Wrong Deposit"
+	case DepositStatusWaitingUserConfirm:
+		return "Waiting User Confirm"
+	}
+	return fmt.Sprintf("DepositStatus(%d)", int(s))
+}
+
+// Final reports whether the deposit can no longer change status
+func (s DepositStatus) Final() bool {
+	switch s {
+	case DepositStatusSuccess, DepositStatusRejected, DepositStatusWrongDeposit:
+		return true
+	}
+	return false
+}
+
+// Credited reports whether the deposit counts towards the balance
+func (s DepositStatus) Credited() bool {
+	return s == DepositStatusCredited || s == DepositStatusSuccess
+}
+
+// Deposit is a deposit record returned by WalletClient.DepositHistory and
+// SubAccountClient.SubAccountDepositHistory
+type Deposit struct {
+	ID            string        `json:"id"`
+	Amount        string        `json:"amount"`
+	Coin          string        `json:"coin"`
+	Network       string        `json:"network"`
+	Status        DepositStatus `json:"status"`
+	Address       string        `json:"address"`
+	AddressTag    string        `json:"addressTag"`
+	TxID          string        `json:"txId"`
+	InsertTime    int64         `json:"insertTime"`
+	CompleteTime  int64         `json:"completeTime"`
+	TransferType  int           `json:"transferType"` // 1 for internal transfers, 0 for external
+	ConfirmTimes  string        `json:"confirmTimes"` // e.g. "12/12"
+	UnlockConfirm int           `json:"unlockConfirm"`
+	WalletType    int           `json:"walletType"`
+}
+
+// DepositMatch identifies a deposit to watch, by TxID or by Address and Amount
+//
+// Email selects a sub-account; leave it empty for the master account. Coin,
+// Network and AddressTag narrow an address match when set.
+type DepositMatch struct {
+	Email      string
+	TxID       string
+	Coin       string
+	Network    string
+	Address    string
+	AddressTag string
+	Amount     float64
+}
+
+// String describes the match for logs and errors
+func (m DepositMatch) String() string {
+	account := "master"
+	if m.Email != "" {
+		account = m.Email
+	}
+	if m.TxID != "" {
+		return account + " tx " + m.TxID
+	}
+	amount := formatDecimal(m.Amount)
+	if m.Coin != "" {
+		amount += " " + m.Coin
+	}
+	return account + " " + amount + " to " + m.Address
+}
+
+// key identifies the match in the watcher
+func (m DepositMatch) key() string {
+	return strings.Join([]string{m.Email, m.TxID, strings.ToUpper(m.Coin), strings.ToUpper(m.Network), m.Address, m.AddressTag, formatDecimal(m.Amount)}, "|")
+}
+
+// matches reports whether a history record is the watched deposit
+func (m DepositMatch) matches(d Deposit) bool {
+	if m.TxID != "" {
+		return d.TxID == m.TxID
+	}
+	if d.Address != m.Address || (m.AddressTag != "" && d.AddressTag != m.AddressTag) {
+		return false
+	}
+	if (m.Coin != "" && !strings.EqualFold(d.Coin, m.Coin)) || (m.Network != "" && !strings.EqualFold(d.Network, m.Network)) {
+		return false
+	}
+	return math.Abs(parseDecimal(d.Amount)-m.Amount) < 1e-9
+}
+
+// DepositChange is a status change of a watched deposit
+type DepositChange struct {
+	Match    DepositMatch
+	Deposit  Deposit
+	Previous DepositStatus
+}
+
+// CreditStats summarizes how long deposits of a coin on a network took to be credited
+// Latency runs from the deposit's insertTime to its completeTime. Until the
+// history reports a completeTime, the poll that first saw the deposit
+// credited stands in for it, accurate to within the watcher's PollInterval;
+// deposits already credited when first seen have no such poll and are only
+// counted once they report a completeTime.
+type CreditStats struct {
+	Coin    string
+	Network string
+	Count   int
+	Min     time.Duration
+	Max     time.Duration
+	Mean    time.Duration
+	Last    time.Duration
+
+	total time.Duration
+}
+
+func (s *CreditStats) add(latency time.Duration) {
+	if s.Count == 0 || latency < s.Min {
+		s.Min = latency
+	}
+	if latency > s.Max {
+		s.Max = latency
+	}
+	s.Count++
+	s.total += latency
+	s.Mean = s.total / time.Duration(s.Count)
+	s.Last = latency
+}
+
+// watchedDeposit is the state of one watched deposit
+type watchedDeposit struct {
+	match     DepositMatch
+	status    DepositStatus
+	watchedAt time.Time
+	credited  bool
+}
+
+// DepositWatcher polls the deposit history of the master account and its
+// sub-accounts and reports status transitions of watched deposits
+//
+// Deposits are matched against records inserted up to Lookback before they
+// were watched, so a deposit already in flight is still found. Watches are
+// dropped once the deposit reaches a final status: Success, Rejected or Wrong Deposit.
+type DepositWatcher struct {
+	// PollInterval is the wait between polls of the deposit history. Default 30s.
+	PollInterval time.Duration
+	// Lookback is how far before Watch the history is searched. Default 24h.
+	Lookback time.Duration
+	// OnChange receives every status change, including the first time a
+	// deposit appears in the history. Called from the polling goroutine. Optional.
+	OnChange func(change DepositChange)
+	// OnError receives poll errors. Optional.
+	OnError func(err error)
+
+	wallet      *WalletClient
+	subAccounts *SubAccountClient
+
+	mu      sync.Mutex
+	watched map[string]*watchedDeposit
+	stats   map[string]*CreditStats
+
+	started bool
+	closed  chan struct{}
+	done    chan struct{}
+}
+
+// NewDepositWatcher creates a watcher polling the master account with wallet
+// and sub-accounts with subAccounts, which may be nil if no sub-account
+// deposits are watched
+func NewDepositWatcher(wallet *WalletClient, subAccounts *SubAccountClient) *DepositWatcher {
+	return &DepositWatcher{
+		PollInterval: defaultDepositPollInterval,
+		Lookback:     defaultDepositLookback,
+		wallet:       wallet,
+		subAccounts:  subAccounts,
+		watched:      make(map[string]*watchedDeposit),
+		stats:        make(map[string]*CreditStats),
+		closed:       make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// Watch starts watching for a deposit
+func (w *DepositWatcher) Watch(match DepositMatch) error {
+	if match.TxID == "" && (match.Address == "" || match.Amount <= 0) {
+		return fmt.Errorf("either txId or address and amount are required")
+	}
+	if match.Email != "" && w.subAccounts == nil {
+		return fmt.Errorf("watching sub-account deposits requires a SubAccountClient")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watched[match.key()]; !ok {
+		w.watched[match.key()] = &watchedDeposit{
+			match:     match,
+			status:    DepositStatusUnknown,
+			watchedAt: time.Now(),
+		}
+	}
+	return nil
+}
+
+// Watching returns the deposits that have not reached a final status
+func (w *DepositWatcher) Watching() []DepositMatch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	matches := make([]DepositMatch, 0, len(w.watched))
+	for _, d := range w.watched {
+		matches = append(matches, d.match)
+	}
+	return matches
+}
+
+// Stats returns credit latency statistics ordered by coin and network
+func (w *DepositWatcher) Stats() []CreditStats {
+	w.mu.Lock()
+	stats := make([]CreditStats, 0, len(w.stats))
+	for _, s := range w.stats {
+		stats = append(stats, *s)
+	}
+	w.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Coin != stats[j].Coin {
+			return stats[i].Coin < stats[j].Coin
+		}
+		return stats[i].Network < stats[j].Network
+	})
+	return stats
+}
+
+// Start polls the deposit history every PollInterval until Close
+func (w *DepositWatcher) Start() {
+	w.mu.Lock()
+	if w.started {
+		w.mu.Unlock()
+		return
+	}
+	w.started = true
+	w.mu.Unlock()
+
+	go w.run()
+}
+
+// Close stops polling
+func (w *DepositWatcher) Close() {
+	select {
+	case <-w.closed:
+		return
+	default:
+	}
+	close(w.closed)
+
+	w.mu.Lock()
+	started := w.started
+	w.mu.Unlock()
+	if started {
+		<-w.done
+	}
+}
+
+// Poll queries the deposit history of every account with watched deposits
+// once and reports status changes
+func (w *DepositWatcher) Poll() error {
+	// One history query per account, starting Lookback before its oldest watch
+	w.mu.Lock()
+	since := make(map[string]time.Time)
+	for _, d := range w.watched {
+		if start, ok := since[d.match.Email]; !ok || d.watchedAt.Before(start) {
+			since[d.match.Email] = d.watchedAt
+		}
+	}
+	w.mu.Unlock()
+
+	records := make(map[string][]Deposit, len(since))
+	for email, start := range since {
+		deposits, err := w.history(email, start.Add(-w.Lookback))
+		if err != nil {
+			return err
+		}
+		records[email] = deposits
+	}
+
+	changes := w.apply(records, time.Now())
+	for _, change := range changes {
+		if w.OnChange != nil {
+			w.OnChange(change)
+		}
+	}
+	return nil
+}
+
+// apply records status changes and credit latencies, and drops final deposits
+func (w *DepositWatcher) apply(records map[string][]Deposit, now time.Time) []DepositChange {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var changes []DepositChange
+	for key, d := range w.watched {
+		for _, record := range records[d.match.Email] {
+			if !d.match.matches(record) {
+				continue
+			}
+			firstSeen := d.status == DepositStatusUnknown
+			if record.Status != d.status {
+				changes = append(changes, DepositChange{Match: d.match, Deposit: record, Previous: d.status})
+				d.status = record.Status
+			}
+			if record.Status.Credited() && !d.credited {
+				d.credited = true
+				w.recordCredit(record, now, firstSeen)
+			}
+			if record.Status.Final() {
+				delete(w.watched, key)
+			}
+			break
+		}
+	}
+	return changes
+}
+
+// recordCredit adds the credit latency of a deposit to its coin and network
+// statistics, measured to its completeTime or else to now, the poll time
+func (w *DepositWatcher) recordCredit(deposit Deposit, now time.Time, firstSeen bool) {
+	if deposit.InsertTime <= 0 {
+		return
+	}
+	credited := now
+	if deposit.CompleteTime > 0 {
+		credited = time.UnixMilli(deposit.CompleteTime)
+	} else if firstSeen {
+		// Credited before the first poll saw it, at an unknown time
+		return
+	}
+	key := strings.ToUpper(deposit.Coin) + "|" + strings.ToUpper(deposit.Network)
+	stats, ok := w.stats[key]
+	if !ok {
+		stats = &CreditStats{Coin: deposit.Coin, Network: deposit.Network}
+		w.stats[key] = stats
+	}
+	stats.add(credited.Sub(time.UnixMilli(deposit.InsertTime)))
+}
+
+// history fetches the deposits of the master account, or of a sub-account
+// when email is set, from start until now
+func (w *DepositWatcher) history(email string, start time.Time) ([]Deposit, error) {
+	if email == "" {
+		return w.wallet.Deposits(start, time.Now())
+	}
+	return w.subAccounts.SubAccountDeposits(email, start, time.Now())
+}
+
+func (w *DepositWatcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+	for {
+		w.reportError(w.Poll())
+		select {
+		case <-ticker.C:
+		case <-w.closed:
+			return
+		}
+	}
+}
+
+func (w *DepositWatcher) reportError(err error) {
+	if err != nil && w.OnError != nil {
+		w.OnError(err)
+	}
+}
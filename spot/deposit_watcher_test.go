@@ -0,0 +1,133 @@
+package spot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDepositMatch(t *testing.T) {
+	deposit := Deposit{Coin: "USDT", Network: "TRX", Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: "150.00000000", TxID: "0xabc"}
+
+	tests := []struct {
+		name  string
+		match DepositMatch
+		want  bool
+	}{
+		{"tx id", DepositMatch{TxID: "0xabc"}, true},
+		{"other tx id", DepositMatch{TxID: "0xdef"}, false},
+		{"address and amount", DepositMatch{Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: 150}, true},
+		{"address, amount and network", DepositMatch{Coin: "usdt", Network: "trx", Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: 150}, true},
+		{"different amount", DepositMatch{Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: 149.99}, false},
+		{"different network", DepositMatch{Network: "ETH", Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: 150}, false},
+		{"missing tag", DepositMatch{Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", AddressTag: "1", Amount: 150}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match.matches(deposit); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepositWatcher(t *testing.T) {
+	insertTime := time.Now().Add(-10 * time.Minute).UnixMilli()
+	var mu sync.Mutex
+	statuses := map[string]string{"master": "0", "sub": "0"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Query().Get("startTime") == "" || r.URL.Query().Get("endTime") == "" || r.URL.Query().Get("limit") == "" {
+			t.Errorf("Expected a bounded window in %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/sapi/v1/capital/deposit/hisrec":
+			w.Write([]byte(`[{"id":"d1","amount":"0.5","coin":"BTC","network":"BTC","status":` + statuses["master"] + `,"address":"bc1qexample","txId":"btc-tx","insertTime":` + formatDecimal(float64(insertTime)) + `,"confirmTimes":"1/2"},
+				{"id":"d3","amount":"2","coin":"ETH","network":"ETH","status":1,"address":"0xexample","txId":"eth-tx","insertTime":` + formatDecimal(float64(insertTime)) + `}]`))
+		case "/sapi/v1/capital/deposit/subHisrec":
+			if r.URL.Query().Get("email") != "sub@example.com" {
+				t.Errorf("Unexpected sub-account query %s", r.URL.RawQuery)
+			}
+			completeTime := "0"
+			if statuses["sub"] == "1" {
+				completeTime = formatDecimal(float64(insertTime + 3*time.Minute.Milliseconds()))
+			}
+			w.Write([]byte(`[{"id":"d2","amount":"150","coin":"USDT","network":"TRX","status":` + statuses["sub"] + `,"address":"TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1","txId":"trx-tx","insertTime":` + formatDecimal(float64(insertTime)) + `,"completeTime":` + completeTime + `}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	set := func(account, status string) {
+		mu.Lock()
+		statuses[account] = status
+		mu.Unlock()
+	}
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	subAccounts := NewSubAccountClient("test_key", "test_secret")
+	subAccounts.BaseURL = server.URL
+
+	if err := NewDepositWatcher(wallet, nil).Watch(DepositMatch{Email: "sub@example.com", TxID: "trx-tx"}); err == nil {
+		t.Error("Expected error watching a sub-account without a SubAccountClient, got nil")
+	}
+
+	watcher := NewDepositWatcher(wallet, subAccounts)
+	var changes []string
+	watcher.OnChange = func(change DepositChange) {
+		changes = append(changes, change.Match.String()+": "+change.Previous.String()+" -> "+change.Deposit.Status.String())
+	}
+	if err := watcher.Watch(DepositMatch{Coin: "BTC"}); err == nil {
+		t.Error("Expected error for match without txId or address, got nil")
+	}
+	watcher.Watch(DepositMatch{TxID: "btc-tx"})
+	watcher.Watch(DepositMatch{TxID: "eth-tx"})
+	watcher.Watch(DepositMatch{Email: "sub@example.com", Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: 150})
+
+	for _, step := range [][2]string{{"0", "0"}, {"6", "1"}, {"1", "1"}} {
+		set("master", step[0])
+		set("sub", step[1])
+		if err := watcher.Poll(); err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+	}
+
+	expected := map[string]bool{
+		"master tx btc-tx: Unknown -> Pending":                                          true,
+		"master tx btc-tx: Pending -> Credited":                                         true,
+		"master tx btc-tx: Credited -> Success":                                         true,
+		"master tx eth-tx: Unknown -> Success":                                          true,
+		"sub@example.com 150 to TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1: Unknown -> Pending": true,
+		"sub@example.com 150 to TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1: Pending -> Success": true,
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("Expected %d changes, got %v", len(expected), changes)
+	}
+	for _, change := range changes {
+		if !expected[change] {
+			t.Errorf("Unexpected change %q", change)
+		}
+	}
+	if watching := watcher.Watching(); len(watching) != 0 {
+		t.Errorf("Expected no deposits left after success, got %+v", watching)
+	}
+
+	// ETH was already credited when first seen and reports no completeTime,
+	// so its latency is unknown
+	stats := watcher.Stats()
+	if len(stats) != 2 || stats[0].Coin != "BTC" || stats[1].Network != "TRX" {
+		t.Fatalf("Expected BTC and USDT credit stats, got %+v", stats)
+	}
+	// BTC is timed to the poll that saw it credited, USDT to its completeTime
+	if btc := stats[0]; btc.Count != 1 || btc.Min < 10*time.Minute || btc.Mean != btc.Last {
+		t.Errorf("Unexpected BTC credit stats %+v", btc)
+	}
+	if usdt := stats[1]; usdt.Count != 1 || usdt.Min != 3*time.Minute || usdt.Max != 3*time.Minute {
+		t.Errorf("Unexpected USDT credit stats %+v", usdt)
+	}
+}
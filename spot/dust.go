@@ -0,0 +1,198 @@
+package spot
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+// ErrNoDust is returned by ConvertDustBelow when no asset qualifies for conversion
+var ErrNoDust = errors.New("no convertible dust assets")
+
+// Account types of dust conversion
+const (
+	DustAccountSpot   = "SPOT"
+	DustAccountMargin = "MARGIN"
+)
+
+// DustAssets is the response of WalletClient.DustAssets
+type DustAssets struct {
+	Details            []DustAsset `json:"details"`
+	TotalTransferBtc   string      `json:"totalTransferBtc"`
+	TotalTransferBNB   string      `json:"totalTransferBNB"`
+	DribbletPercentage string      `json:"dribbletPercentage"` // service charge rate
+}
+
+// DustAsset is an asset that can be converted to BNB
+type DustAsset struct {
+	Asset            string `json:"asset"`
+	AssetFullName    string `json:"assetFullName"`
+	AmountFree       string `json:"amountFree"`
+	ToBTC            string `json:"toBTC"`
+	ToBNB            string `json:"toBNB"`
+	ToBNBOffExchange string `json:"toBNBOffExchange"` // BNB received after the service charge
+	Exchange         string `json:"exchange"`         // service charge in BNB
+}
+
+// DustTransferResult is the response of WalletClient.DustTransfer
+type DustTransferResult struct {
+	TotalServiceCharge string               `json:"totalServiceCharge"`
+	TotalTransfered    string               `json:"totalTransfered"`
+	TransferResult     []DustTransferDetail `json:"transferResult"`
+}
+
+// DustTransferDetail is the conversion of one asset to BNB
+type DustTransferDetail struct {
+	Amount              string `json:"amount"`
+	FromAsset           string `json:"fromAsset"`
+	OperateTime         int64  `json:"operateTime"`
+	ServiceChargeAmount string `json:"serviceChargeAmount"`
+	TranID              int64  `json:"tranId"`
+	TransferedAmount    string `json:"transferedAmount"`
+}
+
+// DustLog is the response of WalletClient.DustLog
+type DustLog struct {
+	Total              int              `json:"total"`
+	UserAssetDribblets []DustConversion `json:"userAssetDribblets"`
+}
+
+// DustConversion is one past dust conversion, covering one or more assets
+type DustConversion struct {
+	OperateTime              int64                `json:"operateTime"`
+	TotalTransferedAmount    string               `json:"totalTransferedAmount"`
+	TotalServiceChargeAmount string               `json:"totalServiceChargeAmount"`
+	TransID                  int64                `json:"transId"`
+	UserAssetDribbletDetails []DustTransferDetail `json:"userAssetDribbletDetails"`
+}
+
+// Below returns the assets valued at less than maxBTC, excluding the given
+// assets, ordered from the smallest valuation
+func (d *DustAssets) Below(maxBTC float64, exclude ...string) []DustAsset {
+	var assets []DustAsset
+	for _, asset := range d.Details {
+		if slices.ContainsFunc(exclude, func(e string) bool { return strings.EqualFold(e, asset.Asset) }) {
+			continue
+		}
+		if parseDecimal(asset.ToBTC) < maxBTC {
+			assets = append(assets, asset)
+		}
+	}
+	sort.SliceStable(assets, func(i, j int) bool {
+		return parseDecimal(assets[i].ToBTC) < parseDecimal(assets[j].ToBTC)
+	})
+	return assets
+}
+
+// DustAssets lists assets that can be converted to BNB (USER_DATA)
+// Response can be decoded into DustAssets with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// POST /sapi/v1/asset/dust-btc
+//
+// https://developers.binance.com/docs/wallet/asset/assets-can-convert-bnb
+//
+// Optional parameters:
+//   - accountType: DustAccountSpot or DustAccountMargin. Default SPOT
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) DustAssets(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("POST", "/sapi/v1/asset/dust-btc", params)
+}
+
+// DustTransfer converts small balances of assets to BNB (USER_DATA)
+// Response can be decoded into DustTransferResult with client.ParseResponse.
+//
+// Weight(UID): 10
+//
+// POST /sapi/v1/asset/dust
+//
+// https://developers.binance.com/docs/wallet/asset/dust-transfer
+//
+// Parameters:
+//   - assets: Assets to convert, e.g. []string{"ADA", "DOT"}
+//
+// Optional parameters:
+//   - accountType: DustAccountSpot or DustAccountMargin. Default SPOT
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) DustTransfer(assets []string, params map[string]interface{}) ([]byte, error) {
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("at least one asset is required")
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["asset"] = assets
+
+	return w.SignRequest("POST", "/sapi/v1/asset/dust", params)
+}
+
+// DustLog queries past dust conversions (USER_DATA)
+// Response can be decoded into DustLog with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/asset/dribblet
+//
+// https://developers.binance.com/docs/wallet/asset/dust-log
+//
+// Only conversions from the last 100 days are returned.
+//
+// Optional parameters:
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) DustLog(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/asset/dribblet", params)
+}
+
+// ConvertDustBelow converts every dust-eligible asset valued at less than
+// maxBTC to BNB in one DustTransfer, skipping the assets in exclude
+// Response can be decoded into DustTransferResult with client.ParseResponse.
+// ErrNoDust is returned when no asset qualifies.
+//
+// Optional parameters:
+//   - accountType: DustAccountSpot or DustAccountMargin. Default SPOT
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) ConvertDustBelow(maxBTC float64, exclude []string, params map[string]interface{}) ([]byte, error) {
+	if maxBTC <= 0 {
+		return nil, fmt.Errorf("maximum valuation must be positive")
+	}
+
+	// Only accountType applies to the listing; recvWindow is shared by both calls
+	listParams := make(map[string]interface{})
+	for _, key := range []string{"accountType", "recvWindow"} {
+		if value, ok := params[key]; ok {
+			listParams[key] = value
+		}
+	}
+	response, err := w.DustAssets(listParams)
+	if err != nil {
+		return nil, err
+	}
+	var dust DustAssets
+	if err := client.ParseResponse(response, &dust); err != nil {
+		return nil, fmt.Errorf("failed to parse dust assets: %w", err)
+	}
+
+	selected := dust.Below(maxBTC, exclude...)
+	if len(selected) == 0 {
+		return nil, ErrNoDust
+	}
+	assets := make([]string, len(selected))
+	for i, asset := range selected {
+		assets[i] = asset.Asset
+	}
+	return w.DustTransfer(assets, params)
+}
@@ -0,0 +1,96 @@
+package spot
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+const testDustAssets = `{
+  "details": [
+    {"asset": "ADA", "assetFullName": "ADA", "amountFree": "6.21", "toBTC": "0.00016848", "toBNB": "0.01777302", "toBNBOffExchange": "0.01741756", "exchange": "0.00035546"},
+    {"asset": "DOT", "assetFullName": "Polkadot", "amountFree": "0.1", "toBTC": "0.00001200", "toBNB": "0.00120000", "toBNBOffExchange": "0.00117600", "exchange": "0.00002400"},
+    {"asset": "LINK", "assetFullName": "Chainlink", "amountFree": "1.5", "toBTC": "0.00030000", "toBNB": "0.03000000", "toBNBOffExchange": "0.02940000", "exchange": "0.00060000"}
+  ],
+  "totalTransferBtc": "0.00048048",
+  "totalTransferBNB": "0.04897302",
+  "dribbletPercentage": "0.02000000"
+}`
+
+func TestDustAssetsBelow(t *testing.T) {
+	var dust DustAssets
+	if err := client.ParseResponse([]byte(testDustAssets), &dust); err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		maxBTC  float64
+		exclude []string
+		want    []string
+	}{
+		{"all", 1, nil, []string{"DOT", "ADA", "LINK"}},
+		{"below threshold", 0.0002, nil, []string{"DOT", "ADA"}},
+		{"excluded", 0.0002, []string{"ada"}, []string{"DOT"}},
+		{"none", 0.00001, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, asset := range dust.Below(tt.maxBTC, tt.exclude...) {
+				got = append(got, asset.Asset)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Below() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertDustBelow(t *testing.T) {
+	var converted [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sapi/v1/asset/dust-btc":
+			if r.URL.Query().Get("accountType") != DustAccountMargin {
+				t.Errorf("Expected accountType MARGIN, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(testDustAssets))
+		case "/sapi/v1/asset/dust":
+			converted = append(converted, r.URL.Query()["asset"])
+			w.Write([]byte(`{"totalServiceCharge":"0.00002400","totalTransfered":"0.00117600","transferResult":[{"amount":"0.1","fromAsset":"DOT","operateTime":1563368549307,"serviceChargeAmount":"0.00002400","tranId":2970932918,"transferedAmount":"0.00117600"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	params := map[string]interface{}{"accountType": DustAccountMargin}
+
+	response, err := wallet.ConvertDustBelow(0.0002, []string{"ADA"}, params)
+	if err != nil {
+		t.Fatalf("ConvertDustBelow() error = %v", err)
+	}
+	var result DustTransferResult
+	if err := client.ParseResponse(response, &result); err != nil || len(result.TransferResult) != 1 || result.TransferResult[0].FromAsset != "DOT" {
+		t.Errorf("Unexpected transfer result %+v, error %v", result, err)
+	}
+
+	if _, err := wallet.ConvertDustBelow(0.00001, nil, params); !errors.Is(err, ErrNoDust) {
+		t.Errorf("Expected ErrNoDust, got %v", err)
+	}
+	if _, err := wallet.DustTransfer(nil, nil); err == nil {
+		t.Error("Expected error for empty asset list, got nil")
+	}
+
+	if len(converted) != 1 || strings.Join(converted[0], ",") != "DOT" {
+		t.Errorf("Expected a single conversion of DOT, got %v", converted)
+	}
+}
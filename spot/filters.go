@@ -0,0 +1,453 @@
+package spot
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+// Symbol filter types
+const (
+	FilterPrice              = "PRICE_FILTER"
+	FilterPercentPrice       = "PERCENT_PRICE"
+	FilterPercentPriceBySide = "PERCENT_PRICE_BY_SIDE"
+	FilterLotSize            = "LOT_SIZE"
+	FilterMarketLotSize      = "MARKET_LOT_SIZE"
+	FilterMinNotional        = "MIN_NOTIONAL"
+	FilterNotional           = "NOTIONAL"
+	FilterIcebergParts       = "ICEBERG_PARTS"
+	FilterTrailingDelta      = "TRAILING_DELTA"
+)
+
+// ExchangeInfo is the response of MarketClient.ExchangeInfo
+type ExchangeInfo struct {
+	Timezone   string       `json:"timezone"`
+	ServerTime int64        `json:"serverTime"`
+	Symbols    []SymbolInfo `json:"symbols"`
+}
+
+// SymbolInfo holds the trading rules of a symbol
+type SymbolInfo struct {
+	Symbol                     string         `json:"symbol"`
+	Status                     string         `json:"status"`
+	BaseAsset                  string         `json:"baseAsset"`
+	BaseAssetPrecision         int            `json:"baseAssetPrecision"`
+	QuoteAsset                 string         `json:"quoteAsset"`
+	QuoteAssetPrecision        int            `json:"quoteAssetPrecision"`
+	OrderTypes                 []string       `json:"orderTypes"`
+	IcebergAllowed             bool           `json:"icebergAllowed"`
+	OcoAllowed                 bool           `json:"ocoAllowed"`
+	QuoteOrderQtyMarketAllowed bool           `json:"quoteOrderQtyMarketAllowed"`
+	IsSpotTradingAllowed       bool           `json:"isSpotTradingAllowed"`
+	IsMarginTradingAllowed     bool           `json:"isMarginTradingAllowed"`
+	Filters                    []SymbolFilter `json:"filters"`
+}
+
+// SymbolFilter is a single symbol filter; only the fields of its FilterType are set
+type SymbolFilter struct {
+	FilterType string `json:"filterType"`
+
+	// PRICE_FILTER
+	MinPrice string `json:"minPrice,omitempty"`
+	MaxPrice string `json:"maxPrice,omitempty"`
+	TickSize string `json:"tickSize,omitempty"`
+
+	// PERCENT_PRICE and PERCENT_PRICE_BY_SIDE
+	MultiplierUp      string `json:"multiplierUp,omitempty"`
+	MultiplierDown    string `json:"multiplierDown,omitempty"`
+	BidMultiplierUp   string `json:"bidMultiplierUp,omitempty"`
+	BidMultiplierDown string `json:"bidMultiplierDown,omitempty"`
+	AskMultiplierUp   string `json:"askMultiplierUp,omitempty"`
+	AskMultiplierDown string `json:"askMultiplierDown,omitempty"`
+	AvgPriceMins      int    `json:"avgPriceMins,omitempty"`
+
+	// LOT_SIZE and MARKET_LOT_SIZE
+	MinQty   string `json:"minQty,omitempty"`
+	MaxQty   string `json:"maxQty,omitempty"`
+	StepSize string `json:"stepSize,omitempty"`
+
+	// MIN_NOTIONAL and NOTIONAL
+	MinNotional      string `json:"minNotional,omitempty"`
+	ApplyToMarket    bool   `json:"applyToMarket,omitempty"`
+	MaxNotional      string `json:"maxNotional,omitempty"`
+	ApplyMinToMarket bool   `json:"applyMinToMarket,omitempty"`
+	ApplyMaxToMarket bool   `json:"applyMaxToMarket,omitempty"`
+
+	// ICEBERG_PARTS
+	Limit int `json:"limit,omitempty"`
+
+	// TRAILING_DELTA
+	MinTrailingAboveDelta int64 `json:"minTrailingAboveDelta,omitempty"`
+	MaxTrailingAboveDelta int64 `json:"maxTrailingAboveDelta,omitempty"`
+	MinTrailingBelowDelta int64 `json:"minTrailingBelowDelta,omitempty"`
+	MaxTrailingBelowDelta int64 `json:"maxTrailingBelowDelta,omitempty"`
+
+	// MAX_NUM_ORDERS, MAX_NUM_ALGO_ORDERS, MAX_NUM_ICEBERG_ORDERS and MAX_POSITION
+	MaxNumOrders        int    `json:"maxNumOrders,omitempty"`
+	MaxNumAlgoOrders    int    `json:"maxNumAlgoOrders,omitempty"`
+	MaxNumIcebergOrders int    `json:"maxNumIcebergOrders,omitempty"`
+	MaxPosition         string `json:"maxPosition,omitempty"`
+}
+
+// AvgPrice is the response of MarketClient.AvgPrice
+type AvgPrice struct {
+	Mins      int    `json:"mins"`
+	Price     string `json:"price"`
+	CloseTime int64  `json:"closeTime"`
+}
+
+// Filter returns the filter of the given type, or nil if the symbol has none
+func (s *SymbolInfo) Filter(filterType string) *SymbolFilter {
+	for i := range s.Filters {
+		if s.Filters[i].FilterType == filterType {
+			return &s.Filters[i]
+		}
+	}
+	return nil
+}
+
+// RoundQuantity rounds a quantity down to the LOT_SIZE step size
+func (s *SymbolInfo) RoundQuantity(quantity float64) float64 {
+	if f := s.Filter(FilterLotSize); f != nil {
+		return roundToStep(quantity, f.StepSize, true)
+	}
+	return quantity
+}
+
+// RoundMarketQuantity rounds a MARKET order quantity down to the MARKET_LOT_SIZE
+// step size, falling back to LOT_SIZE when the symbol defines no market step
+func (s *SymbolInfo) RoundMarketQuantity(quantity float64) float64 {
+	if f := s.Filter(FilterMarketLotSize); f != nil && parseDecimal(f.StepSize) > 0 {
+		return roundToStep(quantity, f.StepSize, true)
+	}
+	return s.RoundQuantity(quantity)
+}
+
+// RoundPrice rounds a price to the nearest PRICE_FILTER tick size
+func (s *SymbolInfo) RoundPrice(price float64) float64 {
+	if f := s.Filter(FilterPrice); f != nil {
+		return roundToStep(price, f.TickSize, false)
+	}
+	return price
+}
+
+// ValidateOrder checks an order against every symbol filter that can be evaluated locally
+//
+// avgPrice is the symbol's current average price, used by the PERCENT_PRICE
+// filters and to estimate the notional of MARKET orders. Pass 0 when unknown
+// to skip those checks. Filters that depend on account state (MAX_NUM_ORDERS,
+// MAX_NUM_ALGO_ORDERS, MAX_NUM_ICEBERG_ORDERS and MAX_POSITION) are not checked.
+func (s *SymbolInfo) ValidateOrder(order OrderRequest, avgPrice float64) error {
+	if err := order.Validate(); err != nil {
+		return err
+	}
+	if order.Symbol != s.Symbol {
+		return fmt.Errorf("order symbol %s does not match rules for %s", order.Symbol, s.Symbol)
+	}
+	if s.Status != "" && s.Status != "TRADING" {
+		return fmt.Errorf("symbol %s is not trading (status %s)", s.Symbol, s.Status)
+	}
+	if len(s.OrderTypes) > 0 && !slices.Contains(s.OrderTypes, string(order.Type)) {
+		return fmt.Errorf("order type %s is not allowed on %s", order.Type, s.Symbol)
+	}
+
+	isMarket := order.Type == OrderTypeMarket
+
+	if f := s.Filter(FilterPrice); f != nil {
+		for _, price := range []float64{order.Price, order.StopPrice} {
+			if price <= 0 {
+				continue
+			}
+			if err := checkRange(FilterPrice, "price", price, f.MinPrice, f.MaxPrice, f.TickSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	if order.Quantity > 0 {
+		if f := s.Filter(FilterLotSize); f != nil {
+			if err := checkRange(FilterLotSize, "quantity", order.Quantity, f.MinQty, f.MaxQty, f.StepSize); err != nil {
+				return err
+			}
+		}
+		if f := s.Filter(FilterMarketLotSize); f != nil && isMarket {
+			if err := checkRange(FilterMarketLotSize, "quantity", order.Quantity, f.MinQty, f.MaxQty, f.StepSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	if f := s.Filter(FilterIcebergParts); f != nil && order.IcebergQty > 0 && f.Limit > 0 {
+		if parts := math.Ceil(order.Quantity/order.IcebergQty - 1e-9); parts > float64(f.Limit) {
+			return fmt.Errorf("%s: order splits into %.0f iceberg parts, limit is %d", FilterIcebergParts, parts, f.Limit)
+		}
+	}
+
+	if f := s.Filter(FilterTrailingDelta); f != nil && order.TrailingDelta > 0 {
+		lower, upper := f.MinTrailingBelowDelta, f.MaxTrailingBelowDelta
+		if trailsAbove(order) {
+			lower, upper = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+		}
+		if (lower > 0 && order.TrailingDelta < lower) || (upper > 0 && order.TrailingDelta > upper) {
+			return fmt.Errorf("%s: trailingDelta %d is outside [%d, %d]", FilterTrailingDelta, order.TrailingDelta, lower, upper)
+		}
+	}
+
+	// The notional of a MARKET order is estimated from the average price
+	price := order.Price
+	if isMarket {
+		price = avgPrice
+	}
+	notional := order.Quantity * price
+	if isMarket && order.QuoteOrderQty > 0 {
+		notional = order.QuoteOrderQty
+	}
+
+	if notional > 0 {
+		if f := s.Filter(FilterMinNotional); f != nil && (!isMarket || f.ApplyToMarket) {
+			if minNotional := parseDecimal(f.MinNotional); notional < minNotional {
+				return fmt.Errorf("%s: notional %s is below minimum %s", FilterMinNotional, formatDecimal(notional), f.MinNotional)
+			}
+		}
+		if f := s.Filter(FilterNotional); f != nil {
+			if minNotional := parseDecimal(f.MinNotional); (!isMarket || f.ApplyMinToMarket) && notional < minNotional {
+				return fmt.Errorf("%s: notional %s is below minimum %s", FilterNotional, formatDecimal(notional), f.MinNotional)
+			}
+			if maxNotional := parseDecimal(f.MaxNotional); (!isMarket || f.ApplyMaxToMarket) && maxNotional > 0 && notional > maxNotional {
+				return fmt.Errorf("%s: notional %s is above maximum %s", FilterNotional, formatDecimal(notional), f.MaxNotional)
+			}
+		}
+	}
+
+	if avgPrice > 0 && order.Price > 0 && !isMarket {
+		if f := s.Filter(FilterPercentPrice); f != nil {
+			if err := checkPercentPrice(FilterPercentPrice, order.Price, avgPrice, f.MultiplierUp, f.MultiplierDown); err != nil {
+				return err
+			}
+		}
+		if f := s.Filter(FilterPercentPriceBySide); f != nil {
+			up, down := f.BidMultiplierUp, f.BidMultiplierDown
+			if order.Side == SideSell {
+				up, down = f.AskMultiplierUp, f.AskMultiplierDown
+			}
+			if err := checkPercentPrice(FilterPercentPriceBySide, order.Price, avgPrice, up, down); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExchangeRules is a cached store of symbol trading rules from exchangeInfo
+type ExchangeRules struct {
+	market *MarketClient
+	ttl    time.Duration
+
+	mu        sync.RWMutex
+	symbols   map[string]SymbolInfo
+	updatedAt time.Time
+}
+
+// NewExchangeRules creates a rules store that refreshes exchangeInfo once the
+// cached copy is older than ttl. A ttl of 0 never refreshes automatically.
+func NewExchangeRules(market *MarketClient, ttl time.Duration) *ExchangeRules {
+	return &ExchangeRules{
+		market:  market,
+		ttl:     ttl,
+		symbols: make(map[string]SymbolInfo),
+	}
+}
+
+// Refresh reloads the rules of all symbols from exchangeInfo
+func (r *ExchangeRules) Refresh() error {
+	response, err := r.market.ExchangeInfo(nil)
+	if err != nil {
+		return err
+	}
+
+	var info ExchangeInfo
+	if err := client.ParseResponse(response, &info); err != nil {
+		return fmt.Errorf("failed to parse exchange info: %w", err)
+	}
+
+	symbols := make(map[string]SymbolInfo, len(info.Symbols))
+	for _, symbol := range info.Symbols {
+		symbols[symbol.Symbol] = symbol
+	}
+
+	r.mu.Lock()
+	r.symbols = symbols
+	r.updatedAt = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// Symbol returns the rules of a symbol, refreshing the cache when it is stale
+func (r *ExchangeRules) Symbol(symbol string) (SymbolInfo, error) {
+	r.mu.RLock()
+	stale := r.updatedAt.IsZero() || (r.ttl > 0 && time.Since(r.updatedAt) > r.ttl)
+	r.mu.RUnlock()
+
+	if stale {
+		if err := r.Refresh(); err != nil {
+			return SymbolInfo{}, err
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.symbols[symbol]
+	if !ok {
+		return SymbolInfo{}, fmt.Errorf("unknown symbol %s", symbol)
+	}
+	return info, nil
+}
+
+// RoundQuantity rounds a quantity down to the symbol's LOT_SIZE step size
+func (r *ExchangeRules) RoundQuantity(symbol string, quantity float64) (float64, error) {
+	info, err := r.Symbol(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return info.RoundQuantity(quantity), nil
+}
+
+// RoundPrice rounds a price to the symbol's nearest PRICE_FILTER tick size
+func (r *ExchangeRules) RoundPrice(symbol string, price float64) (float64, error) {
+	info, err := r.Symbol(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return info.RoundPrice(price), nil
+}
+
+// ValidateOrder checks an order against the symbol's filters before submission
+// The current average price is fetched when a PERCENT_PRICE filter or a
+// MARKET order notional needs it.
+func (r *ExchangeRules) ValidateOrder(order OrderRequest) error {
+	info, err := r.Symbol(order.Symbol)
+	if err != nil {
+		return err
+	}
+
+	var avgPrice float64
+	needsAvgPrice := order.Type == OrderTypeMarket ||
+		info.Filter(FilterPercentPrice) != nil ||
+		info.Filter(FilterPercentPriceBySide) != nil
+	if needsAvgPrice {
+		response, err := r.market.AvgPrice(order.Symbol)
+		if err != nil {
+			return err
+		}
+		var avg AvgPrice
+		if err := client.ParseResponse(response, &avg); err != nil {
+			return fmt.Errorf("failed to parse average price: %w", err)
+		}
+		avgPrice = parseDecimal(avg.Price)
+	}
+
+	return info.ValidateOrder(order, avgPrice)
+}
+
+// trailsAbove reports whether an order uses the "above" trailing delta bounds
+func trailsAbove(order OrderRequest) bool {
+	switch order.Type {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit:
+		return order.Side == SideBuy
+	case OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		return order.Side == SideSell
+	}
+	return false
+}
+
+// checkRange validates value against a min/max/step filter; zero bounds are disabled
+func checkRange(filter, field string, value float64, min, max, step string) error {
+	minValue, maxValue, stepValue := parseDecimal(min), parseDecimal(max), parseDecimal(step)
+	if minValue > 0 && value < minValue {
+		return fmt.Errorf("%s: %s %s is below minimum %s", filter, field, formatDecimal(value), min)
+	}
+	if maxValue > 0 && value > maxValue {
+		return fmt.Errorf("%s: %s %s is above maximum %s", filter, field, formatDecimal(value), max)
+	}
+	if stepValue > 0 && !isMultiple(value, min, step) {
+		return fmt.Errorf("%s: %s %s is not a multiple of %s", filter, field, formatDecimal(value), step)
+	}
+	return nil
+}
+
+// isMultiple reports whether value is base plus a whole number of steps
+// The decimals sent to the API are compared exactly, as a float quotient of a
+// large value by a small step drifts from a whole number.
+func isMultiple(value float64, base, step string) bool {
+	offset, ok := new(big.Rat).SetString(formatDecimal(value))
+	if !ok {
+		return false
+	}
+	if baseValue, ok := new(big.Rat).SetString(base); ok {
+		offset.Sub(offset, baseValue)
+	}
+	stepValue, ok := new(big.Rat).SetString(step)
+	if !ok || stepValue.Sign() == 0 {
+		return true
+	}
+	return offset.Quo(offset, stepValue).IsInt()
+}
+
+// checkPercentPrice validates price against multipliers of the average price
+func checkPercentPrice(filter string, price, avgPrice float64, up, down string) error {
+	if upValue := parseDecimal(up); upValue > 0 && price > avgPrice*upValue {
+		return fmt.Errorf("%s: price %s is above %s x average price %s", filter, formatDecimal(price), up, formatDecimal(avgPrice))
+	}
+	if downValue := parseDecimal(down); downValue > 0 && price < avgPrice*downValue {
+		return fmt.Errorf("%s: price %s is below %s x average price %s", filter, formatDecimal(price), down, formatDecimal(avgPrice))
+	}
+	return nil
+}
+
+// roundToStep rounds value to a multiple of step, down when floor is set or to
+// the nearest multiple otherwise, keeping the step's decimal precision
+func roundToStep(value float64, step string, floor bool) float64 {
+	stepValue := parseDecimal(step)
+	if stepValue <= 0 {
+		return value
+	}
+	steps := value / stepValue
+	if floor {
+		steps = math.Floor(steps + 1e-9)
+	} else {
+		steps = math.Round(steps)
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(steps*stepValue, 'f', decimalPlaces(step), 64), 64)
+	return rounded
+}
+
+// decimalPlaces returns the number of significant decimal places of a decimal string
+func decimalPlaces(value string) int {
+	i := strings.IndexByte(value, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(value[i+1:], "0"))
+}
+
+// parseDecimal parses a decimal string returned by the API, treating invalid values as 0
+func parseDecimal(value string) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// formatDecimal formats a float without exponent or trailing zeros
+func formatDecimal(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,137 @@
+package spot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testExchangeInfo = `{
+  "timezone": "UTC",
+  "serverTime": 1700000000000,
+  "symbols": [{
+    "symbol": "BTCUSDT",
+    "status": "TRADING",
+    "baseAsset": "BTC",
+    "baseAssetPrecision": 8,
+    "quoteAsset": "USDT",
+    "quoteAssetPrecision": 8,
+    "orderTypes": ["LIMIT", "LIMIT_MAKER", "MARKET", "STOP_LOSS_LIMIT", "TAKE_PROFIT_LIMIT"],
+    "icebergAllowed": true,
+    "filters": [
+      {"filterType": "PRICE_FILTER", "minPrice": "0.01000000", "maxPrice": "1000000.00000000", "tickSize": "0.01000000"},
+      {"filterType": "LOT_SIZE", "minQty": "0.00001000", "maxQty": "9000.00000000", "stepSize": "0.00001000"},
+      {"filterType": "ICEBERG_PARTS", "limit": 10},
+      {"filterType": "MARKET_LOT_SIZE", "minQty": "0.00000000", "maxQty": "100.00000000", "stepSize": "0.00000000"},
+      {"filterType": "TRAILING_DELTA", "minTrailingAboveDelta": 10, "maxTrailingAboveDelta": 2000, "minTrailingBelowDelta": 10, "maxTrailingBelowDelta": 2000},
+      {"filterType": "PERCENT_PRICE_BY_SIDE", "bidMultiplierUp": "5", "bidMultiplierDown": "0.2", "askMultiplierUp": "5", "askMultiplierDown": "0.2", "avgPriceMins": 5},
+      {"filterType": "NOTIONAL", "minNotional": "5.00000000", "applyMinToMarket": true, "maxNotional": "9000000.00000000", "applyMaxToMarket": false, "avgPriceMins": 5},
+      {"filterType": "MAX_NUM_ORDERS", "maxNumOrders": 200}
+    ]
+  }]
+}`
+
+func testSymbolInfo(t *testing.T) SymbolInfo {
+	var info ExchangeInfo
+	if err := json.Unmarshal([]byte(testExchangeInfo), &info); err != nil {
+		t.Fatalf("Failed to parse exchange info: %v", err)
+	}
+	return info.Symbols[0]
+}
+
+func TestSymbolInfoRounding(t *testing.T) {
+	info := testSymbolInfo(t)
+
+	if got := info.RoundQuantity(0.123456789); got != 0.12345 {
+		t.Errorf("Expected quantity 0.12345, got %v", got)
+	}
+	if got := info.RoundQuantity(0.3); got != 0.3 {
+		t.Errorf("Expected quantity 0.3, got %v", got)
+	}
+	if got := info.RoundPrice(27123.456); got != 27123.46 {
+		t.Errorf("Expected price 27123.46, got %v", got)
+	}
+	if got := info.RoundMarketQuantity(1.234567); got != 1.23456 {
+		t.Errorf("Expected market quantity to fall back to LOT_SIZE, got %v", got)
+	}
+}
+
+func TestSymbolInfoValidateOrder(t *testing.T) {
+	info := testSymbolInfo(t)
+	limit := func(side OrderSide, quantity, price float64) OrderRequest {
+		return OrderRequest{Symbol: "BTCUSDT", Side: side, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: quantity, Price: price}
+	}
+
+	tests := []struct {
+		name     string
+		order    OrderRequest
+		avgPrice float64
+		wantErr  bool
+	}{
+		{"valid limit", limit(SideBuy, 0.001, 20000), 20000, false},
+		{"price off tick", limit(SideBuy, 0.001, 20000.005), 20000, true},
+		{"quantity off step", limit(SideBuy, 0.0000123, 20000), 20000, true},
+		{"large quantity on small step", limit(SideBuy, 1000.00001, 1000), 1000, false},
+		{"quantity above max", limit(SideBuy, 10000, 20000), 20000, true},
+		{"notional below minimum", limit(SideBuy, 0.0001, 20000), 20000, true},
+		{"bid above multiplier", limit(SideBuy, 0.001, 120000), 20000, true},
+		{"ask below multiplier", limit(SideSell, 0.001, 3000), 20000, true},
+		{"percent price skipped without average", limit(SideSell, 0.01, 3000), 0, false},
+		{"market notional below minimum", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeMarket, QuoteOrderQty: 1}, 20000, true},
+		{"market above market lot size", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeMarket, Quantity: 101}, 20000, true},
+		{"order type not allowed", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeStopLoss, Quantity: 0.001, StopPrice: 21000}, 20000, true},
+		{"iceberg parts above limit", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.011, Price: 20000, IcebergQty: 0.001}, 20000, true},
+		{"trailing delta above max", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTakeProfitLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001, Price: 21000, TrailingDelta: 5000}, 20000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := info.ValidateOrder(tt.order, tt.avgPrice)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExchangeRules(t *testing.T) {
+	exchangeInfoCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/exchangeInfo":
+			exchangeInfoCalls++
+			w.Write([]byte(testExchangeInfo))
+		case "/api/v3/avgPrice":
+			w.Write([]byte(`{"mins":5,"price":"20000.00","closeTime":1700000000000}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	market := NewMarketClient("", "")
+	market.BaseURL = server.URL
+	rules := NewExchangeRules(market, time.Hour)
+
+	quantity, err := rules.RoundQuantity("BTCUSDT", 0.123456789)
+	if err != nil {
+		t.Fatalf("RoundQuantity() error = %v", err)
+	}
+	if quantity != 0.12345 {
+		t.Errorf("Expected quantity 0.12345, got %v", quantity)
+	}
+
+	if err := rules.ValidateOrder(OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001, Price: 120000}); err == nil {
+		t.Error("Expected PERCENT_PRICE_BY_SIDE error, got nil")
+	}
+
+	if _, err := rules.Symbol("ETHUSDT"); err == nil {
+		t.Error("Expected error for unknown symbol, got nil")
+	}
+
+	if exchangeInfoCalls != 1 {
+		t.Errorf("Expected exchange info to be fetched once, got %d", exchangeInfoCalls)
+	}
+}
@@ -0,0 +1,103 @@
+package spot
+
+// AccountTrade is a trade record returned by WalletClient.MyTrades
+type AccountTrade struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	OrderListID     int64  `json:"orderListId"` // -1 unless the order is part of an order list
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsBestMatch     bool   `json:"isBestMatch"`
+}
+
+// UniversalTransfers is the response of WalletClient.UniversalTransferHistory
+type UniversalTransfers struct {
+	Total int                 `json:"total"`
+	Rows  []UniversalTransfer `json:"rows"`
+}
+
+// UniversalTransfer is a transfer between the wallets of one account
+type UniversalTransfer struct {
+	Asset     string `json:"asset"`
+	Amount    string `json:"amount"`
+	Type      string `json:"type"`   // e.g. "MAIN_UMFUTURE"
+	Status    string `json:"status"` // "CONFIRMED", "FAILED" or "PENDING"
+	TranID    int64  `json:"tranId"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// SubAccountUniversalTransfers is the response of WalletClient.MasterSubAccountTransferHistory
+// and SubAccountClient.SubAccountUniversalTransferHistory
+type SubAccountUniversalTransfers struct {
+	Result     []SubAccountUniversalTransfer `json:"result"`
+	TotalCount int                           `json:"totalCount"`
+}
+
+// SubAccountUniversalTransfer is a transfer between the master account and its sub-accounts
+// An empty email is the master account.
+type SubAccountUniversalTransfer struct {
+	TranID          int64  `json:"tranId"`
+	FromEmail       string `json:"fromEmail"`
+	ToEmail         string `json:"toEmail"`
+	Asset           string `json:"asset"`
+	Amount          string `json:"amount"`
+	CreateTimeStamp int64  `json:"createTimeStamp"`
+	FromAccountType string `json:"fromAccountType"`
+	ToAccountType   string `json:"toAccountType"`
+	Status          string `json:"status"`
+	ClientTranID    string `json:"clientTranId"`
+}
+
+// SubAccountFuturesTransfers is the response of SubAccountClient.SubAccountFuturesAssetTransferHistory
+type SubAccountFuturesTransfers struct {
+	Success     bool                         `json:"success"`
+	FuturesType int                          `json:"futuresType"`
+	Transfers   []SubAccountInternalTransfer `json:"transfers"`
+}
+
+// SubAccountInternalTransfer is a transfer between the master account and
+// sub-accounts returned by SubAccountClient.SubAccountFuturesAssetTransferHistory
+// and SubAccountClient.SubAccountSpotTransferHistory
+type SubAccountInternalTransfer struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Asset  string `json:"asset"`
+	Qty    string `json:"qty"`
+	Status string `json:"status"` // not set by the futures history
+	TranID int64  `json:"tranId"`
+	Time   int64  `json:"time"`
+}
+
+// ManagedSubAccountTransfers is the response of SubAccountClient.ManagedSubAccountInvestorTransLog
+// and SubAccountClient.ManagedSubAccountTradingTransLog
+type ManagedSubAccountTransfers struct {
+	ManagerSubTransferHistoryVos []ManagedSubAccountTransfer `json:"managerSubTransferHistoryVos"`
+	Count                        int                         `json:"count"`
+}
+
+// ManagedSubAccountTransfer is a transfer into or out of a managed sub-account
+type ManagedSubAccountTransfer struct {
+	FromEmail       string `json:"fromEmail"`
+	FromAccountType string `json:"fromAccountType"`
+	ToEmail         string `json:"toEmail"`
+	ToAccountType   string `json:"toAccountType"`
+	Asset           string `json:"asset"`
+	Amount          string `json:"amount"`
+	ScheduledData   int64  `json:"scheduledData"`
+	CreateTime      int64  `json:"createTime"`
+	Status          string `json:"status"`
+	TranID          int64  `json:"tranId"`
+}
+
+// SubAccountUniversalTransferResult is the response of SubAccountClient.SubAccountUniversalTransfer
+type SubAccountUniversalTransferResult struct {
+	TranID       int64  `json:"tranId"`
+	ClientTranID string `json:"clientTranId"`
+}
@@ -0,0 +1,187 @@
+package spot
+
+// WalletBalance is the valuation of one wallet returned by WalletClient.Balance
+type WalletBalance struct {
+	Activate   bool   `json:"activate"`
+	Balance    string `json:"balance"`    // valued in the requested quoteAsset
+	WalletName string `json:"walletName"` // e.g. "Spot", "Funding", "Cross Margin", "USDⓈ-M Futures"
+}
+
+// UserAsset is a spot balance returned by WalletClient.UserAsset
+type UserAsset struct {
+	Asset        string `json:"asset"`
+	Free         string `json:"free"`
+	Locked       string `json:"locked"`
+	Freeze       string `json:"freeze"`
+	Withdrawing  string `json:"withdrawing"`
+	Ipoable      string `json:"ipoable"`
+	BtcValuation string `json:"btcValuation"` // only sent with needBtcValuation=true
+}
+
+// SubAccountSpotSummary is the response of SubAccountClient.SubAccountSpotSummary
+type SubAccountSpotSummary struct {
+	TotalCount                int                   `json:"totalCount"`
+	MasterAccountTotalAsset   string                `json:"masterAccountTotalAsset"` // in BTC
+	SpotSubUserAssetBtcVoList []SubAccountSpotTotal `json:"spotSubUserAssetBtcVoList"`
+}
+
+// SubAccountSpotTotal is the spot total of one sub-account in BTC
+type SubAccountSpotTotal struct {
+	Email      string `json:"email"`
+	TotalAsset string `json:"totalAsset"`
+}
+
+// SubAccountMarginSummary is the response of SubAccountClient.SubAccountMarginAccountSummary
+type SubAccountMarginSummary struct {
+	TotalAssetOfBtc     string                  `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string                  `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string                  `json:"totalNetAssetOfBtc"`
+	SubAccountList      []SubAccountMarginTotal `json:"subAccountList"`
+}
+
+// SubAccountMarginTotal is the margin account total of one sub-account in BTC
+type SubAccountMarginTotal struct {
+	Email               string `json:"email"`
+	TotalAssetOfBtc     string `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string `json:"totalNetAssetOfBtc"`
+}
+
+// SubAccountFuturesSummary is the response of SubAccountClient.SubAccountFuturesAccountSummary
+// FutureAccountSummaryResp is set for USDⓈ-M futures (futuresType 1) and
+// DeliveryAccountSummaryResp for COIN-M futures (futuresType 2).
+type SubAccountFuturesSummary struct {
+	FutureAccountSummaryResp   *SubAccountFuturesTotals `json:"futureAccountSummaryResp"`
+	DeliveryAccountSummaryResp *SubAccountFuturesTotals `json:"deliveryAccountSummaryResp"`
+}
+
+// SubAccountFuturesTotals sums the futures accounts of every sub-account
+// The COIN-M response names the totals with an OfBTC suffix.
+type SubAccountFuturesTotals struct {
+	TotalInitialMargin          string                   `json:"totalInitialMargin"`
+	TotalMaintenanceMargin      string                   `json:"totalMaintenanceMargin"`
+	TotalMarginBalance          string                   `json:"totalMarginBalance"`
+	TotalMarginBalanceOfBTC     string                   `json:"totalMarginBalanceOfBTC"`
+	TotalOpenOrderInitialMargin string                   `json:"totalOpenOrderInitialMargin"`
+	TotalPositionInitialMargin  string                   `json:"totalPositionInitialMargin"`
+	TotalUnrealizedProfit       string                   `json:"totalUnrealizedProfit"`
+	TotalUnrealizedProfitOfBTC  string                   `json:"totalUnrealizedProfitOfBTC"`
+	TotalWalletBalance          string                   `json:"totalWalletBalance"`
+	TotalWalletBalanceOfBTC     string                   `json:"totalWalletBalanceOfBTC"`
+	Asset                       string                   `json:"asset"` // "USD" for USDⓈ-M, "BTC" for COIN-M
+	SubAccountList              []SubAccountFuturesTotal `json:"subAccountList"`
+}
+
+// SubAccountFuturesTotal is the futures account total of one sub-account in Asset
+type SubAccountFuturesTotal struct {
+	Email                       string `json:"email"`
+	TotalInitialMargin          string `json:"totalInitialMargin"`
+	TotalMaintenanceMargin      string `json:"totalMaintenanceMargin"`
+	TotalMarginBalance          string `json:"totalMarginBalance"`
+	TotalOpenOrderInitialMargin string `json:"totalOpenOrderInitialMargin"`
+	TotalPositionInitialMargin  string `json:"totalPositionInitialMargin"`
+	TotalUnrealizedProfit       string `json:"totalUnrealizedProfit"`
+	TotalWalletBalance          string `json:"totalWalletBalance"`
+	Asset                       string `json:"asset"`
+}
+
+// ManagedSubAccounts is the response of SubAccountClient.QueryManagedSubAccountList
+type ManagedSubAccounts struct {
+	Total                    int                 `json:"total"`
+	ManagerSubUserInfoVoList []ManagedSubAccount `json:"managerSubUserInfoVoList"`
+}
+
+// ManagedSubAccount is a managed sub-account of an investor
+type ManagedSubAccount struct {
+	RootUserID               int64  `json:"rootUserId"`
+	ManagersubUserID         int64  `json:"managersubUserId"`
+	BindParentUserID         int64  `json:"bindParentUserId"`
+	Email                    string `json:"email"`
+	InsertTimeStamp          int64  `json:"insertTimeStamp"`
+	BindParentEmail          string `json:"bindParentEmail"`
+	IsSubUserEnabled         bool   `json:"isSubUserEnabled"`
+	IsUserActive             bool   `json:"isUserActive"`
+	IsMarginEnabled          bool   `json:"isMarginEnabled"`
+	IsFutureEnabled          bool   `json:"isFutureEnabled"`
+	IsSignedLVTRiskAgreement bool   `json:"isSignedLVTRiskAgreement"`
+}
+
+// ManagedSubAccountAsset is a balance returned by SubAccountClient.ManagedSubAccountAssets
+type ManagedSubAccountAsset struct {
+	Coin             string `json:"coin"`
+	Name             string `json:"name"`
+	TotalBalance     string `json:"totalBalance"`
+	AvailableBalance string `json:"availableBalance"`
+	InOrder          string `json:"inOrder"`
+	BtcValue         string `json:"btcValue"`
+}
+
+// SubAccounts is the response of SubAccountClient.SubAccountList
+type SubAccounts struct {
+	SubAccounts []SubAccount `json:"subAccounts"`
+}
+
+// SubAccount is a sub-account of the master account
+type SubAccount struct {
+	Email                       string `json:"email"`
+	IsFreeze                    bool   `json:"isFreeze"`
+	CreateTime                  int64  `json:"createTime"`
+	IsManagedSubAccount         bool   `json:"isManagedSubAccount"`
+	IsAssetManagementSubAccount bool   `json:"isAssetManagementSubAccount"`
+}
+
+// SubAccountBalances is the response of SubAccountClient.QuerySubAccountAssets
+type SubAccountBalances struct {
+	Balances []SubAccountBalance `json:"balances"`
+}
+
+// SubAccountBalance is a spot balance of a sub-account
+type SubAccountBalance struct {
+	Asset       string `json:"asset"`
+	Free        string `json:"free"`
+	Locked      string `json:"locked"`
+	Freeze      string `json:"freeze"`
+	Withdrawing string `json:"withdrawing"`
+}
+
+// SubAccountMarginDetail is the response of SubAccountClient.SubAccountMarginAccount
+type SubAccountMarginDetail struct {
+	Email                 string            `json:"email"`
+	MarginLevel           string            `json:"marginLevel"`
+	TotalAssetOfBtc       string            `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc   string            `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc    string            `json:"totalNetAssetOfBtc"`
+	MarginUserAssetVoList []MarginUserAsset `json:"marginUserAssetVoList"`
+}
+
+// SubAccountFuturesDetail is the response of SubAccountClient.SubAccountFuturesAccount
+// FutureAccountResp is set for USDⓈ-M futures (futuresType 1) and
+// DeliveryAccountResp for COIN-M futures (futuresType 2).
+type SubAccountFuturesDetail struct {
+	FutureAccountResp   *SubAccountFuturesAccount `json:"futureAccountResp"`
+	DeliveryAccountResp *SubAccountFuturesAccount `json:"deliveryAccountResp"`
+}
+
+// SubAccountFuturesAccount is the futures account of one sub-account
+type SubAccountFuturesAccount struct {
+	Email       string                   `json:"email"`
+	Asset       string                   `json:"asset"` // only sent for USDⓈ-M futures
+	Assets      []SubAccountFuturesAsset `json:"assets"`
+	CanDeposit  bool                     `json:"canDeposit"`
+	CanTrade    bool                     `json:"canTrade"`
+	CanWithdraw bool                     `json:"canWithdraw"`
+	UpdateTime  int64                    `json:"updateTime"`
+}
+
+// SubAccountFuturesAsset is an asset balance of a sub-account's futures account
+type SubAccountFuturesAsset struct {
+	Asset                  string `json:"asset"`
+	InitialMargin          string `json:"initialMargin"`
+	MaintenanceMargin      string `json:"maintenanceMargin"`
+	MarginBalance          string `json:"marginBalance"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	WalletBalance          string `json:"walletBalance"`
+}
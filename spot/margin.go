@@ -0,0 +1,640 @@
+package spot
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Margin order side effect types, sent as the sideEffectType parameter of NewOrder
+const (
+	SideEffectNoSideEffect    = "NO_SIDE_EFFECT"
+	SideEffectMarginBuy       = "MARGIN_BUY"
+	SideEffectAutoRepay       = "AUTO_REPAY"
+	SideEffectAutoBorrowRepay = "AUTO_BORROW_REPAY"
+)
+
+// Borrow/repay types of BorrowRepayRecords
+const (
+	BorrowRepayBorrow = "BORROW"
+	BorrowRepayRepay  = "REPAY"
+)
+
+// MarginClient handles cross and isolated margin account endpoints of the own account
+type MarginClient struct {
+	*client.Client
+}
+
+// NewMarginClient creates a new MarginClient
+func NewMarginClient(apiKey, apiSecret string) *MarginClient {
+	return &MarginClient{
+		Client: client.NewClient(apiKey, apiSecret),
+	}
+}
+
+// MarginTransaction is the response of Borrow and Repay
+type MarginTransaction struct {
+	TranID int64 `json:"tranId"`
+}
+
+// MarginAccount is the cross margin account returned by Account
+type MarginAccount struct {
+	Created                    bool              `json:"created"`
+	BorrowEnabled              bool              `json:"borrowEnabled"`
+	MarginLevel                string            `json:"marginLevel"`
+	CollateralMarginLevel      string            `json:"collateralMarginLevel"`
+	TotalAssetOfBtc            string            `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc        string            `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc         string            `json:"totalNetAssetOfBtc"`
+	TotalCollateralValueInUSDT string            `json:"TotalCollateralValueInUSDT"`
+	TotalOpenOrderLossInUSDT   string            `json:"totalOpenOrderLossInUSDT"`
+	TradeEnabled               bool              `json:"tradeEnabled"`
+	TransferInEnabled          bool              `json:"transferInEnabled"`
+	TransferOutEnabled         bool              `json:"transferOutEnabled"`
+	AccountType                string            `json:"accountType"`
+	UserAssets                 []MarginUserAsset `json:"userAssets"`
+}
+
+// MarginUserAsset is an asset balance of a cross margin account
+type MarginUserAsset struct {
+	Asset    string `json:"asset"`
+	Borrowed string `json:"borrowed"`
+	Free     string `json:"free"`
+	Interest string `json:"interest"`
+	Locked   string `json:"locked"`
+	NetAsset string `json:"netAsset"`
+}
+
+// IsolatedMarginAccount is the response of IsolatedAccount
+type IsolatedMarginAccount struct {
+	Assets              []IsolatedMarginSymbol `json:"assets"`
+	TotalAssetOfBtc     string                 `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string                 `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string                 `json:"totalNetAssetOfBtc"`
+}
+
+// IsolatedMarginSymbol is the isolated margin account of one symbol
+type IsolatedMarginSymbol struct {
+	Symbol            string              `json:"symbol"`
+	BaseAsset         IsolatedMarginAsset `json:"baseAsset"`
+	QuoteAsset        IsolatedMarginAsset `json:"quoteAsset"`
+	IsolatedCreated   bool                `json:"isolatedCreated"`
+	Enabled           bool                `json:"enabled"`
+	MarginLevel       string              `json:"marginLevel"`
+	MarginLevelStatus string              `json:"marginLevelStatus"`
+	MarginRatio       string              `json:"marginRatio"`
+	IndexPrice        string              `json:"indexPrice"`
+	LiquidatePrice    string              `json:"liquidatePrice"`
+	LiquidateRate     string              `json:"liquidateRate"`
+	TradeEnabled      bool                `json:"tradeEnabled"`
+}
+
+// IsolatedMarginAsset is the base or quote asset of an isolated margin symbol
+type IsolatedMarginAsset struct {
+	Asset         string `json:"asset"`
+	BorrowEnabled bool   `json:"borrowEnabled"`
+	Borrowed      string `json:"borrowed"`
+	Free          string `json:"free"`
+	Interest      string `json:"interest"`
+	Locked        string `json:"locked"`
+	NetAsset      string `json:"netAsset"`
+	NetAssetOfBtc string `json:"netAssetOfBtc"`
+	RepayEnabled  bool   `json:"repayEnabled"`
+	TotalAsset    string `json:"totalAsset"`
+}
+
+// MarginOrder is a margin order as returned by NewOrder, CancelOrder, GetOrder,
+// GetOpenOrders and GetOrders; fields not sent by an endpoint are left empty
+type MarginOrder struct {
+	Symbol                string `json:"symbol"`
+	OrderID               int64  `json:"orderId"`
+	ClientOrderID         string `json:"clientOrderId"`
+	OrigClientOrderID     string `json:"origClientOrderId"`
+	TransactTime          int64  `json:"transactTime"`
+	Time                  int64  `json:"time"`
+	UpdateTime            int64  `json:"updateTime"`
+	Price                 string `json:"price"`
+	OrigQty               string `json:"origQty"`
+	ExecutedQty           string `json:"executedQty"`
+	CummulativeQuoteQty   string `json:"cummulativeQuoteQty"`
+	Status                string `json:"status"`
+	TimeInForce           string `json:"timeInForce"`
+	Type                  string `json:"type"`
+	Side                  string `json:"side"`
+	StopPrice             string `json:"stopPrice"`
+	IcebergQty            string `json:"icebergQty"`
+	IsWorking             bool   `json:"isWorking"`
+	IsIsolated            bool   `json:"isIsolated"`
+	MarginBuyBorrowAmount string `json:"marginBuyBorrowAmount"`
+	MarginBuyBorrowAsset  string `json:"marginBuyBorrowAsset"`
+}
+
+// BorrowRepayRecords is the response of BorrowRepayRecords
+type BorrowRepayRecords struct {
+	Rows  []BorrowRepayRecord `json:"rows"`
+	Total int64               `json:"total"`
+}
+
+// BorrowRepayRecord is a borrow or repay transaction
+type BorrowRepayRecord struct {
+	Type           string `json:"type"`
+	IsolatedSymbol string `json:"isolatedSymbol"`
+	Amount         string `json:"amount"`
+	Asset          string `json:"asset"`
+	Interest       string `json:"interest"`
+	Principal      string `json:"principal"`
+	Status         string `json:"status"`
+	Timestamp      int64  `json:"timestamp"`
+	TxID           int64  `json:"txId"`
+}
+
+// InterestHistory is the response of InterestHistory
+type InterestHistory struct {
+	Rows  []InterestRecord `json:"rows"`
+	Total int64            `json:"total"`
+}
+
+// InterestRecord is an interest charge
+type InterestRecord struct {
+	TxID                int64  `json:"txId"`
+	InterestAccuredTime int64  `json:"interestAccuredTime"`
+	Asset               string `json:"asset"`
+	RawAsset            string `json:"rawAsset"`
+	Principal           string `json:"principal"`
+	Interest            string `json:"interest"`
+	InterestRate        string `json:"interestRate"`
+	Type                string `json:"type"`
+	IsolatedSymbol      string `json:"isolatedSymbol"`
+}
+
+// MaxBorrowable is the response of MaxBorrowable
+type MaxBorrowable struct {
+	Amount      string `json:"amount"`
+	BorrowLimit string `json:"borrowLimit"`
+}
+
+// MaxTransferable is the response of MaxTransferable
+type MaxTransferable struct {
+	Amount string `json:"amount"`
+}
+
+// ForceLiquidationRecords is the response of ForceLiquidationRecords
+type ForceLiquidationRecords struct {
+	Rows  []ForceLiquidationRecord `json:"rows"`
+	Total int64                    `json:"total"`
+}
+
+// ForceLiquidationRecord is an order placed by a forced liquidation
+type ForceLiquidationRecord struct {
+	AvgPrice    string `json:"avgPrice"`
+	ExecutedQty string `json:"executedQty"`
+	OrderID     int64  `json:"orderId"`
+	Price       string `json:"price"`
+	Qty         string `json:"qty"`
+	Side        string `json:"side"`
+	Symbol      string `json:"symbol"`
+	TimeInForce string `json:"timeInForce"`
+	IsIsolated  bool   `json:"isIsolated"`
+	UpdatedTime int64  `json:"updatedTime"`
+}
+
+// MarginLevelInfo holds the margin level thresholds of the account
+// The account receives a margin call below MarginCallBar and is liquidated below ForceLiquidationBar.
+type MarginLevelInfo struct {
+	NormalBar           string `json:"normalBar"`
+	MarginCallBar       string `json:"marginCallBar"`
+	ForceLiquidationBar string `json:"forceLiquidationBar"`
+}
+
+// Borrow borrows an asset into the cross or an isolated margin account (MARGIN)
+// Response can be decoded into MarginTransaction with client.ParseResponse.
+//
+// Weight(UID): 1500
+//
+// POST /sapi/v1/margin/borrow-repay
+//
+// https://developers.binance.com/docs/margin_trading/borrow-and-repay/Margin-Account-Borrow-Repay
+//
+// Parameters:
+//   - asset: Asset to borrow
+//   - amount: Amount to borrow
+//
+// Optional parameters:
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - symbol: Isolated margin symbol, required when isIsolated is "TRUE"
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) Borrow(asset string, amount float64, params map[string]interface{}) ([]byte, error) {
+	return m.borrowRepay(BorrowRepayBorrow, asset, amount, params)
+}
+
+// Repay repays borrowed assets and interest of the cross or an isolated margin account (MARGIN)
+// Response can be decoded into MarginTransaction with client.ParseResponse.
+//
+// Weight(UID): 1500
+//
+// POST /sapi/v1/margin/borrow-repay
+//
+// https://developers.binance.com/docs/margin_trading/borrow-and-repay/Margin-Account-Borrow-Repay
+//
+// Parameters:
+//   - asset: Asset to repay
+//   - amount: Amount to repay
+//
+// Optional parameters:
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - symbol: Isolated margin symbol, required when isIsolated is "TRUE"
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) Repay(asset string, amount float64, params map[string]interface{}) ([]byte, error) {
+	return m.borrowRepay(BorrowRepayRepay, asset, amount, params)
+}
+
+func (m *MarginClient) borrowRepay(borrowRepayType, asset string, amount float64, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"asset":  asset,
+		"amount": amount,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["asset"] = asset
+	params["amount"] = amount
+	params["type"] = borrowRepayType
+
+	return m.SignRequest("POST", "/sapi/v1/margin/borrow-repay", params)
+}
+
+// BorrowRepayRecords queries borrow or repay records (USER_DATA)
+// Response can be decoded into BorrowRepayRecords with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/borrow-repay
+//
+// https://developers.binance.com/docs/margin_trading/borrow-and-repay/Query-Borrow-Repay
+//
+// Parameters:
+//   - borrowRepayType: BORROW or REPAY
+//
+// Optional parameters:
+//   - asset: Asset
+//   - isolatedSymbol: Isolated margin symbol
+//   - txId: Transaction ID from Borrow or Repay
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - current: Currently querying page, start from 1. Default 1
+//   - size: Default 10, max 100
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) BorrowRepayRecords(borrowRepayType string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(borrowRepayType, "borrowRepayType"); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["type"] = borrowRepayType
+
+	return m.SignRequest("GET", "/sapi/v1/margin/borrow-repay", params)
+}
+
+// Account queries the cross margin account details (USER_DATA)
+// Response can be decoded into MarginAccount with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/account
+//
+// https://developers.binance.com/docs/margin_trading/account/Query-Cross-Margin-Account-Details
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) Account(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.SignRequest("GET", "/sapi/v1/margin/account", params)
+}
+
+// IsolatedAccount queries isolated margin account info (USER_DATA)
+// Response can be decoded into IsolatedMarginAccount with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/isolated/account
+//
+// https://developers.binance.com/docs/margin_trading/account/Query-Isolated-Margin-Account-Info
+//
+// Optional parameters:
+//   - symbols: Up to 5 comma separated symbols, e.g. "BTCUSDT,BNBUSDT"
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) IsolatedAccount(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.SignRequest("GET", "/sapi/v1/margin/isolated/account", params)
+}
+
+// NewOrder posts a new order for the margin account (TRADE)
+// Response can be decoded into MarginOrder with client.ParseResponse.
+//
+// Weight(UID): 6
+//
+// POST /sapi/v1/margin/order
+//
+// https://developers.binance.com/docs/margin_trading/trade/Margin-Account-New-Order
+//
+// Parameters:
+//   - order: The order to place, validated against its type
+//
+// Optional parameters:
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - sideEffectType: SideEffectNoSideEffect, SideEffectMarginBuy, SideEffectAutoRepay or SideEffectAutoBorrowRepay
+//   - autoRepayAtCancel: Repay the borrowed amount when the order is cancelled, default true
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) NewOrder(order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return m.SignRequest("POST", "/sapi/v1/margin/order", order.Params(params))
+}
+
+// CancelOrder cancels an active margin order (TRADE)
+// Response can be decoded into MarginOrder with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// DELETE /sapi/v1/margin/order
+//
+// https://developers.binance.com/docs/margin_trading/trade/Margin-Account-Cancel-Order
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - newClientOrderId: Used to uniquely identify this cancel
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) CancelOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return m.SignRequest("DELETE", "/sapi/v1/margin/order", params)
+}
+
+// CancelOpenOrders cancels all active margin orders on a symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// DELETE /sapi/v1/margin/openOrders
+//
+// https://developers.binance.com/docs/margin_trading/trade/Margin-Account-Cancel-All-Open-Orders
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters:
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) CancelOpenOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return m.SignRequest("DELETE", "/sapi/v1/margin/openOrders", params)
+}
+
+// GetOrder queries a margin order (USER_DATA)
+// Response can be decoded into MarginOrder with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/order
+//
+// https://developers.binance.com/docs/margin_trading/trade/Query-Margin-Account-Order
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) GetOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return m.SignRequest("GET", "/sapi/v1/margin/order", params)
+}
+
+// GetOpenOrders queries open margin orders (USER_DATA)
+// Response can be decoded into []MarginOrder with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/openOrders
+//
+// https://developers.binance.com/docs/margin_trading/trade/Query-Margin-Account-Open-Orders
+//
+// Optional parameters:
+//   - symbol: Trading pair symbol, required for isolated margin
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) GetOpenOrders(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.SignRequest("GET", "/sapi/v1/margin/openOrders", params)
+}
+
+// GetOrders queries all margin orders of a symbol: active, canceled, or filled (USER_DATA)
+// Response can be decoded into []MarginOrder with client.ParseResponse.
+//
+// Weight(IP): 200
+//
+// GET /sapi/v1/margin/allOrders
+//
+// https://developers.binance.com/docs/margin_trading/trade/Query-Margin-Account-All-Orders
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters:
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - orderId: Return orders from this ID onwards
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - limit: Default 500, max 500
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) GetOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return m.SignRequest("GET", "/sapi/v1/margin/allOrders", params)
+}
+
+// MyTrades queries margin account trades of a symbol (USER_DATA)
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/myTrades
+//
+// https://developers.binance.com/docs/margin_trading/trade/Query-Margin-Account-Trade-List
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters:
+//   - isIsolated: "TRUE" for isolated margin, default "FALSE"
+//   - orderId: Only return trades of this order
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - fromId: Trade ID to fetch from
+//   - limit: Default 500, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) MyTrades(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return m.SignRequest("GET", "/sapi/v1/margin/myTrades", params)
+}
+
+// InterestHistory queries interest charged on borrowed assets (USER_DATA)
+// Response can be decoded into InterestHistory with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/margin/interestHistory
+//
+// https://developers.binance.com/docs/margin_trading/borrow-and-repay/Get-Interest-History
+//
+// Optional parameters:
+//   - asset: Asset
+//   - isolatedSymbol: Isolated margin symbol
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - current: Currently querying page, start from 1. Default 1
+//   - size: Default 10, max 100
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) InterestHistory(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.SignRequest("GET", "/sapi/v1/margin/interestHistory", params)
+}
+
+// MaxBorrowable queries the maximum amount of an asset that can be borrowed (USER_DATA)
+// Response can be decoded into MaxBorrowable with client.ParseResponse.
+//
+// Weight(IP): 50
+//
+// GET /sapi/v1/margin/maxBorrowable
+//
+// https://developers.binance.com/docs/margin_trading/borrow-and-repay/Query-Max-Borrow
+//
+// Parameters:
+//   - asset: Asset
+//
+// Optional parameters:
+//   - isolatedSymbol: Isolated margin symbol
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) MaxBorrowable(asset string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(asset, "asset"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["asset"] = asset
+	return m.SignRequest("GET", "/sapi/v1/margin/maxBorrowable", params)
+}
+
+// MaxTransferable queries the maximum amount of an asset that can be transferred out (USER_DATA)
+// Response can be decoded into MaxTransferable with client.ParseResponse.
+//
+// Weight(IP): 50
+//
+// GET /sapi/v1/margin/maxTransferable
+//
+// https://developers.binance.com/docs/margin_trading/transfer/Query-Max-Transfer-Out-Amount
+//
+// Parameters:
+//   - asset: Asset
+//
+// Optional parameters:
+//   - isolatedSymbol: Isolated margin symbol
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) MaxTransferable(asset string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(asset, "asset"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["asset"] = asset
+	return m.SignRequest("GET", "/sapi/v1/margin/maxTransferable", params)
+}
+
+// ForceLiquidationRecords queries forced liquidation records (USER_DATA)
+// Response can be decoded into ForceLiquidationRecords with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /sapi/v1/margin/forceLiquidationRec
+//
+// https://developers.binance.com/docs/margin_trading/trade/Get-Force-Liquidation-Record
+//
+// Optional parameters:
+//   - isolatedSymbol: Isolated margin symbol
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - current: Currently querying page, start from 1. Default 1
+//   - size: Default 10, max 100
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) ForceLiquidationRecords(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.SignRequest("GET", "/sapi/v1/margin/forceLiquidationRec", params)
+}
+
+// MarginLevelInfo queries the margin level thresholds of the cross margin account (USER_DATA)
+// Response can be decoded into MarginLevelInfo with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/margin/tradeCoeff
+//
+// https://developers.binance.com/docs/margin_trading/account/Get-Summary-Of-Margin-Account
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (m *MarginClient) MarginLevelInfo(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.SignRequest("GET", "/sapi/v1/margin/tradeCoeff", params)
+}
@@ -0,0 +1,161 @@
+package spot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestMarginClientMethods(t *testing.T) {
+	client := NewMarginClient("test_key", "test_secret")
+
+	if client == nil {
+		t.Fatal("Expected non-nil client")
+	}
+
+	if client.Client == nil {
+		t.Error("Expected embedded Client to be non-nil")
+	}
+
+	t.Log("MarginClient structure verification passed")
+}
+
+func TestMarginRequiresParameters(t *testing.T) {
+	margin := NewMarginClient("test_key", "test_secret")
+
+	if _, err := margin.Borrow("", 1, nil); err == nil {
+		t.Error("Expected error for empty asset, got nil")
+	}
+	if _, err := margin.BorrowRepayRecords("", nil); err == nil {
+		t.Error("Expected error for empty type, got nil")
+	}
+	if _, err := margin.CancelOrder("BTCUSDT", nil); err == nil {
+		t.Error("Expected error without orderId or origClientOrderId, got nil")
+	}
+	if _, err := margin.MaxBorrowable("", nil); err == nil {
+		t.Error("Expected error for empty asset, got nil")
+	}
+	if _, err := margin.NewOrder(OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit}, nil); err == nil {
+		t.Error("Expected error for invalid order, got nil")
+	}
+}
+
+func TestMarginBorrowRepay(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+query.Get("type")+" "+query.Get("asset")+" "+query.Get("amount"))
+		w.Write([]byte(`{"tranId":100000001}`))
+	}))
+	defer server.Close()
+
+	margin := NewMarginClient("test_key", "test_secret")
+	margin.BaseURL = server.URL
+
+	response, err := margin.Borrow("USDT", 100.5, nil)
+	if err != nil {
+		t.Fatalf("Borrow() error = %v", err)
+	}
+	var transaction MarginTransaction
+	if err := client.ParseResponse(response, &transaction); err != nil || transaction.TranID != 100000001 {
+		t.Errorf("Unexpected transaction %+v, error %v", transaction, err)
+	}
+	if _, err := margin.Repay("USDT", 50, map[string]interface{}{"isIsolated": "TRUE", "symbol": "BTCUSDT"}); err != nil {
+		t.Fatalf("Repay() error = %v", err)
+	}
+
+	expected := []string{
+		"POST /sapi/v1/margin/borrow-repay BORROW USDT 100.5",
+		"POST /sapi/v1/margin/borrow-repay REPAY USDT 50",
+	}
+	for i, request := range expected {
+		if requests[i] != request {
+			t.Errorf("Expected request %q, got %q", request, requests[i])
+		}
+	}
+}
+
+func TestIsolatedMarginAccountDecode(t *testing.T) {
+	data := []byte(`{
+		"assets": [{
+			"baseAsset": {"asset": "BTC", "borrowEnabled": true, "borrowed": "0.00000000", "free": "0.10000000", "interest": "0.00000000", "locked": "0.00000000", "netAsset": "0.10000000", "netAssetOfBtc": "0.10000000", "repayEnabled": true, "totalAsset": "0.10000000"},
+			"quoteAsset": {"asset": "USDT", "borrowEnabled": true, "borrowed": "1000.00000000", "free": "3000.00000000", "interest": "0.50000000", "locked": "0.00000000", "netAsset": "1999.50000000", "netAssetOfBtc": "0.03000000", "repayEnabled": true, "totalAsset": "3000.00000000"},
+			"symbol": "BTCUSDT",
+			"isolatedCreated": true,
+			"enabled": true,
+			"marginLevel": "3.50000000",
+			"marginLevelStatus": "EXCESSIVE",
+			"marginRatio": "10.00000000",
+			"indexPrice": "60000.00000000",
+			"liquidatePrice": "9000.00000000",
+			"liquidateRate": "85.00000000",
+			"tradeEnabled": true
+		}],
+		"totalAssetOfBtc": "0.15000000",
+		"totalLiabilityOfBtc": "0.01666667",
+		"totalNetAssetOfBtc": "0.13333333"
+	}`)
+
+	var account IsolatedMarginAccount
+	if err := client.ParseResponse(data, &account); err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+	if len(account.Assets) != 1 {
+		t.Fatalf("Expected 1 isolated symbol, got %d", len(account.Assets))
+	}
+	symbol := account.Assets[0]
+	if symbol.Symbol != "BTCUSDT" || symbol.QuoteAsset.Borrowed != "1000.00000000" || symbol.MarginLevelStatus != "EXCESSIVE" || symbol.LiquidatePrice != "9000.00000000" {
+		t.Errorf("Unexpected isolated margin symbol: %+v", symbol)
+	}
+}
+
+func TestMarginAccount(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	margin := NewMarginClient(apiKey, apiSecret)
+
+	response, err := margin.Account(nil)
+	if err != nil {
+		t.Fatalf("Account() error = %v", err)
+	}
+
+	var account MarginAccount
+	if err := client.ParseResponse(response, &account); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	prettyJSON, _ := json.MarshalIndent(account, "", "  ")
+	t.Logf("Margin Account Response:\n%s", string(prettyJSON))
+}
+
+func TestMarginLevelInfo(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	margin := NewMarginClient(apiKey, apiSecret)
+
+	response, err := margin.MarginLevelInfo(nil)
+	if err != nil {
+		t.Fatalf("MarginLevelInfo() error = %v", err)
+	}
+
+	var info MarginLevelInfo
+	if err := client.ParseResponse(response, &info); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	t.Logf("Margin level thresholds: normal %s, margin call %s, liquidation %s", info.NormalBar, info.MarginCallBar, info.ForceLiquidationBar)
+}
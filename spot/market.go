@@ -0,0 +1,222 @@
+package spot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// MarketClient handles public market data API endpoints
+type MarketClient struct {
+	*client.Client
+}
+
+// Kline is a candlestick returned by MarketClient.Klines
+// The API returns each kline as an array, which UnmarshalJSON decodes.
+type Kline struct {
+	OpenTime                 int64
+	Open                     string
+	High                     string
+	Low                      string
+	Close                    string
+	Volume                   string
+	CloseTime                int64
+	QuoteAssetVolume         string
+	Trades                   int64
+	TakerBuyBaseAssetVolume  string
+	TakerBuyQuoteAssetVolume string
+}
+
+// UnmarshalJSON decodes a kline from its array form
+func (k *Kline) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) < 11 {
+		return fmt.Errorf("kline has %d fields, expected at least 11", len(fields))
+	}
+	targets := []interface{}{
+		&k.OpenTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume,
+		&k.CloseTime, &k.QuoteAssetVolume, &k.Trades, &k.TakerBuyBaseAssetVolume, &k.TakerBuyQuoteAssetVolume,
+	}
+	for i, target := range targets {
+		if err := json.Unmarshal(fields[i], target); err != nil {
+			return fmt.Errorf("kline field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// SymbolPrice is a latest price returned by MarketClient.TickerPrice
+type SymbolPrice struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// NewMarketClient creates a new MarketClient
+// Market data endpoints are public, so the credentials may be left empty.
+func NewMarketClient(apiKey, apiSecret string) *MarketClient {
+	return &MarketClient{
+		Client: client.NewClient(apiKey, apiSecret),
+	}
+}
+
+// Ping tests connectivity to the REST API
+//
+// Weight(IP): 1
+//
+// GET /api/v3/ping
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/general-endpoints#test-connectivity
+func (m *MarketClient) Ping() ([]byte, error) {
+	return m.Query("GET", "/api/v3/ping", nil)
+}
+
+// Time checks the server time
+//
+// Weight(IP): 1
+//
+// GET /api/v3/time
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/general-endpoints#check-server-time
+func (m *MarketClient) Time() ([]byte, error) {
+	return m.Query("GET", "/api/v3/time", nil)
+}
+
+// ExchangeInfo gets current exchange trading rules and symbol information
+// Response can be decoded into ExchangeInfo with client.ParseResponse.
+//
+// Weight(IP): 20
+//
+// GET /api/v3/exchangeInfo
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/general-endpoints#exchange-information
+//
+// Optional parameters:
+//   - symbol: Trading pair symbol
+//   - symbols: JSON array of symbols, e.g. ["BTCUSDT","BNBBTC"]
+//   - permissions: JSON array of permissions, e.g. ["SPOT","MARGIN"]
+//   - showPermissionSets: true or false
+//   - symbolStatus: TRADING, HALT or BREAK
+func (m *MarketClient) ExchangeInfo(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.Query("GET", "/api/v3/exchangeInfo", params)
+}
+
+// Depth gets the order book
+//
+// Weight(IP): 5 for limit 1-100, 25 for 101-500, 50 for 501-1000, 250 for 1001-5000
+//
+// GET /api/v3/depth
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/market-data-endpoints#order-book
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters:
+//   - limit: Default 100, max 5000
+func (m *MarketClient) Depth(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return m.Query("GET", "/api/v3/depth", params)
+}
+
+// AvgPrice gets the current average price for a symbol
+//
+// Weight(IP): 2
+//
+// GET /api/v3/avgPrice
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/market-data-endpoints#current-average-price
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+func (m *MarketClient) AvgPrice(symbol string) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	return m.Query("GET", "/api/v3/avgPrice", map[string]interface{}{
+		"symbol": symbol,
+	})
+}
+
+// TickerPrice gets the latest price for a symbol or symbols
+// Response can be decoded into []SymbolPrice, or SymbolPrice for a single symbol, with client.ParseResponse.
+//
+// Weight(IP): 2 for a single symbol, 4 when the symbol is omitted
+//
+// GET /api/v3/ticker/price
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/market-data-endpoints#symbol-price-ticker
+//
+// Optional parameters:
+//   - symbol: Trading pair symbol
+//   - symbols: JSON array of symbols, e.g. ["BTCUSDT","BNBBTC"]
+func (m *MarketClient) TickerPrice(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.Query("GET", "/api/v3/ticker/price", params)
+}
+
+// BookTicker gets the best price and quantity on the order book for a symbol or symbols
+//
+// Weight(IP): 2 for a single symbol, 4 when the symbol is omitted
+//
+// GET /api/v3/ticker/bookTicker
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/market-data-endpoints#symbol-order-book-ticker
+//
+// Optional parameters:
+//   - symbol: Trading pair symbol
+//   - symbols: JSON array of symbols, e.g. ["BTCUSDT","BNBBTC"]
+func (m *MarketClient) BookTicker(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return m.Query("GET", "/api/v3/ticker/bookTicker", params)
+}
+
+// Klines gets candlesticks of a symbol, oldest first
+// Response can be decoded into []Kline with client.ParseResponse.
+//
+// Weight(IP): 2
+//
+// GET /api/v3/klines
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/market-data-endpoints#klinecandlestick-data
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//   - interval: Kline interval, e.g. "1m", "1h", "1d"
+//
+// Optional parameters:
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - timeZone: Interval time zone, default 0 (UTC)
+//   - limit: Default 500, max 1000
+func (m *MarketClient) Klines(symbol, interval string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol":   symbol,
+		"interval": interval,
+	}); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	params["interval"] = interval
+	return m.Query("GET", "/api/v3/klines", params)
+}
@@ -0,0 +1,165 @@
+package spot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+// WithdrawPrecision returns the number of decimal places a withdrawal amount may have
+func (n *CoinNetwork) WithdrawPrecision() int {
+	return decimalPlaces(n.WithdrawIntegerMultiple)
+}
+
+// RoundWithdrawAmount rounds an amount down to the network's withdrawal multiple
+func (n *CoinNetwork) RoundWithdrawAmount(amount float64) float64 {
+	return roundToStep(amount, n.WithdrawIntegerMultiple, true)
+}
+
+// NetworkCatalogue is a cached store of coin and network rules from capital/config/getall
+//
+// It answers which networks accept deposits or withdrawals, how many
+// confirmations a deposit needs and the withdrawal fees, limits and precision.
+type NetworkCatalogue struct {
+	wallet *WalletClient
+	ttl    time.Duration
+
+	mu        sync.RWMutex
+	coins     map[string]CoinInfo
+	updatedAt time.Time
+}
+
+// NewNetworkCatalogue creates a catalogue that refreshes capital/config/getall
+// once the cached copy is older than ttl. A ttl of 0 never refreshes automatically.
+func NewNetworkCatalogue(wallet *WalletClient, ttl time.Duration) *NetworkCatalogue {
+	return &NetworkCatalogue{
+		wallet: wallet,
+		ttl:    ttl,
+		coins:  make(map[string]CoinInfo),
+	}
+}
+
+// Refresh reloads the rules of all coins from capital/config/getall
+func (c *NetworkCatalogue) Refresh() error {
+	response, err := c.wallet.AllCoins(nil)
+	if err != nil {
+		return err
+	}
+
+	var list []CoinInfo
+	if err := client.ParseResponse(response, &list); err != nil {
+		return fmt.Errorf("failed to parse coin information: %w", err)
+	}
+
+	coins := make(map[string]CoinInfo, len(list))
+	for _, coin := range list {
+		coins[strings.ToUpper(coin.Coin)] = coin
+	}
+
+	c.mu.Lock()
+	c.coins = coins
+	c.updatedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// refreshIfStale reloads the catalogue when it is empty or older than the ttl
+func (c *NetworkCatalogue) refreshIfStale() error {
+	c.mu.RLock()
+	stale := c.updatedAt.IsZero() || (c.ttl > 0 && time.Since(c.updatedAt) > c.ttl)
+	c.mu.RUnlock()
+
+	if stale {
+		return c.Refresh()
+	}
+	return nil
+}
+
+// Coins returns every coin ordered by symbol, refreshing the cache when it is stale
+func (c *NetworkCatalogue) Coins() ([]CoinInfo, error) {
+	if err := c.refreshIfStale(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	coins := make([]CoinInfo, 0, len(c.coins))
+	for _, coin := range c.coins {
+		coins = append(coins, coin)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i].Coin < coins[j].Coin
+	})
+	return coins, nil
+}
+
+// Coin returns the rules of a coin, refreshing the cache when it is stale
+func (c *NetworkCatalogue) Coin(coin string) (CoinInfo, error) {
+	if err := c.refreshIfStale(); err != nil {
+		return CoinInfo{}, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	info, ok := c.coins[strings.ToUpper(coin)]
+	if !ok {
+		return CoinInfo{}, fmt.Errorf("unknown coin %s", coin)
+	}
+	return info, nil
+}
+
+// Network returns the rules of a coin on a network, or on its default network when network is empty
+func (c *NetworkCatalogue) Network(coin, network string) (CoinNetwork, error) {
+	info, err := c.Coin(coin)
+	if err != nil {
+		return CoinNetwork{}, err
+	}
+	rules := info.Network(network)
+	if rules == nil {
+		if network == "" {
+			return CoinNetwork{}, fmt.Errorf("coin %s has no default network", coin)
+		}
+		return CoinNetwork{}, fmt.Errorf("unknown network %s for %s", network, coin)
+	}
+	return *rules, nil
+}
+
+// DepositNetworks returns the networks a coin can currently be deposited on
+func (c *NetworkCatalogue) DepositNetworks(coin string) ([]CoinNetwork, error) {
+	return c.networks(coin, func(n CoinNetwork) bool { return n.DepositEnable })
+}
+
+// WithdrawNetworks returns the networks a coin can currently be withdrawn on
+func (c *NetworkCatalogue) WithdrawNetworks(coin string) ([]CoinNetwork, error) {
+	return c.networks(coin, func(n CoinNetwork) bool { return n.WithdrawEnable })
+}
+
+// Confirmations returns the block confirmations after which a deposit is
+// credited (minConfirm) and after which it can be withdrawn (unLockConfirm)
+func (c *NetworkCatalogue) Confirmations(coin, network string) (credit, unlock int, err error) {
+	rules, err := c.Network(coin, network)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rules.MinConfirm, rules.UnLockConfirm, nil
+}
+
+// networks returns the networks of a coin accepted by keep
+func (c *NetworkCatalogue) networks(coin string, keep func(CoinNetwork) bool) ([]CoinNetwork, error) {
+	info, err := c.Coin(coin)
+	if err != nil {
+		return nil, err
+	}
+	var networks []CoinNetwork
+	for _, network := range info.NetworkList {
+		if keep(network) {
+			networks = append(networks, network)
+		}
+	}
+	return networks, nil
+}
@@ -0,0 +1,120 @@
+package spot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestNetworkCatalogue(t *testing.T) {
+	configCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sapi/v1/capital/config/getall":
+			configCalls++
+			w.Write([]byte(testCoinConfig))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	catalogue := NewNetworkCatalogue(wallet, time.Hour)
+
+	network, err := catalogue.Network("usdt", "")
+	if err != nil {
+		t.Fatalf("Network() error = %v", err)
+	}
+	if network.Network != "ETH" || network.WithdrawFee != "4" {
+		t.Errorf("Expected default ETH network, got %+v", network)
+	}
+
+	credit, unlock, err := catalogue.Confirmations("XRP", "XRP")
+	if err != nil || credit != 1 || unlock != 0 {
+		t.Errorf("Expected 1 confirmation to credit XRP, got %d, %d, %v", credit, unlock, err)
+	}
+
+	withdrawable, err := catalogue.WithdrawNetworks("USDT")
+	if err != nil || len(withdrawable) != 1 || withdrawable[0].Network != "ETH" {
+		t.Errorf("Expected only ETH to allow USDT withdrawals, got %+v, %v", withdrawable, err)
+	}
+	depositable, err := catalogue.DepositNetworks("XRP")
+	if err != nil || len(depositable) != 1 {
+		t.Errorf("Expected XRP deposits on one network, got %+v, %v", depositable, err)
+	}
+
+	coins, err := catalogue.Coins()
+	if err != nil || len(coins) != 2 || coins[0].Coin != "USDT" {
+		t.Errorf("Expected coins ordered by symbol, got %+v, %v", coins, err)
+	}
+
+	if _, err := catalogue.Network("USDT", "SOL"); err == nil {
+		t.Error("Expected error for unknown network, got nil")
+	}
+	if _, err := catalogue.Coin("DOGE"); err == nil {
+		t.Error("Expected error for unknown coin, got nil")
+	}
+
+	if configCalls != 1 {
+		t.Errorf("Expected coin config to be fetched once, got %d", configCalls)
+	}
+}
+
+func TestCoinNetworkRoundWithdrawAmount(t *testing.T) {
+	network := CoinNetwork{WithdrawIntegerMultiple: "0.00010000"}
+
+	if precision := network.WithdrawPrecision(); precision != 4 {
+		t.Errorf("Expected precision 4, got %d", precision)
+	}
+	if amount := network.RoundWithdrawAmount(12.345678); amount != 12.3456 {
+		t.Errorf("Expected 12.3456, got %v", amount)
+	}
+}
+
+func TestDepositAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("coin") != "XRP" || query.Get("signature") == "" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/sapi/v1/capital/deposit/address":
+			w.Write([]byte(`{"address":"rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh","coin":"XRP","tag":"104816","url":"https://xrpscan.com/account/rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh"}`))
+		case "/sapi/v1/capital/deposit/address/list":
+			w.Write([]byte(`[{"coin":"XRP","address":"rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh","tag":"104816","isDefault":1}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+
+	response, err := wallet.DepositAddress("XRP", map[string]interface{}{"network": "XRP"})
+	if err != nil {
+		t.Fatalf("DepositAddress() error = %v", err)
+	}
+	var address DepositAddress
+	if err := client.ParseResponse(response, &address); err != nil || address.Tag != "104816" {
+		t.Errorf("Unexpected deposit address %+v, error %v", address, err)
+	}
+
+	response, err = wallet.DepositAddressList("XRP", nil)
+	if err != nil {
+		t.Fatalf("DepositAddressList() error = %v", err)
+	}
+	var addresses []DepositAddressInfo
+	if err := client.ParseResponse(response, &addresses); err != nil || len(addresses) != 1 || addresses[0].IsDefault != 1 {
+		t.Errorf("Unexpected deposit addresses %+v, error %v", addresses, err)
+	}
+
+	if _, err := wallet.DepositAddress("", nil); err == nil {
+		t.Error("Expected error for empty coin, got nil")
+	}
+}
@@ -0,0 +1,261 @@
+package spot
+
+import (
+	"fmt"
+
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// OrderSide is the side of an order
+type OrderSide string
+
+const (
+	SideBuy  OrderSide = "BUY"
+	SideSell OrderSide = "SELL"
+)
+
+// OrderType is the type of an order
+type OrderType string
+
+const (
+	OrderTypeLimit           OrderType = "LIMIT"
+	OrderTypeMarket          OrderType = "MARKET"
+	OrderTypeStopLoss        OrderType = "STOP_LOSS"
+	OrderTypeStopLossLimit   OrderType = "STOP_LOSS_LIMIT"
+	OrderTypeTakeProfit      OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
+	OrderTypeLimitMaker      OrderType = "LIMIT_MAKER"
+)
+
+// TimeInForce defines how long an order remains active
+type TimeInForce string
+
+const (
+	TimeInForceGTC TimeInForce = "GTC"
+	TimeInForceIOC TimeInForce = "IOC"
+	TimeInForceFOK TimeInForce = "FOK"
+)
+
+// OrderRequest describes a new spot order
+//
+// Zero values are treated as "not set". Which fields are required depends on
+// the order type:
+//   - LIMIT: TimeInForce, Quantity, Price
+//   - MARKET: Quantity or QuoteOrderQty
+//   - STOP_LOSS / TAKE_PROFIT: Quantity, StopPrice or TrailingDelta
+//   - STOP_LOSS_LIMIT / TAKE_PROFIT_LIMIT: TimeInForce, Quantity, Price, StopPrice or TrailingDelta
+//   - LIMIT_MAKER: Quantity, Price
+type OrderRequest struct {
+	Symbol                  string
+	Side                    OrderSide
+	Type                    OrderType
+	TimeInForce             TimeInForce
+	Quantity                float64
+	QuoteOrderQty           float64
+	Price                   float64
+	NewClientOrderID        string
+	StrategyID              int64
+	StrategyType            int
+	StopPrice               float64
+	TrailingDelta           int64
+	IcebergQty              float64
+	NewOrderRespType        string
+	SelfTradePreventionMode string
+}
+
+// Validate checks that the fields required by the order type are set
+func (o *OrderRequest) Validate() error {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol": o.Symbol,
+		"side":   string(o.Side),
+		"type":   string(o.Type),
+	}); err != nil {
+		return err
+	}
+
+	switch o.Side {
+	case SideBuy, SideSell:
+	default:
+		return fmt.Errorf("invalid order side %s", o.Side)
+	}
+
+	switch o.Type {
+	case OrderTypeLimit:
+		return o.require(true, true, true, false)
+	case OrderTypeMarket:
+		if o.Quantity <= 0 && o.QuoteOrderQty <= 0 {
+			return fmt.Errorf("MARKET order requires quantity or quoteOrderQty")
+		}
+		if o.Quantity > 0 && o.QuoteOrderQty > 0 {
+			return fmt.Errorf("MARKET order cannot have both quantity and quoteOrderQty")
+		}
+		return nil
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		return o.require(false, true, false, true)
+	case OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		return o.require(true, true, true, true)
+	case OrderTypeLimitMaker:
+		return o.require(false, true, true, false)
+	default:
+		return fmt.Errorf("invalid order type %s", o.Type)
+	}
+}
+
+// require reports the first missing field among those requested
+func (o *OrderRequest) require(timeInForce, quantity, price, trigger bool) error {
+	if timeInForce && o.TimeInForce == "" {
+		return fmt.Errorf("%s order requires timeInForce", o.Type)
+	}
+	if quantity && o.Quantity <= 0 {
+		return fmt.Errorf("%s order requires quantity", o.Type)
+	}
+	if price && o.Price <= 0 {
+		return fmt.Errorf("%s order requires price", o.Type)
+	}
+	if trigger && o.StopPrice <= 0 && o.TrailingDelta <= 0 {
+		return fmt.Errorf("%s order requires stopPrice or trailingDelta", o.Type)
+	}
+	return nil
+}
+
+// Params converts the order into request parameters, merged over params
+func (o *OrderRequest) Params(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = o.Symbol
+	params["side"] = string(o.Side)
+	params["type"] = string(o.Type)
+
+	if o.TimeInForce != "" {
+		params["timeInForce"] = string(o.TimeInForce)
+	}
+	if o.Quantity > 0 {
+		params["quantity"] = o.Quantity
+	}
+	if o.QuoteOrderQty > 0 {
+		params["quoteOrderQty"] = o.QuoteOrderQty
+	}
+	if o.Price > 0 {
+		params["price"] = o.Price
+	}
+	if o.NewClientOrderID != "" {
+		params["newClientOrderId"] = o.NewClientOrderID
+	}
+	if o.StrategyID > 0 {
+		params["strategyId"] = o.StrategyID
+	}
+	if o.StrategyType > 0 {
+		params["strategyType"] = o.StrategyType
+	}
+	if o.StopPrice > 0 {
+		params["stopPrice"] = o.StopPrice
+	}
+	if o.TrailingDelta > 0 {
+		params["trailingDelta"] = o.TrailingDelta
+	}
+	if o.IcebergQty > 0 {
+		params["icebergQty"] = o.IcebergQty
+	}
+	if o.NewOrderRespType != "" {
+		params["newOrderRespType"] = o.NewOrderRespType
+	}
+	if o.SelfTradePreventionMode != "" {
+		params["selfTradePreventionMode"] = o.SelfTradePreventionMode
+	}
+	return params
+}
+
+// OCOOrderRequest describes a new OCO order on POST /api/v3/order/oco
+//
+// Symbol, Side, Quantity, Price and StopPrice are required.
+// StopLimitTimeInForce is required when StopLimitPrice is set.
+type OCOOrderRequest struct {
+	Symbol                  string
+	Side                    OrderSide
+	Quantity                float64
+	Price                   float64
+	StopPrice               float64
+	StopLimitPrice          float64
+	StopLimitTimeInForce    TimeInForce
+	ListClientOrderID       string
+	LimitClientOrderID      string
+	LimitIcebergQty         float64
+	StopClientOrderID       string
+	StopIcebergQty          float64
+	TrailingDelta           int64
+	NewOrderRespType        string
+	SelfTradePreventionMode string
+}
+
+// Validate checks that the fields required for an OCO order are set
+func (o *OCOOrderRequest) Validate() error {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol": o.Symbol,
+		"side":   string(o.Side),
+	}); err != nil {
+		return err
+	}
+	switch o.Side {
+	case SideBuy, SideSell:
+	default:
+		return fmt.Errorf("invalid order side %s", o.Side)
+	}
+	if o.Quantity <= 0 {
+		return fmt.Errorf("OCO order requires quantity")
+	}
+	if o.Price <= 0 {
+		return fmt.Errorf("OCO order requires price")
+	}
+	if o.StopPrice <= 0 {
+		return fmt.Errorf("OCO order requires stopPrice")
+	}
+	if o.StopLimitPrice > 0 && o.StopLimitTimeInForce == "" {
+		return fmt.Errorf("OCO order with stopLimitPrice requires stopLimitTimeInForce")
+	}
+	return nil
+}
+
+// Params converts the OCO order into request parameters, merged over params
+func (o *OCOOrderRequest) Params(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = o.Symbol
+	params["side"] = string(o.Side)
+	params["quantity"] = o.Quantity
+	params["price"] = o.Price
+	params["stopPrice"] = o.StopPrice
+
+	if o.StopLimitPrice > 0 {
+		params["stopLimitPrice"] = o.StopLimitPrice
+	}
+	if o.StopLimitTimeInForce != "" {
+		params["stopLimitTimeInForce"] = string(o.StopLimitTimeInForce)
+	}
+	if o.ListClientOrderID != "" {
+		params["listClientOrderId"] = o.ListClientOrderID
+	}
+	if o.LimitClientOrderID != "" {
+		params["limitClientOrderId"] = o.LimitClientOrderID
+	}
+	if o.LimitIcebergQty > 0 {
+		params["limitIcebergQty"] = o.LimitIcebergQty
+	}
+	if o.StopClientOrderID != "" {
+		params["stopClientOrderId"] = o.StopClientOrderID
+	}
+	if o.StopIcebergQty > 0 {
+		params["stopIcebergQty"] = o.StopIcebergQty
+	}
+	if o.TrailingDelta > 0 {
+		params["trailingDelta"] = o.TrailingDelta
+	}
+	if o.NewOrderRespType != "" {
+		params["newOrderRespType"] = o.NewOrderRespType
+	}
+	if o.SelfTradePreventionMode != "" {
+		params["selfTradePreventionMode"] = o.SelfTradePreventionMode
+	}
+	return params
+}
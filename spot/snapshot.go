@@ -0,0 +1,135 @@
+package spot
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Account types of AccountSnapshot
+const (
+	SnapshotSpot    = "SPOT"
+	SnapshotMargin  = "MARGIN"
+	SnapshotFutures = "FUTURES"
+)
+
+// Transfer directions of SubAccountTransfer
+const (
+	SubAccountTransferIn  = 1
+	SubAccountTransferOut = 2
+)
+
+// SubAccountTransfer is a transfer record returned by WalletClient.SubAccountTransferHistory
+type SubAccountTransfer struct {
+	CounterParty    string `json:"counterParty"` // "master" or "subAccount"
+	Email           string `json:"email"`        // email of the counterparty
+	Type            int    `json:"type"`         // SubAccountTransferIn or SubAccountTransferOut
+	Asset           string `json:"asset"`
+	Qty             string `json:"qty"`
+	FromAccountType string `json:"fromAccountType"`
+	ToAccountType   string `json:"toAccountType"`
+	Status          string `json:"status"` // "PROCESS", "SUCCESS" or "FAILURE"
+	TranID          int64  `json:"tranId"`
+	Time            int64  `json:"time"`
+}
+
+// AccountSnapshots is the response of WalletClient.AccountSnapshot
+type AccountSnapshots struct {
+	Code        int               `json:"code"`
+	Msg         string            `json:"msg"`
+	SnapshotVos []AccountSnapshot `json:"snapshotVos"`
+}
+
+// AccountSnapshot is the end of day state of one account type
+// Data depends on Type; decode it with Spot, Margin or Futures.
+type AccountSnapshot struct {
+	Type       string          `json:"type"` // "spot", "margin" or "futures"
+	UpdateTime int64           `json:"updateTime"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Day returns the UTC date the snapshot was taken on
+func (s *AccountSnapshot) Day() time.Time {
+	return time.UnixMilli(s.UpdateTime).UTC().Truncate(24 * time.Hour)
+}
+
+// Spot decodes the data of a spot snapshot
+func (s *AccountSnapshot) Spot() (SpotSnapshot, error) {
+	var data SpotSnapshot
+	err := json.Unmarshal(s.Data, &data)
+	return data, err
+}
+
+// Margin decodes the data of a margin snapshot
+func (s *AccountSnapshot) Margin() (MarginSnapshot, error) {
+	var data MarginSnapshot
+	err := json.Unmarshal(s.Data, &data)
+	return data, err
+}
+
+// Futures decodes the data of a USDⓈ-M futures snapshot
+func (s *AccountSnapshot) Futures() (FuturesSnapshot, error) {
+	var data FuturesSnapshot
+	err := json.Unmarshal(s.Data, &data)
+	return data, err
+}
+
+// IsType reports whether the snapshot is of an account type, e.g. SnapshotSpot
+func (s *AccountSnapshot) IsType(accountType string) bool {
+	return strings.EqualFold(s.Type, accountType)
+}
+
+// SpotSnapshot is the data of a spot snapshot
+type SpotSnapshot struct {
+	Balances        []SnapshotBalance `json:"balances"`
+	TotalAssetOfBtc string            `json:"totalAssetOfBtc"`
+}
+
+// SnapshotBalance is a spot balance in a snapshot
+type SnapshotBalance struct {
+	Asset  string `json:"asset"`
+	Free   string `json:"free"`
+	Locked string `json:"locked"`
+}
+
+// MarginSnapshot is the data of a margin snapshot
+type MarginSnapshot struct {
+	MarginLevel         string                `json:"marginLevel"`
+	TotalAssetOfBtc     string                `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string                `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string                `json:"totalNetAssetOfBtc"`
+	UserAssets          []MarginSnapshotAsset `json:"userAssets"`
+}
+
+// MarginSnapshotAsset is a margin balance in a snapshot
+type MarginSnapshotAsset struct {
+	Asset    string `json:"asset"`
+	Borrowed string `json:"borrowed"`
+	Free     string `json:"free"`
+	Interest string `json:"interest"`
+	Locked   string `json:"locked"`
+	NetAsset string `json:"netAsset"`
+}
+
+// FuturesSnapshot is the data of a USDⓈ-M futures snapshot
+type FuturesSnapshot struct {
+	Assets   []FuturesSnapshotAsset    `json:"assets"`
+	Position []FuturesSnapshotPosition `json:"position"`
+}
+
+// FuturesSnapshotAsset is a futures wallet balance in a snapshot
+// MarginBalance includes the unrealized profit of open positions.
+type FuturesSnapshotAsset struct {
+	Asset         string `json:"asset"`
+	MarginBalance string `json:"marginBalance"`
+	WalletBalance string `json:"walletBalance"`
+}
+
+// FuturesSnapshotPosition is an open futures position in a snapshot
+type FuturesSnapshotPosition struct {
+	EntryPrice       string `json:"entryPrice"`
+	MarkPrice        string `json:"markPrice"`
+	PositionAmt      string `json:"positionAmt"`
+	Symbol           string `json:"symbol"`
+	UnRealizedProfit string `json:"unRealizedProfit"`
+}
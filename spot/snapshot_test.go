@@ -0,0 +1,59 @@
+package spot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestAccountSnapshotDecode(t *testing.T) {
+	body := `{"code":200,"msg":"","snapshotVos":[
+		{"data":{"balances":[{"asset":"BTC","free":"0.09905021","locked":"0.00000000"}],"totalAssetOfBtc":"0.09942700"},"type":"spot","updateTime":1576281599000},
+		{"data":{"marginLevel":"2748.02909813","totalAssetOfBtc":"0.00274803","totalLiabilityOfBtc":"0.00000100","totalNetAssetOfBtc":"0.00274750","userAssets":[{"asset":"XRP","borrowed":"0.00000000","free":"1.00000000","interest":"0.00000000","locked":"0.00000000","netAsset":"1.00000000"}]},"type":"margin","updateTime":1576281599000},
+		{"data":{"assets":[{"asset":"USDT","marginBalance":"118.99782335","walletBalance":"120.23811389"}],"position":[{"entryPrice":"7130.41000000","markPrice":"7257.66239673","positionAmt":"0.01000000","symbol":"BTCUSDT","unRealizedProfit":"1.24029054"}]},"type":"futures","updateTime":1576281599000}]}`
+
+	var snapshots AccountSnapshots
+	if err := client.ParseResponse([]byte(body), &snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots.SnapshotVos) != 3 {
+		t.Fatalf("Expected 3 snapshots, got %d", len(snapshots.SnapshotVos))
+	}
+
+	spotSnapshot := snapshots.SnapshotVos[0]
+	if !spotSnapshot.IsType(SnapshotSpot) || !spotSnapshot.Day().Equal(time.Date(2019, 12, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected spot snapshot %s on %s", spotSnapshot.Type, spotSnapshot.Day())
+	}
+	spotData, err := spotSnapshot.Spot()
+	if err != nil || spotData.TotalAssetOfBtc != "0.09942700" || spotData.Balances[0].Free != "0.09905021" {
+		t.Errorf("Unexpected spot data %+v, err %v", spotData, err)
+	}
+
+	marginData, err := snapshots.SnapshotVos[1].Margin()
+	if err != nil || marginData.TotalNetAssetOfBtc != "0.00274750" || marginData.UserAssets[0].Asset != "XRP" {
+		t.Errorf("Unexpected margin data %+v, err %v", marginData, err)
+	}
+
+	futuresData, err := snapshots.SnapshotVos[2].Futures()
+	if err != nil || futuresData.Assets[0].MarginBalance != "118.99782335" || futuresData.Position[0].Symbol != "BTCUSDT" {
+		t.Errorf("Unexpected futures data %+v, err %v", futuresData, err)
+	}
+}
+
+func TestKlineDecode(t *testing.T) {
+	body := `[[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0"]]`
+
+	var klines []Kline
+	if err := client.ParseResponse([]byte(body), &klines); err != nil {
+		t.Fatal(err)
+	}
+	kline := klines[0]
+	if kline.OpenTime != 1499040000000 || kline.Close != "0.01577100" || kline.CloseTime != 1499644799999 || kline.Trades != 308 || kline.TakerBuyQuoteAssetVolume != "28.46694368" {
+		t.Errorf("Unexpected kline %+v", kline)
+	}
+
+	if err := client.ParseResponse([]byte(`[[1499040000000,"0.01634790"]]`), &klines); err == nil {
+		t.Error("Expected error for a short kline")
+	}
+}
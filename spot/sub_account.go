@@ -44,6 +44,7 @@ func (s *SubAccountClient) SubAccountCreate(subAccountString string, params map[
 
 // SubAccountList queries sub-account list (For Master Account)
 // Fetch sub account list.
+// Response can be decoded into SubAccounts with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/list
 //
@@ -191,6 +192,7 @@ func (s *SubAccountClient) SubAccountEnableMargin(email string, params map[strin
 }
 
 // SubAccountMarginAccount gets detail on sub-account's margin account (For Master Account)
+// Response can be decoded into SubAccountMarginDetail with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/margin/account
 //
@@ -215,6 +217,7 @@ func (s *SubAccountClient) SubAccountMarginAccount(email string, params map[stri
 }
 
 // SubAccountMarginAccountSummary gets summary of sub-account's margin account (For Master Account)
+// Response can be decoded into SubAccountMarginSummary with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/margin/accountSummary
 //
@@ -399,6 +402,7 @@ func (s *SubAccountClient) SubAccountTransferSubAccountHistory(params map[string
 }
 
 // SubAccountFuturesAssetTransferHistory queries sub-account futures asset transfer history (For Master Account)
+// Response can be decoded into SubAccountFuturesTransfers with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/futures/internalTransfer
 //
@@ -470,6 +474,7 @@ func (s *SubAccountClient) SubAccountFuturesAssetTransfer(fromEmail, toEmail str
 }
 
 // SubAccountSpotSummary queries sub-account spot assets summary (For Master Account)
+// Response can be decoded into SubAccountSpotSummary with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/spotSummary
 //
@@ -488,6 +493,7 @@ func (s *SubAccountClient) SubAccountSpotSummary(params map[string]interface{})
 }
 
 // SubAccountUniversalTransfer performs universal transfer (For Master Account)
+// Response can be decoded into SubAccountUniversalTransferResult with client.ParseResponse.
 //
 // POST /sapi/v1/sub-account/universalTransfer
 //
@@ -531,6 +537,7 @@ func (s *SubAccountClient) SubAccountUniversalTransfer(fromAccountType, toAccoun
 }
 
 // SubAccountUniversalTransferHistory queries universal transfer history (For Master Account)
+// Response can be decoded into SubAccountUniversalTransfers with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/universalTransfer
 //
@@ -557,6 +564,7 @@ func (s *SubAccountClient) SubAccountUniversalTransferHistory(params map[string]
 }
 
 // SubAccountFuturesAccount gets detail on sub-account's futures account V2 (For Master Account)
+// Response can be decoded into SubAccountFuturesDetail with client.ParseResponse.
 //
 // GET /sapi/v2/sub-account/futures/account
 //
@@ -586,6 +594,7 @@ func (s *SubAccountClient) SubAccountFuturesAccount(email string, futuresType in
 }
 
 // SubAccountFuturesAccountSummary gets summary of sub-account's futures account V2 (For Master Account)
+// Response can be decoded into SubAccountFuturesSummary with client.ParseResponse.
 //
 // GET /sapi/v2/sub-account/futures/accountSummary
 //
@@ -641,6 +650,7 @@ func (s *SubAccountClient) SubAccountFuturesPositionRisk(email string, futuresTy
 }
 
 // SubAccountSpotTransferHistory queries sub-account spot asset transfer history (For Master Account)
+// Response can be decoded into []SubAccountInternalTransfer with client.ParseResponse.
 //
 // GET /sapi/v1/sub-account/sub/transfer/history
 //
@@ -723,6 +733,7 @@ func (s *SubAccountClient) ManagedSubAccountDeposit(toEmail, asset string, amoun
 }
 
 // ManagedSubAccountAssets queries managed sub-account asset details (For Investor Master Account)
+// Response can be decoded into []ManagedSubAccountAsset with client.ParseResponse.
 //
 // GET /sapi/v1/managed-subaccount/asset
 //
@@ -907,6 +918,7 @@ func (s *SubAccountClient) ManagedSubAccountGetSnapshot(email, snapshotType stri
 }
 
 // ManagedSubAccountInvestorTransLog queries managed sub-account transfer log (Investor)
+// Response can be decoded into ManagedSubAccountTransfers with client.ParseResponse.
 //
 // GET /sapi/v1/managed-subaccount/queryTransLogForInvestor
 //
@@ -946,6 +958,7 @@ func (s *SubAccountClient) ManagedSubAccountInvestorTransLog(email string, start
 }
 
 // ManagedSubAccountTradingTransLog queries managed sub-account transfer log (Trading Team)
+// Response can be decoded into ManagedSubAccountTransfers with client.ParseResponse.
 //
 // GET /sapi/v1/managed-subaccount/queryTransLogForTradeParent
 //
@@ -1015,6 +1028,7 @@ func (s *SubAccountClient) ManagedSubAccountDepositAddress(email, coin string, p
 }
 
 // QuerySubAccountAssets queries sub-account assets V4 (For Master Account)
+// Response can be decoded into SubAccountBalances with client.ParseResponse.
 //
 // GET /sapi/v4/sub-account/assets
 //
@@ -1124,6 +1138,7 @@ func (s *SubAccountClient) QueryManagedSubAccountTransferLog(startTime, endTime
 }
 
 // QueryManagedSubAccountList queries managed sub-account list (For Investor)
+// Response can be decoded into ManagedSubAccounts with client.ParseResponse.
 //
 // GET /sapi/v1/managed-subaccount/info
 //
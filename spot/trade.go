@@ -0,0 +1,553 @@
+package spot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// TradeClient handles spot trading related API endpoints
+type TradeClient struct {
+	*client.Client
+}
+
+// NewTradeClient creates a new TradeClient
+func NewTradeClient(apiKey, apiSecret string) *TradeClient {
+	return &TradeClient{
+		Client: client.NewClient(apiKey, apiSecret),
+	}
+}
+
+// NewOrderTest tests new order creation and signature/recvWindow (TRADE)
+// Creates and validates a new order but does not send it into the matching engine.
+//
+// Weight(IP): 1, or 20 with computeCommissionRates
+//
+// POST /api/v3/order/test
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#test-new-order-trade
+//
+// Parameters:
+//   - order: The order to test, validated against its type
+//
+// Optional parameters:
+//   - computeCommissionRates: true or false
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) NewOrderTest(order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return t.SignRequest("POST", "/api/v3/order/test", order.Params(params))
+}
+
+// NewOrder sends in a new order (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /api/v3/order
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-trade
+//
+// Parameters:
+//   - order: The order to place, validated against its type
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) NewOrder(order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return t.SignRequest("POST", "/api/v3/order", order.Params(params))
+}
+
+// CancelOrder cancels an active order (TRADE)
+//
+// Weight(IP): 1
+//
+// DELETE /api/v3/order
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#cancel-order-trade
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - newClientOrderId: Used to uniquely identify this cancel
+//   - cancelRestrictions: ONLY_NEW or ONLY_PARTIALLY_FILLED
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) CancelOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return t.SignRequest("DELETE", "/api/v3/order", params)
+}
+
+// CancelOpenOrders cancels all active orders on a symbol, including order lists (TRADE)
+//
+// Weight(IP): 1
+//
+// DELETE /api/v3/openOrders
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#cancel-all-open-orders-on-a-symbol-trade
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) CancelOpenOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return t.SignRequest("DELETE", "/api/v3/openOrders", params)
+}
+
+// GetOrder checks an order's status (USER_DATA)
+//
+// Weight(IP): 4
+//
+// GET /api/v3/order
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-order-user_data
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return t.SignRequest("GET", "/api/v3/order", params)
+}
+
+// CancelAndReplace cancels an existing order and places a new order on the same symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /api/v3/order/cancelReplace
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#cancel-an-existing-order-and-send-a-new-order-trade
+//
+// Parameters:
+//   - cancelReplaceMode: STOP_ON_FAILURE or ALLOW_FAILURE
+//   - order: The replacement order, validated against its type
+//
+// Optional parameters (either cancelOrderId or cancelOrigClientOrderId must be sent):
+//   - cancelOrderId: Order ID to cancel
+//   - cancelOrigClientOrderId: Client order ID to cancel
+//   - cancelNewClientOrderId: Used to uniquely identify this cancel
+//   - cancelRestrictions: ONLY_NEW or ONLY_PARTIALLY_FILLED
+//   - orderRateLimitExceededMode: DO_NOTHING or CANCEL_ONLY
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) CancelAndReplace(cancelReplaceMode string, order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(cancelReplaceMode, "cancelReplaceMode"); err != nil {
+		return nil, err
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "cancelOrderId", "cancelOrigClientOrderId"); err != nil {
+		return nil, err
+	}
+	params = order.Params(params)
+	params["cancelReplaceMode"] = cancelReplaceMode
+	return t.SignRequest("POST", "/api/v3/order/cancelReplace", params)
+}
+
+// GetOpenOrders gets all open orders on a symbol, or all symbols if omitted (USER_DATA)
+//
+// Weight(IP): 6 for one symbol, 80 when the symbol is omitted
+//
+// GET /api/v3/openOrders
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#current-open-orders-user_data
+//
+// Optional parameters:
+//   - symbol: Trading pair symbol
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOpenOrders(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return t.SignRequest("GET", "/api/v3/openOrders", params)
+}
+
+// GetOrders gets all account orders; active, canceled, or filled (USER_DATA)
+//
+// Weight(IP): 20
+//
+// GET /api/v3/allOrders
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#all-orders-user_data
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters:
+//   - orderId: Return orders with ID >= orderId
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - limit: Default 500, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return t.SignRequest("GET", "/api/v3/allOrders", params)
+}
+
+// NewOCOOrder sends in a new OCO order (TRADE)
+// This endpoint is deprecated by Binance in favour of NewOrderListOCO.
+//
+// Weight(IP): 1
+//
+// POST /api/v3/order/oco
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-oco---deprecated-trade
+//
+// Parameters:
+//   - order: The OCO order to place
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) NewOCOOrder(order OCOOrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return t.SignRequest("POST", "/api/v3/order/oco", order.Params(params))
+}
+
+// NewOrderListOCO sends in a new one-cancels-the-other order list (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /api/v3/orderList/oco
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-list---oco-trade
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//   - side: BUY or SELL
+//   - quantity: Quantity for both legs
+//   - aboveType: STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+//   - belowType: STOP_LOSS_LIMIT, STOP_LOSS, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+//
+// Optional parameters:
+//   - listClientOrderId, aboveClientOrderId, belowClientOrderId: Client order IDs
+//   - abovePrice, aboveStopPrice, aboveTrailingDelta, aboveTimeInForce, aboveIcebergQty
+//   - belowPrice, belowStopPrice, belowTrailingDelta, belowTimeInForce, belowIcebergQty
+//   - newOrderRespType: ACK, RESULT or FULL
+//   - selfTradePreventionMode: Self trade prevention mode
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) NewOrderListOCO(symbol string, side OrderSide, quantity float64, aboveType, belowType OrderType, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol":    symbol,
+		"side":      string(side),
+		"quantity":  quantity,
+		"aboveType": string(aboveType),
+		"belowType": string(belowType),
+	}); err != nil {
+		return nil, err
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("order list requires a positive quantity")
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	params["side"] = string(side)
+	params["quantity"] = quantity
+	params["aboveType"] = string(aboveType)
+	params["belowType"] = string(belowType)
+
+	return t.SignRequest("POST", "/api/v3/orderList/oco", params)
+}
+
+// NewOrderListOTO sends in a new one-triggers-the-other order list (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /api/v3/orderList/oto
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-list---oto-trade
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//   - working: The working order, placed immediately
+//   - pending: The pending order, placed once the working order fills
+//
+// The working and pending orders are sent with their fields prefixed by
+// "working" and "pending" respectively.
+//
+// Optional parameters:
+//   - listClientOrderId: Client order list ID
+//   - newOrderRespType: ACK, RESULT or FULL
+//   - selfTradePreventionMode: Self trade prevention mode
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) NewOrderListOTO(symbol string, working, pending OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	working.Symbol, pending.Symbol = symbol, symbol
+	if err := working.Validate(); err != nil {
+		return nil, err
+	}
+	if err := pending.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	addOrderListLeg(params, "working", working)
+	addOrderListLeg(params, "pending", pending)
+
+	return t.SignRequest("POST", "/api/v3/orderList/oto", params)
+}
+
+// NewOrderListOTOCO sends in a new one-triggers-a-one-cancels-the-other order list (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /api/v3/orderList/otoco
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-list---otoco-trade
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//   - working: The working order, placed immediately
+//   - pendingAbove: The pending order above the market, placed once the working order fills
+//   - pendingBelow: The pending order below the market, placed once the working order fills
+//
+// Both pending legs share pendingAbove's side and quantity; pendingBelow may
+// leave them empty but must not set different ones.
+//
+// Optional parameters:
+//   - listClientOrderId: Client order list ID
+//   - newOrderRespType: ACK, RESULT or FULL
+//   - selfTradePreventionMode: Self trade prevention mode
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) NewOrderListOTOCO(symbol string, working, pendingAbove, pendingBelow OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	working.Symbol, pendingAbove.Symbol, pendingBelow.Symbol = symbol, symbol, symbol
+	// Side and quantity are shared by both pending legs and sent from pendingAbove
+	if pendingBelow.Side == "" {
+		pendingBelow.Side = pendingAbove.Side
+	} else if pendingBelow.Side != pendingAbove.Side {
+		return nil, fmt.Errorf("pending legs must have the same side, got %s and %s", pendingAbove.Side, pendingBelow.Side)
+	}
+	if pendingBelow.Quantity == 0 {
+		pendingBelow.Quantity = pendingAbove.Quantity
+	} else if pendingBelow.Quantity != pendingAbove.Quantity {
+		return nil, fmt.Errorf("pending legs must have the same quantity, got %v and %v", pendingAbove.Quantity, pendingBelow.Quantity)
+	}
+	for _, leg := range []OrderRequest{working, pendingAbove, pendingBelow} {
+		if err := leg.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	addOrderListLeg(params, "working", working)
+	addOrderListLeg(params, "pendingAbove", pendingAbove)
+	addOrderListLeg(params, "pendingBelow", pendingBelow)
+	for _, key := range []string{"pendingAboveSide", "pendingBelowSide", "pendingAboveQuantity", "pendingBelowQuantity"} {
+		delete(params, key)
+	}
+	params["pendingSide"] = string(pendingAbove.Side)
+	params["pendingQuantity"] = pendingAbove.Quantity
+
+	return t.SignRequest("POST", "/api/v3/orderList/otoco", params)
+}
+
+// addOrderListLeg adds an order list leg to params with its fields prefixed
+func addOrderListLeg(params map[string]interface{}, prefix string, order OrderRequest) {
+	for key, value := range order.Params(nil) {
+		switch key {
+		case "symbol", "newOrderRespType", "selfTradePreventionMode":
+			continue
+		case "newClientOrderId":
+			key = "ClientOrderId"
+		}
+		params[prefix+strings.ToUpper(key[:1])+key[1:]] = value
+	}
+}
+
+// CancelOrderList cancels an entire order list (TRADE)
+//
+// Weight(IP): 1
+//
+// DELETE /api/v3/orderList
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#cancel-order-list-trade
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters (either orderListId or listClientOrderId must be sent):
+//   - orderListId: Order list ID
+//   - listClientOrderId: Client order list ID
+//   - newClientOrderId: Used to uniquely identify this cancel
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) CancelOrderList(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderListId", "listClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return t.SignRequest("DELETE", "/api/v3/orderList", params)
+}
+
+// GetOrderList retrieves a specific order list (USER_DATA)
+//
+// Weight(IP): 4
+//
+// GET /api/v3/orderList
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-order-list-user_data
+//
+// Optional parameters (either orderListId or origClientOrderId must be sent):
+//   - orderListId: Order list ID
+//   - origClientOrderId: Client order list ID
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOrderList(params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckOneOfParameters(params, "orderListId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	return t.SignRequest("GET", "/api/v3/orderList", params)
+}
+
+// GetOrderLists retrieves all order lists based on provided optional parameters (USER_DATA)
+//
+// Weight(IP): 20
+//
+// GET /api/v3/allOrderList
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-all-order-lists-user_data
+//
+// Optional parameters:
+//   - fromId: If supplied, neither startTime nor endTime can be provided
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - limit: Default 500, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOrderLists(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return t.SignRequest("GET", "/api/v3/allOrderList", params)
+}
+
+// GetOpenOrderLists retrieves all open order lists (USER_DATA)
+//
+// Weight(IP): 6
+//
+// GET /api/v3/openOrderList
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-open-order-lists-user_data
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOpenOrderLists(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return t.SignRequest("GET", "/api/v3/openOrderList", params)
+}
+
+// Account gets current account information (USER_DATA)
+//
+// Weight(IP): 20
+//
+// GET /api/v3/account
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#account-information-user_data
+//
+// Optional parameters:
+//   - omitZeroBalances: When true, emits only the non-zero balances
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) Account(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return t.SignRequest("GET", "/api/v3/account", params)
+}
+
+// GetOrderRateLimit displays the user's unfilled order count for all intervals (USER_DATA)
+//
+// Weight(IP): 40
+//
+// GET /api/v3/rateLimit/order
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-unfilled-order-count-user_data
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) GetOrderRateLimit(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return t.SignRequest("GET", "/api/v3/rateLimit/order", params)
+}
+
+// QueryPreventedMatches displays the list of orders that were expired due to STP (USER_DATA)
+//
+// Weight(IP): 2 when querying by preventedMatchId, 20 when querying by orderId
+//
+// GET /api/v3/myPreventedMatches
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-prevented-matches-user_data
+//
+// Parameters:
+//   - symbol: Trading pair symbol
+//
+// Optional parameters (one of preventedMatchId or orderId must be sent):
+//   - preventedMatchId: Prevented match ID
+//   - orderId: Order ID
+//   - fromPreventedMatchId: Prevented match ID to start from
+//   - limit: Default 500, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (t *TradeClient) QueryPreventedMatches(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "preventedMatchId", "orderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return t.SignRequest("GET", "/api/v3/myPreventedMatches", params)
+}
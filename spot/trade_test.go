@@ -0,0 +1,242 @@
+package spot
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestNewTradeClient(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	client := NewTradeClient(apiKey, apiSecret)
+
+	if client == nil {
+		t.Fatal("Expected non-nil client")
+	}
+
+	if client.APIKey != apiKey {
+		t.Errorf("Expected APIKey %s, got %s", apiKey, client.APIKey)
+	}
+
+	if client.BaseURL == "" {
+		t.Error("Expected non-empty BaseURL")
+	}
+
+	t.Logf("TradeClient initialized successfully with BaseURL: %s", client.BaseURL)
+}
+
+func TestTradeClientMethods(t *testing.T) {
+	client := NewTradeClient("test_key", "test_secret")
+
+	if client == nil {
+		t.Fatal("Expected non-nil client")
+	}
+
+	if client.Client == nil {
+		t.Error("Expected embedded Client to be non-nil")
+	}
+
+	t.Log("TradeClient structure verification passed")
+}
+
+func TestOrderRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   OrderRequest
+		wantErr bool
+	}{
+		{"limit", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001, Price: 20000}, false},
+		{"limit without price", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001}, true},
+		{"limit without timeInForce", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, Quantity: 0.001, Price: 20000}, true},
+		{"market with quantity", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeMarket, Quantity: 0.001}, false},
+		{"market with quoteOrderQty", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeMarket, QuoteOrderQty: 10}, false},
+		{"market without size", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeMarket}, true},
+		{"market with both sizes", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeMarket, Quantity: 1, QuoteOrderQty: 10}, true},
+		{"stop loss with trailing delta", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStopLoss, Quantity: 0.001, TrailingDelta: 100}, false},
+		{"stop loss without trigger", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStopLoss, Quantity: 0.001}, true},
+		{"take profit limit", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTakeProfitLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001, Price: 30000, StopPrice: 29000}, false},
+		{"limit maker without price", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimitMaker, Quantity: 0.001}, true},
+		{"missing symbol", OrderRequest{Side: SideBuy, Type: OrderTypeMarket, Quantity: 1}, true},
+		{"invalid side", OrderRequest{Symbol: "BTCUSDT", Side: "HOLD", Type: OrderTypeMarket, Quantity: 1}, true},
+		{"invalid type", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: "ICEBERG", Quantity: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOCOOrderRequestValidate(t *testing.T) {
+	order := OCOOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Quantity: 0.001, Price: 30000, StopPrice: 19000, StopLimitPrice: 18900}
+	if err := order.Validate(); err == nil {
+		t.Error("Expected error for stopLimitPrice without stopLimitTimeInForce, got nil")
+	}
+
+	order.StopLimitTimeInForce = TimeInForceGTC
+	if err := order.Validate(); err != nil {
+		t.Errorf("Expected valid OCO order, got %v", err)
+	}
+}
+
+func TestAddOrderListLeg(t *testing.T) {
+	params := make(map[string]interface{})
+	addOrderListLeg(params, "working", OrderRequest{
+		Symbol:           "BTCUSDT",
+		Side:             SideBuy,
+		Type:             OrderTypeLimit,
+		TimeInForce:      TimeInForceGTC,
+		Quantity:         0.001,
+		Price:            20000,
+		NewClientOrderID: "my-order",
+	})
+
+	expected := map[string]interface{}{
+		"workingSide":          "BUY",
+		"workingType":          "LIMIT",
+		"workingTimeInForce":   "GTC",
+		"workingQuantity":      0.001,
+		"workingPrice":         20000.0,
+		"workingClientOrderId": "my-order",
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, params[key])
+		}
+	}
+	if _, ok := params["workingSymbol"]; ok {
+		t.Error("Expected symbol to be omitted from order list leg")
+	}
+}
+
+func TestNewOrderRequiresValidOrder(t *testing.T) {
+	client := NewTradeClient("test_key", "test_secret")
+
+	_, err := client.NewOrder(OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit}, nil)
+	if err == nil {
+		t.Error("Expected error for incomplete LIMIT order, got nil")
+	}
+	t.Logf("Correctly returned error for incomplete order: %v", err)
+}
+
+func TestNewOrderListValidation(t *testing.T) {
+	client := NewTradeClient("test_key", "test_secret")
+
+	if _, err := client.NewOrderListOCO("BTCUSDT", SideSell, 0, OrderTypeLimitMaker, OrderTypeStopLoss, nil); err == nil {
+		t.Error("Expected error for OCO order list without quantity, got nil")
+	}
+
+	working := OrderRequest{Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 1, Price: 20000}
+	above := OrderRequest{Side: SideSell, Type: OrderTypeLimitMaker, Quantity: 1, Price: 21000}
+	for name, below := range map[string]OrderRequest{
+		"side":     {Side: SideBuy, Type: OrderTypeStopLoss, StopPrice: 19000},
+		"quantity": {Type: OrderTypeStopLoss, Quantity: 2, StopPrice: 19000},
+	} {
+		if _, err := client.NewOrderListOTOCO("BTCUSDT", working, above, below, nil); err == nil {
+			t.Errorf("Expected error for pending legs with a different %s, got nil", name)
+		}
+	}
+}
+
+func TestCancelOrderRequiresOrderID(t *testing.T) {
+	client := NewTradeClient("test_key", "test_secret")
+
+	_, err := client.CancelOrder("BTCUSDT", nil)
+	if err == nil {
+		t.Error("Expected error for missing orderId and origClientOrderId, got nil")
+	}
+	t.Logf("Correctly returned error for missing order ID: %v", err)
+}
+
+func TestNewOrderTest(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	client := NewTradeClient(apiKey, apiSecret)
+
+	response, err := client.NewOrderTest(OrderRequest{
+		Symbol:      "BTCUSDT",
+		Side:        SideBuy,
+		Type:        OrderTypeLimit,
+		TimeInForce: TimeInForceGTC,
+		Quantity:    0.001,
+		Price:       10000,
+	}, nil)
+	if err != nil {
+		t.Logf("API Error (this may be expected): %v", err)
+		return
+	}
+
+	t.Logf("Test order response: %s", string(response))
+}
+
+func TestAccount(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	client := NewTradeClient(apiKey, apiSecret)
+
+	response, err := client.Account(map[string]interface{}{
+		"omitZeroBalances": true,
+	})
+	if err != nil {
+		t.Logf("API Error (this may be expected): %v", err)
+		return
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(response, &result); err != nil {
+		t.Errorf("Failed to parse response: %v", err)
+		t.Logf("Raw response: %s", string(response))
+		return
+	}
+
+	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
+	t.Logf("Account response:\n%s", string(prettyJSON))
+}
+
+func TestGetOpenOrders(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	client := NewTradeClient(apiKey, apiSecret)
+
+	response, err := client.GetOpenOrders(map[string]interface{}{
+		"symbol": "BTCUSDT",
+	})
+	if err != nil {
+		t.Logf("API Error (this may be expected): %v", err)
+		return
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(response, &result); err != nil {
+		t.Errorf("Failed to parse response: %v", err)
+		return
+	}
+
+	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
+	t.Logf("Open orders response:\n%s", string(prettyJSON))
+}
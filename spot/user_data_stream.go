@@ -0,0 +1,77 @@
+package spot
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// UserDataStreamClient handles user data stream listen key endpoints
+type UserDataStreamClient struct {
+	*client.Client
+}
+
+// NewUserDataStreamClient creates a new UserDataStreamClient
+// Listen key endpoints only require the API key; the secret may be left empty.
+func NewUserDataStreamClient(apiKey, apiSecret string) *UserDataStreamClient {
+	return &UserDataStreamClient{
+		Client: client.NewClient(apiKey, apiSecret),
+	}
+}
+
+// ListenKey is the response of UserDataStreamClient.NewListenKey
+type ListenKey struct {
+	ListenKey string `json:"listenKey"`
+}
+
+// NewListenKey starts a new user data stream (USER_STREAM)
+// The stream will close after 60 minutes unless a keepalive is sent.
+// If the account has an active listenKey, that listenKey will be returned.
+// Response can be decoded into ListenKey with client.ParseResponse.
+//
+// Weight(IP): 2
+//
+// POST /api/v3/userDataStream
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/user-data-stream#create-a-listenkey-user_stream
+func (u *UserDataStreamClient) NewListenKey() ([]byte, error) {
+	return u.SendRequest("POST", "/api/v3/userDataStream", nil)
+}
+
+// RenewListenKey keeps a user data stream alive for another 60 minutes (USER_STREAM)
+// It's recommended to send a keepalive about every 30 minutes.
+//
+// Weight(IP): 2
+//
+// PUT /api/v3/userDataStream
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/user-data-stream#pingkeep-alive-a-listenkey-user_stream
+//
+// Parameters:
+//   - listenKey: The listen key to keep alive
+func (u *UserDataStreamClient) RenewListenKey(listenKey string) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(listenKey, "listenKey"); err != nil {
+		return nil, err
+	}
+	return u.SendRequest("PUT", "/api/v3/userDataStream", map[string]interface{}{
+		"listenKey": listenKey,
+	})
+}
+
+// CloseListenKey closes out a user data stream (USER_STREAM)
+//
+// Weight(IP): 2
+//
+// DELETE /api/v3/userDataStream
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/user-data-stream#close-a-listenkey-user_stream
+//
+// Parameters:
+//   - listenKey: The listen key to close
+func (u *UserDataStreamClient) CloseListenKey(listenKey string) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(listenKey, "listenKey"); err != nil {
+		return nil, err
+	}
+	return u.SendRequest("DELETE", "/api/v3/userDataStream", map[string]interface{}{
+		"listenKey": listenKey,
+	})
+}
@@ -0,0 +1,46 @@
+package spot
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func TestNewListenKey(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	streamClient := NewUserDataStreamClient(apiKey, apiSecret)
+
+	response, err := streamClient.NewListenKey()
+	if err != nil {
+		t.Logf("API Error (this may be expected): %v", err)
+		return
+	}
+
+	var key ListenKey
+	if err := client.ParseResponse(response, &key); err != nil {
+		t.Errorf("Failed to parse response: %v", err)
+		return
+	}
+	t.Logf("Listen key created: %s", key.ListenKey)
+
+	if _, err := streamClient.CloseListenKey(key.ListenKey); err != nil {
+		t.Logf("API Error closing listen key: %v", err)
+	}
+}
+
+func TestRenewListenKeyRequiresListenKey(t *testing.T) {
+	client := NewUserDataStreamClient("test_key", "test_secret")
+
+	_, err := client.RenewListenKey("")
+	if err == nil {
+		t.Error("Expected error for empty listenKey, got nil")
+	}
+	t.Logf("Correctly returned error for empty listenKey: %v", err)
+}
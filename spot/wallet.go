@@ -18,6 +18,7 @@ func NewWalletClient(apiKey, apiSecret string) *WalletClient {
 }
 
 // Balance queries user wallet balance (USER_DATA)
+// Response can be decoded into []WalletBalance with client.ParseResponse.
 //
 // Weight(IP): 60
 //
@@ -36,6 +37,7 @@ func (w *WalletClient) Balance(params map[string]interface{}) ([]byte, error) {
 }
 
 // UserAsset gets user assets, just for positive data (USER_DATA)
+// Response can be decoded into []UserAsset with client.ParseResponse.
 //
 // Weight(IP): 5
 //
@@ -101,6 +103,7 @@ func (w *WalletClient) WithdrawalHistory(params map[string]interface{}) ([]byte,
 }
 
 // MyTrades queries account trade history (USER_DATA)
+// Response can be decoded into []AccountTrade with client.ParseResponse.
 //
 // Weight(IP): 10
 //
@@ -130,6 +133,7 @@ func (w *WalletClient) MyTrades(symbol string, params map[string]interface{}) ([
 }
 
 // UniversalTransferHistory queries user universal transfer history (USER_DATA)
+// Response can be decoded into UniversalTransfers with client.ParseResponse.
 //
 // Weight(IP): 1
 //
@@ -166,6 +170,7 @@ func (w *WalletClient) UniversalTransferHistory(transferType string, params map[
 }
 
 // SubAccountTransferHistory queries sub-account's own transfer history (For Sub-account)
+// Response can be decoded into []SubAccountTransfer with client.ParseResponse.
 //
 // Weight(IP): 1
 //
@@ -193,6 +198,7 @@ func (w *WalletClient) SubAccountTransferHistory(params map[string]interface{})
 }
 
 // AccountSnapshot queries daily account snapshots (USER_DATA)
+// Response can be decoded into AccountSnapshots with client.ParseResponse.
 //
 // Weight(IP): 2400
 //
@@ -205,7 +211,7 @@ func (w *WalletClient) SubAccountTransferHistory(params map[string]interface{})
 // The snapshot is taken daily and shows balances at the end of each day.
 //
 // Parameters:
-//   - type: Account type (required). Values: "SPOT", "MARGIN", "FUTURES"
+//   - type: Account type (required). Values: SnapshotSpot, SnapshotMargin, SnapshotFutures
 //
 // Optional parameters:
 //   - startTime: Start time in milliseconds
@@ -224,6 +230,7 @@ func (w *WalletClient) AccountSnapshot(accountType string, params map[string]int
 }
 
 // MasterSubAccountTransferHistory queries sub-account transfer history (For Master Account)
+// Response can be decoded into SubAccountUniversalTransfers with client.ParseResponse.
 //
 // Weight(IP): 1
 //
@@ -0,0 +1,407 @@
+package spot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Wallet types a withdrawal is paid from
+const (
+	WalletTypeSpot    = 0
+	WalletTypeFunding = 1
+)
+
+// CoinInfo is a coin returned by WalletClient.AllCoins with its deposit and withdrawal networks
+type CoinInfo struct {
+	Coin              string        `json:"coin"`
+	Name              string        `json:"name"`
+	DepositAllEnable  bool          `json:"depositAllEnable"`
+	WithdrawAllEnable bool          `json:"withdrawAllEnable"`
+	Free              string        `json:"free"`
+	Locked            string        `json:"locked"`
+	Freeze            string        `json:"freeze"`
+	Withdrawing       string        `json:"withdrawing"`
+	Ipoing            string        `json:"ipoing"`
+	Ipoable           string        `json:"ipoable"`
+	Storage           string        `json:"storage"`
+	IsLegalMoney      bool          `json:"isLegalMoney"`
+	Trading           bool          `json:"trading"`
+	NetworkList       []CoinNetwork `json:"networkList"`
+}
+
+// CoinNetwork holds the deposit and withdrawal rules of a coin on one network
+type CoinNetwork struct {
+	Network                 string `json:"network"`
+	Coin                    string `json:"coin"`
+	Name                    string `json:"name"`
+	IsDefault               bool   `json:"isDefault"`
+	DepositEnable           bool   `json:"depositEnable"`
+	WithdrawEnable          bool   `json:"withdrawEnable"`
+	DepositDesc             string `json:"depositDesc"`
+	WithdrawDesc            string `json:"withdrawDesc"`
+	SpecialTips             string `json:"specialTips"`
+	AddressRegex            string `json:"addressRegex"`
+	MemoRegex               string `json:"memoRegex"`
+	MinConfirm              int    `json:"minConfirm"`
+	UnLockConfirm           int    `json:"unLockConfirm"`
+	WithdrawFee             string `json:"withdrawFee"`
+	WithdrawMin             string `json:"withdrawMin"`
+	WithdrawMax             string `json:"withdrawMax"`
+	WithdrawIntegerMultiple string `json:"withdrawIntegerMultiple"`
+	DepositDust             string `json:"depositDust"`
+	SameAddress             bool   `json:"sameAddress"` // the address is shared and a memo is required
+	EstimatedArrivalTime    int64  `json:"estimatedArrivalTime"`
+	Busy                    bool   `json:"busy"`
+	ContractAddress         string `json:"contractAddress"`
+	ContractAddressURL      string `json:"contractAddressUrl"`
+}
+
+// WithdrawResult is the response of WalletClient.Withdraw
+type WithdrawResult struct {
+	ID string `json:"id"`
+}
+
+// Network returns the named network of the coin, or its default network when
+// name is empty. It returns nil if the coin has no such network.
+func (c *CoinInfo) Network(name string) *CoinNetwork {
+	for i := range c.NetworkList {
+		network := &c.NetworkList[i]
+		if (name == "" && network.IsDefault) || (name != "" && strings.EqualFold(network.Network, name)) {
+			return network
+		}
+	}
+	return nil
+}
+
+// WithdrawRequest describes a withdrawal
+//
+// Network may be left empty to use the coin's default network. AddressTag is
+// the memo or tag required by networks with shared deposit addresses.
+type WithdrawRequest struct {
+	Coin               string
+	Network            string
+	Address            string
+	AddressTag         string
+	Amount             float64
+	WithdrawOrderID    string
+	TransactionFeeFlag bool // for internal transfers, the recipient pays the fee
+	Name               string
+	WalletType         int
+}
+
+// Validate checks that the required fields are set
+func (r *WithdrawRequest) Validate() error {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"coin":    r.Coin,
+		"address": r.Address,
+	}); err != nil {
+		return err
+	}
+	if r.Amount <= 0 {
+		return fmt.Errorf("withdrawal amount must be positive")
+	}
+	if r.WalletType != WalletTypeSpot && r.WalletType != WalletTypeFunding {
+		return fmt.Errorf("invalid wallet type %d", r.WalletType)
+	}
+	return nil
+}
+
+// Params converts the request into request parameters, merged over params
+func (r *WithdrawRequest) Params(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["coin"] = r.Coin
+	params["address"] = r.Address
+	params["amount"] = r.Amount
+
+	if r.Network != "" {
+		params["network"] = r.Network
+	}
+	if r.AddressTag != "" {
+		params["addressTag"] = r.AddressTag
+	}
+	if r.WithdrawOrderID != "" {
+		params["withdrawOrderId"] = r.WithdrawOrderID
+	}
+	if r.TransactionFeeFlag {
+		params["transactionFeeFlag"] = "true"
+	}
+	if r.Name != "" {
+		params["name"] = r.Name
+	}
+	if r.WalletType != WalletTypeSpot {
+		params["walletType"] = r.WalletType
+	}
+	return params
+}
+
+// ValidateWithdraw checks a withdrawal against the network's rules: withdrawals
+// enabled, address and memo format, amount bounds, fee and integer multiple
+func (n *CoinNetwork) ValidateWithdraw(req WithdrawRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if !strings.EqualFold(req.Coin, n.Coin) {
+		return fmt.Errorf("withdrawal coin %s does not match rules for %s", req.Coin, n.Coin)
+	}
+	if req.Network != "" && !strings.EqualFold(req.Network, n.Network) {
+		return fmt.Errorf("withdrawal network %s does not match rules for %s", req.Network, n.Network)
+	}
+	if !n.WithdrawEnable {
+		return fmt.Errorf("withdrawals of %s on %s are disabled", n.Coin, n.Network)
+	}
+
+	matched, err := matchesRule(n.AddressRegex, req.Address)
+	if err != nil {
+		return fmt.Errorf("failed to check %s address: %w", n.Network, err)
+	}
+	if !matched {
+		return fmt.Errorf("address %s is not a valid %s address", req.Address, n.Network)
+	}
+	if n.SameAddress && req.AddressTag == "" {
+		return fmt.Errorf("withdrawals of %s on %s require an address tag", n.Coin, n.Network)
+	}
+	if req.AddressTag != "" {
+		matched, err := matchesRule(n.MemoRegex, req.AddressTag)
+		if err != nil {
+			return fmt.Errorf("failed to check %s memo: %w", n.Network, err)
+		}
+		if !matched {
+			return fmt.Errorf("address tag %s is not a valid %s memo", req.AddressTag, n.Network)
+		}
+	}
+
+	if minAmount := parseDecimal(n.WithdrawMin); minAmount > 0 && req.Amount < minAmount {
+		return fmt.Errorf("withdrawal amount %s is below minimum %s", formatDecimal(req.Amount), n.WithdrawMin)
+	}
+	if maxAmount := parseDecimal(n.WithdrawMax); maxAmount > 0 && req.Amount > maxAmount {
+		return fmt.Errorf("withdrawal amount %s is above maximum %s", formatDecimal(req.Amount), n.WithdrawMax)
+	}
+	if fee := parseDecimal(n.WithdrawFee); !req.TransactionFeeFlag && req.Amount <= fee {
+		return fmt.Errorf("withdrawal amount %s does not cover fee %s", formatDecimal(req.Amount), n.WithdrawFee)
+	}
+	if multiple := parseDecimal(n.WithdrawIntegerMultiple); multiple > 0 && !isMultiple(req.Amount, "", n.WithdrawIntegerMultiple) {
+		return fmt.Errorf("withdrawal amount %s is not a multiple of %s", formatDecimal(req.Amount), n.WithdrawIntegerMultiple)
+	}
+	return nil
+}
+
+// matchesRule reports whether value matches a network's address or memo regex
+// Some rules use syntax Go's regexp does not support, such as lookaheads;
+// those are an error, as value cannot be checked against them.
+func matchesRule(pattern, value string) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("unsupported rule %q: %w", pattern, err)
+	}
+	return re.MatchString(value), nil
+}
+
+// AllCoins queries deposit and withdrawal information of all coins (USER_DATA)
+// Response can be decoded into []CoinInfo with client.ParseResponse.
+//
+// Weight(IP): 10
+//
+// GET /sapi/v1/capital/config/getall
+//
+// https://developers.binance.com/docs/wallet/capital
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) AllCoins(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return w.SignRequest("GET", "/sapi/v1/capital/config/getall", params)
+}
+
+// Withdraw submits a withdrawal (USER_DATA)
+// Response can be decoded into WithdrawResult with client.ParseResponse.
+//
+// Only the required fields are checked. Use a Withdrawer to also check the
+// network rules and restrict withdrawals to an address book.
+//
+// Weight(UID): 900
+//
+// POST /sapi/v1/capital/withdraw/apply
+//
+// https://developers.binance.com/docs/wallet/capital/withdraw
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (w *WalletClient) Withdraw(req WithdrawRequest, params map[string]interface{}) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return w.SignRequest("POST", "/sapi/v1/capital/withdraw/apply", req.Params(params))
+}
+
+// AddressBookEntry is an allowlisted withdrawal destination
+type AddressBookEntry struct {
+	Label      string `json:"label,omitempty"`
+	Coin       string `json:"coin"`
+	Network    string `json:"network"`
+	Address    string `json:"address"`
+	AddressTag string `json:"addressTag,omitempty"`
+}
+
+// key identifies an entry; coin and network are case-insensitive, the address and tag are not
+func (e AddressBookEntry) key() string {
+	return strings.ToUpper(e.Coin) + "|" + strings.ToUpper(e.Network) + "|" + e.Address + "|" + e.AddressTag
+}
+
+// AddressBook is a local allowlist of withdrawal destinations
+type AddressBook struct {
+	mu      sync.RWMutex
+	entries map[string]AddressBookEntry
+}
+
+// NewAddressBook creates an address book holding entries
+func NewAddressBook(entries ...AddressBookEntry) (*AddressBook, error) {
+	book := &AddressBook{entries: make(map[string]AddressBookEntry)}
+	for _, entry := range entries {
+		if err := book.Add(entry); err != nil {
+			return nil, err
+		}
+	}
+	return book, nil
+}
+
+// LoadAddressBook reads an address book saved as a JSON array of entries
+func LoadAddressBook(path string) (*AddressBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+	var entries []AddressBookEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse address book: %w", err)
+	}
+	return NewAddressBook(entries...)
+}
+
+// Save writes the address book to path as a JSON array of entries
+func (b *AddressBook) Save(path string) error {
+	data, err := json.MarshalIndent(b.Entries(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode address book: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write address book: %w", err)
+	}
+	return nil
+}
+
+// Add allowlists a destination; coin, network and address are required
+func (b *AddressBook) Add(entry AddressBookEntry) error {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"coin":    entry.Coin,
+		"network": entry.Network,
+		"address": entry.Address,
+	}); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.entries[entry.key()] = entry
+	b.mu.Unlock()
+	return nil
+}
+
+// Remove removes a destination from the allowlist
+func (b *AddressBook) Remove(entry AddressBookEntry) {
+	b.mu.Lock()
+	delete(b.entries, entry.key())
+	b.mu.Unlock()
+}
+
+// Entries returns the allowlisted destinations ordered by coin, network and address
+func (b *AddressBook) Entries() []AddressBookEntry {
+	b.mu.RLock()
+	entries := make([]AddressBookEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		entries = append(entries, entry)
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key() < entries[j].key()
+	})
+	return entries
+}
+
+// Lookup returns the entry matching the coin, network, address and tag of a withdrawal
+func (b *AddressBook) Lookup(req WithdrawRequest) (AddressBookEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	entry, ok := b.entries[AddressBookEntry{
+		Coin:       req.Coin,
+		Network:    req.Network,
+		Address:    req.Address,
+		AddressTag: req.AddressTag,
+	}.key()]
+	return entry, ok
+}
+
+// Withdrawer submits withdrawals after checking them against the network rules
+// of a NetworkCatalogue and refuses destinations missing from its address book
+type Withdrawer struct {
+	wallet    *WalletClient
+	catalogue *NetworkCatalogue
+	book      *AddressBook
+}
+
+// NewWithdrawer creates a Withdrawer restricted to the destinations of book
+// The catalogue may be shared with other helpers; its ttl bounds how stale the
+// network rules a withdrawal is checked against can be.
+func NewWithdrawer(wallet *WalletClient, catalogue *NetworkCatalogue, book *AddressBook) *Withdrawer {
+	return &Withdrawer{
+		wallet:    wallet,
+		catalogue: catalogue,
+		book:      book,
+	}
+}
+
+// Check validates a withdrawal without submitting it and returns the network
+// it would be sent on, resolving an empty network to the coin's default
+func (w *Withdrawer) Check(req WithdrawRequest) (CoinNetwork, error) {
+	if err := req.Validate(); err != nil {
+		return CoinNetwork{}, err
+	}
+
+	network, err := w.catalogue.Network(req.Coin, req.Network)
+	if err != nil {
+		return CoinNetwork{}, err
+	}
+	if err := network.ValidateWithdraw(req); err != nil {
+		return CoinNetwork{}, err
+	}
+
+	req.Network = network.Network
+	if _, ok := w.book.Lookup(req); !ok {
+		return CoinNetwork{}, fmt.Errorf("address %s on %s %s is not in the address book", req.Address, req.Coin, req.Network)
+	}
+	return network, nil
+}
+
+// Withdraw checks a withdrawal and submits it on the resolved network
+// Response can be decoded into WithdrawResult with client.ParseResponse.
+func (w *Withdrawer) Withdraw(req WithdrawRequest, params map[string]interface{}) ([]byte, error) {
+	network, err := w.Check(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Network = network.Network
+	return w.wallet.Withdraw(req, params)
+}
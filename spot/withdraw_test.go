@@ -0,0 +1,223 @@
+package spot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+const testCoinConfig = `[{
+  "coin": "XRP",
+  "name": "Ripple",
+  "depositAllEnable": true,
+  "withdrawAllEnable": true,
+  "free": "500",
+  "networkList": [{
+    "network": "XRP",
+    "coin": "XRP",
+    "isDefault": true,
+    "depositEnable": true,
+    "withdrawEnable": true,
+    "addressRegex": "^r[1-9A-HJ-NP-Za-km-z]{25,34}$",
+    "memoRegex": "^[1-9][0-9]{0,9}$|^$",
+    "minConfirm": 1,
+    "withdrawFee": "0.25",
+    "withdrawMin": "2",
+    "withdrawMax": "9999999",
+    "withdrawIntegerMultiple": "0.000001",
+    "sameAddress": true
+  }]
+}, {
+  "coin": "USDT",
+  "name": "TetherUS",
+  "networkList": [{
+    "network": "ETH",
+    "coin": "USDT",
+    "isDefault": true,
+    "withdrawEnable": true,
+    "addressRegex": "^(0x)[0-9A-Fa-f]{40}$",
+    "withdrawFee": "4",
+    "withdrawMin": "10",
+    "withdrawMax": "10000000",
+    "withdrawIntegerMultiple": "0.000001"
+  }, {
+    "network": "TRX",
+    "coin": "USDT",
+    "withdrawEnable": false,
+    "addressRegex": "^T[1-9A-HJ-NP-Za-km-z]{33}$",
+    "memoRegex": "^((?!0)[0-9]{1,10})$|^$",
+    "withdrawFee": "1",
+    "withdrawMin": "10"
+  }]
+}]`
+
+const testETHAddress = "0x1111111111111111111111111111111111111111"
+
+func testCoins(t *testing.T) []CoinInfo {
+	var coins []CoinInfo
+	if err := json.Unmarshal([]byte(testCoinConfig), &coins); err != nil {
+		t.Fatalf("Failed to parse coin config: %v", err)
+	}
+	return coins
+}
+
+func TestCoinNetworkValidateWithdraw(t *testing.T) {
+	coins := testCoins(t)
+	xrp, usdt := coins[0].Network(""), coins[1].Network("ETH")
+	trx := coins[1].Network("trx")
+	if xrp == nil || usdt == nil || trx == nil {
+		t.Fatal("Expected XRP default, USDT ETH and USDT TRX networks")
+	}
+	btc := &CoinNetwork{Coin: "BTC", Network: "BTC", WithdrawEnable: true, WithdrawIntegerMultiple: "0.00000001"}
+
+	tests := []struct {
+		name    string
+		network *CoinNetwork
+		req     WithdrawRequest
+		wantErr bool
+	}{
+		{"valid", usdt, WithdrawRequest{Coin: "USDT", Address: testETHAddress, Amount: 50}, false},
+		{"valid with memo", xrp, WithdrawRequest{Coin: "XRP", Address: "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", AddressTag: "104816", Amount: 20}, false},
+		{"missing memo", xrp, WithdrawRequest{Coin: "XRP", Address: "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", Amount: 20}, true},
+		{"invalid memo", xrp, WithdrawRequest{Coin: "XRP", Address: "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", AddressTag: "abc", Amount: 20}, true},
+		{"invalid address", usdt, WithdrawRequest{Coin: "USDT", Address: "0x1234", Amount: 50}, true},
+		{"below minimum", usdt, WithdrawRequest{Coin: "USDT", Address: testETHAddress, Amount: 5}, true},
+		{"above maximum", usdt, WithdrawRequest{Coin: "USDT", Address: testETHAddress, Amount: 20000000}, true},
+		{"not a multiple", usdt, WithdrawRequest{Coin: "USDT", Address: testETHAddress, Amount: 50.0000001}, true},
+		{"satoshi multiple 0.91383027", btc, WithdrawRequest{Coin: "BTC", Address: "bc1qexample", Amount: 0.91383027}, false},
+		{"satoshi multiple 0.92031077", btc, WithdrawRequest{Coin: "BTC", Address: "bc1qexample", Amount: 0.92031077}, false},
+		{"satoshi multiple 0.92129780", btc, WithdrawRequest{Coin: "BTC", Address: "bc1qexample", Amount: 0.92129780}, false},
+		{"finer than a satoshi", btc, WithdrawRequest{Coin: "BTC", Address: "bc1qexample", Amount: 0.919999995}, true},
+		{"wrong coin", usdt, WithdrawRequest{Coin: "BTC", Address: testETHAddress, Amount: 50}, true},
+		{"disabled network", trx, WithdrawRequest{Coin: "USDT", Network: "TRX", Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", Amount: 50}, true},
+		{"unsupported memo regex is rejected", &CoinNetwork{Coin: "USDT", Network: "TRX", WithdrawEnable: true, MemoRegex: trx.MemoRegex}, WithdrawRequest{Coin: "USDT", Address: "TJYeasTPa6gpEEfYcBMWJyWkbYmRsqfcW1", AddressTag: "1", Amount: 50}, true},
+		{"missing address", usdt, WithdrawRequest{Coin: "USDT", Amount: 50}, true},
+		{"zero amount", usdt, WithdrawRequest{Coin: "USDT", Address: testETHAddress}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.network.ValidateWithdraw(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithdraw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithdrawRequestParams(t *testing.T) {
+	req := WithdrawRequest{
+		Coin:            "XRP",
+		Network:         "XRP",
+		Address:         "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh",
+		AddressTag:      "104816",
+		Amount:          20.5,
+		WithdrawOrderID: "payout-1",
+		WalletType:      WalletTypeFunding,
+	}
+	params := req.Params(map[string]interface{}{"recvWindow": 5000})
+
+	expected := map[string]interface{}{
+		"coin":            "XRP",
+		"network":         "XRP",
+		"address":         "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh",
+		"addressTag":      "104816",
+		"amount":          20.5,
+		"withdrawOrderId": "payout-1",
+		"walletType":      WalletTypeFunding,
+		"recvWindow":      5000,
+	}
+	if len(params) != len(expected) {
+		t.Errorf("Expected %d params, got %v", len(expected), params)
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, params[key])
+		}
+	}
+}
+
+func TestAddressBookSaveLoad(t *testing.T) {
+	book, err := NewAddressBook(
+		AddressBookEntry{Label: "cold wallet", Coin: "USDT", Network: "ETH", Address: testETHAddress},
+		AddressBookEntry{Label: "exchange", Coin: "XRP", Network: "XRP", Address: "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", AddressTag: "104816"},
+	)
+	if err != nil {
+		t.Fatalf("NewAddressBook() error = %v", err)
+	}
+	if err := book.Add(AddressBookEntry{Coin: "USDT", Address: testETHAddress}); err == nil {
+		t.Error("Expected error for entry without network, got nil")
+	}
+
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	if err := book.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadAddressBook(path)
+	if err != nil {
+		t.Fatalf("LoadAddressBook() error = %v", err)
+	}
+
+	if entry, ok := loaded.Lookup(WithdrawRequest{Coin: "usdt", Network: "eth", Address: testETHAddress}); !ok || entry.Label != "cold wallet" {
+		t.Errorf("Expected cold wallet entry, got %+v, %v", entry, ok)
+	}
+	if _, ok := loaded.Lookup(WithdrawRequest{Coin: "XRP", Network: "XRP", Address: "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", AddressTag: "1"}); ok {
+		t.Error("Expected a different address tag not to match")
+	}
+
+	loaded.Remove(AddressBookEntry{Coin: "USDT", Network: "ETH", Address: testETHAddress})
+	if entries := loaded.Entries(); len(entries) != 1 || entries[0].Coin != "XRP" {
+		t.Errorf("Expected only the XRP entry after Remove, got %+v", entries)
+	}
+}
+
+func TestWithdrawer(t *testing.T) {
+	var applied []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sapi/v1/capital/config/getall":
+			w.Write([]byte(testCoinConfig))
+		case "/sapi/v1/capital/withdraw/apply":
+			query := r.URL.Query()
+			applied = append(applied, r.Method+" "+query.Get("coin")+" "+query.Get("network")+" "+query.Get("address")+" "+query.Get("amount"))
+			w.Write([]byte(`{"id":"7213fea8e94b4a5593d507237e5a555b"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	book, _ := NewAddressBook(AddressBookEntry{Coin: "USDT", Network: "ETH", Address: testETHAddress})
+	withdrawer := NewWithdrawer(wallet, NewNetworkCatalogue(wallet, time.Hour), book)
+
+	response, err := withdrawer.Withdraw(WithdrawRequest{Coin: "USDT", Address: testETHAddress, Amount: 25}, nil)
+	if err != nil {
+		t.Fatalf("Withdraw() error = %v", err)
+	}
+	var result WithdrawResult
+	if err := client.ParseResponse(response, &result); err != nil || result.ID == "" {
+		t.Errorf("Unexpected result %+v, error %v", result, err)
+	}
+
+	unknown := "0x2222222222222222222222222222222222222222"
+	if _, err := withdrawer.Withdraw(WithdrawRequest{Coin: "USDT", Address: unknown, Amount: 25}, nil); err == nil {
+		t.Error("Expected error for address missing from the address book, got nil")
+	}
+	if _, err := withdrawer.Withdraw(WithdrawRequest{Coin: "USDT", Address: testETHAddress, Amount: 5}, nil); err == nil {
+		t.Error("Expected error for amount below minimum, got nil")
+	}
+	if _, err := withdrawer.Check(WithdrawRequest{Coin: "DOGE", Address: testETHAddress, Amount: 25}); err == nil {
+		t.Error("Expected error for unknown coin, got nil")
+	}
+
+	if len(applied) != 1 || applied[0] != "POST USDT ETH "+testETHAddress+" 25" {
+		t.Errorf("Expected a single withdrawal on the default network, got %v", applied)
+	}
+}
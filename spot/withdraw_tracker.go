@@ -0,0 +1,431 @@
+package spot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+const (
+	defaultWithdrawPollInterval = 30 * time.Second
+	// maxWithdrawIDList is the most IDs WithdrawalHistory accepts in idList
+	maxWithdrawIDList = 45
+)
+
+// WithdrawStatus is the status code of a withdrawal in WithdrawalHistory
+type WithdrawStatus int
+
+const (
+	// WithdrawStatusUnknown is a tracked withdrawal not yet seen in the history
+	WithdrawStatusUnknown          WithdrawStatus = -1
+	WithdrawStatusEmailSent        WithdrawStatus = 0
+	WithdrawStatusCancelled        WithdrawStatus = 1
+	WithdrawStatusAwaitingApproval WithdrawStatus = 2
+	WithdrawStatusRejected         WithdrawStatus = 3
+	WithdrawStatusProcessing       WithdrawStatus = 4
+	WithdrawStatusFailure          WithdrawStatus = 5
+	WithdrawStatusCompleted        WithdrawStatus = 6
+)
+
+// String returns the status name shown in the Binance documentation
+func (s WithdrawStatus) String() string {
+	switch s {
+	case WithdrawStatusUnknown:
+		return "Unknown"
+	case WithdrawStatusEmailSent:
+		return "Email Sent"
+	case WithdrawStatusCancelled:
+		return "Cancelled"
+	case WithdrawStatusAwaitingApproval:
+		return "Awaiting Approval"
+	case WithdrawStatusRejected:
+		return "Rejected"
+	case WithdrawStatusProcessing:
+		return "Processing"
+	case WithdrawStatusFailure:
+		return "Failure"
+	case WithdrawStatusCompleted:
+		return "Completed"
+	}
+	return fmt.Sprintf("WithdrawStatus(%d)", int(s))
+}
+
+// Final reports whether the withdrawal can no longer change status
+func (s WithdrawStatus) Final() bool {
+	switch s {
+	case WithdrawStatusCancelled, WithdrawStatusRejected, WithdrawStatusFailure, WithdrawStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// Withdrawal is a withdrawal record returned by WalletClient.WithdrawalHistory
+type Withdrawal struct {
+	ID              string         `json:"id"`
+	Amount          string         `json:"amount"`
+	TransactionFee  string         `json:"transactionFee"`
+	Coin            string         `json:"coin"`
+	Status          WithdrawStatus `json:"status"`
+	Address         string         `json:"address"`
+	AddressTag      string         `json:"addressTag"`
+	TxID            string         `json:"txId"`
+	ApplyTime       string         `json:"applyTime"`
+	Network         string         `json:"network"`
+	TransferType    int            `json:"transferType"` // 1 for internal transfers, 0 for external
+	WithdrawOrderID string         `json:"withdrawOrderId"`
+	Info            string         `json:"info"`
+	ConfirmNo       int            `json:"confirmNo"`
+	WalletType      int            `json:"walletType"`
+	TxKey           string         `json:"txKey"`
+	CompleteTime    string         `json:"completeTime"`
+}
+
+// WithdrawalRef identifies a withdrawal by the ID returned by Withdraw or by
+// the withdrawOrderId it was submitted with
+type WithdrawalRef struct {
+	ID              string `json:"id,omitempty"`
+	WithdrawOrderID string `json:"withdrawOrderId,omitempty"`
+}
+
+// String returns the ID, or the withdrawOrderId when no ID is set
+func (r WithdrawalRef) String() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return r.WithdrawOrderID
+}
+
+// key identifies the ref in the tracker; IDs and withdrawOrderIds live in separate namespaces
+func (r WithdrawalRef) key() string {
+	if r.ID != "" {
+		return "id:" + r.ID
+	}
+	return "order:" + r.WithdrawOrderID
+}
+
+// matches reports whether a history record is the referenced withdrawal
+func (r WithdrawalRef) matches(w Withdrawal) bool {
+	if r.ID != "" {
+		return w.ID == r.ID
+	}
+	return w.WithdrawOrderID == r.WithdrawOrderID
+}
+
+// WithdrawalChange is a status change of a tracked withdrawal
+type WithdrawalChange struct {
+	Ref        WithdrawalRef
+	Withdrawal Withdrawal
+	Previous   WithdrawStatus
+}
+
+// trackedWithdrawal is the persisted state of an in-flight withdrawal
+type trackedWithdrawal struct {
+	Ref       WithdrawalRef  `json:"ref"`
+	Status    WithdrawStatus `json:"status"`
+	TrackedAt int64          `json:"trackedAt"`
+
+	last Withdrawal
+	done chan struct{}
+}
+
+// WithdrawalTracker follows withdrawals through WithdrawalHistory until they
+// reach a final status: Completed, Failure, Rejected or Cancelled
+//
+// Each poll queries tracked IDs in batches through idList and each tracked
+// withdrawOrderId separately. In-flight withdrawals are saved to the state
+// file after every change, so a new tracker created with the same path
+// resumes following them.
+type WithdrawalTracker struct {
+	// PollInterval is the wait between polls of WithdrawalHistory. Default 30s.
+	PollInterval time.Duration
+	// OnChange receives every status change, including the first time a
+	// withdrawal appears in the history. Called from the polling goroutine. Optional.
+	OnChange func(change WithdrawalChange)
+	// OnError receives poll and persistence errors. Optional.
+	OnError func(err error)
+
+	wallet    *WalletClient
+	statePath string
+
+	mu       sync.Mutex
+	tracked  map[string]*trackedWithdrawal
+	finished map[string]Withdrawal
+
+	started bool
+	closed  chan struct{}
+	done    chan struct{}
+}
+
+// NewWithdrawalTracker creates a tracker polling wallet and loads the
+// in-flight withdrawals saved at statePath. An empty statePath disables persistence.
+func NewWithdrawalTracker(wallet *WalletClient, statePath string) (*WithdrawalTracker, error) {
+	t := &WithdrawalTracker{
+		PollInterval: defaultWithdrawPollInterval,
+		wallet:       wallet,
+		statePath:    statePath,
+		tracked:      make(map[string]*trackedWithdrawal),
+		finished:     make(map[string]Withdrawal),
+		closed:       make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	if statePath == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read withdrawal tracker state: %w", err)
+	}
+	var saved []*trackedWithdrawal
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse withdrawal tracker state: %w", err)
+	}
+	for _, w := range saved {
+		w.done = make(chan struct{})
+		t.tracked[w.Ref.key()] = w
+	}
+	return t, nil
+}
+
+// Track starts following a withdrawal
+func (t *WithdrawalTracker) Track(ref WithdrawalRef) error {
+	if ref.ID == "" && ref.WithdrawOrderID == "" {
+		return fmt.Errorf("either id or withdrawOrderId is required")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.tracked[ref.key()]; ok {
+		return nil
+	}
+	delete(t.finished, ref.key())
+	t.tracked[ref.key()] = &trackedWithdrawal{
+		Ref:       ref,
+		Status:    WithdrawStatusUnknown,
+		TrackedAt: time.Now().UnixMilli(),
+		done:      make(chan struct{}),
+	}
+	return t.saveLocked()
+}
+
+// Pending returns the refs of withdrawals that have not reached a final status
+func (t *WithdrawalTracker) Pending() []WithdrawalRef {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	refs := make([]WithdrawalRef, 0, len(t.tracked))
+	for _, w := range t.tracked {
+		refs = append(refs, w.Ref)
+	}
+	return refs
+}
+
+// Start polls WithdrawalHistory every PollInterval until Close
+func (t *WithdrawalTracker) Start() {
+	t.mu.Lock()
+	if t.started {
+		t.mu.Unlock()
+		return
+	}
+	t.started = true
+	t.mu.Unlock()
+
+	go t.run()
+}
+
+// Close stops polling; in-flight withdrawals remain in the state file
+func (t *WithdrawalTracker) Close() {
+	select {
+	case <-t.closed:
+		return
+	default:
+	}
+	close(t.closed)
+
+	t.mu.Lock()
+	started := t.started
+	t.mu.Unlock()
+	if started {
+		<-t.done
+	}
+}
+
+// Wait blocks until a tracked withdrawal reaches a final status or timeout
+// elapses, returning the last known record. Polling must be running, either
+// through Start or by calling Poll from another goroutine.
+func (t *WithdrawalTracker) Wait(ref WithdrawalRef, timeout time.Duration) (Withdrawal, error) {
+	t.mu.Lock()
+	if w, ok := t.finished[ref.key()]; ok {
+		t.mu.Unlock()
+		return w, nil
+	}
+	tracked, ok := t.tracked[ref.key()]
+	t.mu.Unlock()
+	if !ok {
+		return Withdrawal{}, fmt.Errorf("withdrawal %s is not tracked", ref)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-tracked.done:
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.finished[ref.key()], nil
+	case <-timer.C:
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return tracked.last, fmt.Errorf("timed out waiting for withdrawal %s (status %s)", ref, tracked.Status)
+	}
+}
+
+// Poll queries WithdrawalHistory once for every tracked withdrawal and
+// reports status changes
+func (t *WithdrawalTracker) Poll() error {
+	t.mu.Lock()
+	var ids, orderIDs []string
+	for _, w := range t.tracked {
+		if w.Ref.ID != "" {
+			ids = append(ids, w.Ref.ID)
+		} else {
+			orderIDs = append(orderIDs, w.Ref.WithdrawOrderID)
+		}
+	}
+	t.mu.Unlock()
+
+	var records []Withdrawal
+	for start := 0; start < len(ids); start += maxWithdrawIDList {
+		end := min(start+maxWithdrawIDList, len(ids))
+		batch, err := t.history(map[string]interface{}{"idList": strings.Join(ids[start:end], ",")})
+		if err != nil {
+			return err
+		}
+		records = append(records, batch...)
+	}
+	for _, orderID := range orderIDs {
+		batch, err := t.history(map[string]interface{}{"withdrawOrderId": orderID})
+		if err != nil {
+			return err
+		}
+		records = append(records, batch...)
+	}
+
+	changes := t.apply(records)
+	for _, change := range changes {
+		if t.OnChange != nil {
+			t.OnChange(change)
+		}
+	}
+	return nil
+}
+
+// apply records status changes, retires final withdrawals and persists the result
+func (t *WithdrawalTracker) apply(records []Withdrawal) []WithdrawalChange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var changes []WithdrawalChange
+	for key, w := range t.tracked {
+		for _, record := range records {
+			if !w.Ref.matches(record) {
+				continue
+			}
+			w.last = record
+			if record.Status != w.Status {
+				changes = append(changes, WithdrawalChange{Ref: w.Ref, Withdrawal: record, Previous: w.Status})
+				w.Status = record.Status
+			}
+			if record.Status.Final() {
+				t.finished[key] = record
+				delete(t.tracked, key)
+				close(w.done)
+			}
+			break
+		}
+	}
+
+	if len(changes) > 0 {
+		if err := t.saveLocked(); err != nil {
+			t.reportError(err)
+		}
+	}
+	return changes
+}
+
+// history fetches and decodes withdrawal records matching params
+func (t *WithdrawalTracker) history(params map[string]interface{}) ([]Withdrawal, error) {
+	response, err := t.wallet.WithdrawalHistory(params)
+	if err != nil {
+		return nil, err
+	}
+	var records []Withdrawal
+	if err := client.ParseResponse(response, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse withdrawal history: %w", err)
+	}
+	return records, nil
+}
+
+func (t *WithdrawalTracker) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.PollInterval)
+	defer ticker.Stop()
+	for {
+		t.reportError(t.Poll())
+		select {
+		case <-ticker.C:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// saveLocked writes the in-flight withdrawals to the state file; callers hold mu
+func (t *WithdrawalTracker) saveLocked() error {
+	if t.statePath == "" {
+		return nil
+	}
+
+	saved := make([]*trackedWithdrawal, 0, len(t.tracked))
+	for _, w := range t.tracked {
+		saved = append(saved, w)
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode withdrawal tracker state: %w", err)
+	}
+
+	// Write to a temporary file first so a crash cannot leave a truncated state
+	tmp, err := os.CreateTemp(filepath.Dir(t.statePath), filepath.Base(t.statePath)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write withdrawal tracker state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write withdrawal tracker state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write withdrawal tracker state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), t.statePath); err != nil {
+		return fmt.Errorf("failed to write withdrawal tracker state: %w", err)
+	}
+	return nil
+}
+
+func (t *WithdrawalTracker) reportError(err error) {
+	if err != nil && t.OnError != nil {
+		t.OnError(err)
+	}
+}
@@ -0,0 +1,172 @@
+package spot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithdrawStatus(t *testing.T) {
+	tests := []struct {
+		status WithdrawStatus
+		name   string
+		final  bool
+	}{
+		{WithdrawStatusEmailSent, "Email Sent", false},
+		{WithdrawStatusAwaitingApproval, "Awaiting Approval", false},
+		{WithdrawStatusProcessing, "Processing", false},
+		{WithdrawStatusCompleted, "Completed", true},
+		{WithdrawStatusFailure, "Failure", true},
+		{WithdrawStatusRejected, "Rejected", true},
+		{WithdrawStatusCancelled, "Cancelled", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.status.String() != tt.name || tt.status.Final() != tt.final {
+				t.Errorf("Expected %s (final %v), got %s (final %v)", tt.name, tt.final, tt.status, tt.status.Final())
+			}
+		})
+	}
+}
+
+// withdrawalHistoryServer serves WithdrawalHistory from statuses, keyed by ID
+// and withdrawOrderId, and records the queries it receives
+type withdrawalHistoryServer struct {
+	mu       sync.Mutex
+	statuses map[string]WithdrawStatus
+	queries  []string
+}
+
+func (s *withdrawalHistoryServer) set(id string, status WithdrawStatus) {
+	s.mu.Lock()
+	s.statuses[id] = status
+	s.mu.Unlock()
+}
+
+func (s *withdrawalHistoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/sapi/v1/capital/withdraw/history" {
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	s.queries = append(s.queries, "idList="+query.Get("idList")+" withdrawOrderId="+query.Get("withdrawOrderId"))
+	records := "["
+	for key, status := range s.statuses {
+		if records != "[" {
+			records += ","
+		}
+		records += fmt.Sprintf(`{"id":"%s","withdrawOrderId":"%s","coin":"USDT","amount":"25","status":%d}`, key, key, status)
+	}
+	w.Write([]byte(records + "]"))
+}
+
+func TestWithdrawalTracker(t *testing.T) {
+	history := &withdrawalHistoryServer{statuses: map[string]WithdrawStatus{"w1": WithdrawStatusEmailSent}}
+	server := httptest.NewServer(history)
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	statePath := filepath.Join(t.TempDir(), "withdrawals.json")
+
+	tracker, err := NewWithdrawalTracker(wallet, statePath)
+	if err != nil {
+		t.Fatalf("NewWithdrawalTracker() error = %v", err)
+	}
+	var changes []string
+	tracker.OnChange = func(change WithdrawalChange) {
+		changes = append(changes, change.Ref.String()+": "+change.Previous.String()+" -> "+change.Withdrawal.Status.String())
+	}
+
+	if err := tracker.Track(WithdrawalRef{}); err == nil {
+		t.Error("Expected error for empty ref, got nil")
+	}
+	if err := tracker.Track(WithdrawalRef{ID: "w1"}); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+	if err := tracker.Track(WithdrawalRef{WithdrawOrderID: "payout-1"}); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	if err := tracker.Poll(); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	history.set("w1", WithdrawStatusProcessing)
+	history.set("payout-1", WithdrawStatusProcessing)
+	if err := tracker.Poll(); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	// A new tracker resumes from the state file
+	resumed, err := NewWithdrawalTracker(wallet, statePath)
+	if err != nil {
+		t.Fatalf("NewWithdrawalTracker() error = %v", err)
+	}
+	if pending := resumed.Pending(); len(pending) != 2 {
+		t.Fatalf("Expected 2 resumed withdrawals, got %+v", pending)
+	}
+
+	history.set("w1", WithdrawStatusCompleted)
+	if err := tracker.Poll(); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	completed, err := tracker.Wait(WithdrawalRef{ID: "w1"}, time.Second)
+	if err != nil || completed.Status != WithdrawStatusCompleted {
+		t.Errorf("Expected completed withdrawal, got %+v, %v", completed, err)
+	}
+	if _, err := tracker.Wait(WithdrawalRef{WithdrawOrderID: "payout-1"}, 10*time.Millisecond); err == nil {
+		t.Error("Expected timeout waiting for a processing withdrawal, got nil")
+	}
+
+	expected := []string{
+		"w1: Unknown -> Email Sent",
+		"w1: Email Sent -> Processing",
+		"payout-1: Unknown -> Processing",
+		"w1: Processing -> Completed",
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected changes %v, got %v", expected, changes)
+	}
+	for _, change := range expected {
+		found := false
+		for _, got := range changes {
+			found = found || got == change
+		}
+		if !found {
+			t.Errorf("Expected change %q in %v", change, changes)
+		}
+	}
+
+	resumed, _ = NewWithdrawalTracker(wallet, statePath)
+	if pending := resumed.Pending(); len(pending) != 1 || pending[0].WithdrawOrderID != "payout-1" {
+		t.Errorf("Expected only payout-1 to remain in flight, got %+v", pending)
+	}
+}
+
+func TestWithdrawalTrackerStart(t *testing.T) {
+	history := &withdrawalHistoryServer{statuses: map[string]WithdrawStatus{"w1": WithdrawStatusProcessing}}
+	server := httptest.NewServer(history)
+	defer server.Close()
+
+	wallet := NewWalletClient("test_key", "test_secret")
+	wallet.BaseURL = server.URL
+	tracker, _ := NewWithdrawalTracker(wallet, "")
+	tracker.PollInterval = 10 * time.Millisecond
+	tracker.Track(WithdrawalRef{ID: "w1"})
+	tracker.Start()
+	defer tracker.Close()
+
+	time.AfterFunc(30*time.Millisecond, func() { history.set("w1", WithdrawStatusFailure) })
+	withdrawal, err := tracker.Wait(WithdrawalRef{ID: "w1"}, 2*time.Second)
+	if err != nil || withdrawal.Status != WithdrawStatusFailure {
+		t.Errorf("Expected failed withdrawal, got %+v, %v", withdrawal, err)
+	}
+}
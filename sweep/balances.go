@@ -0,0 +1,163 @@
+package sweep
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// subAccountPageSize is the largest page of SubAccountList
+const subAccountPageSize = 200
+
+// Futures types of SubAccountFuturesAccount
+const (
+	futuresUSDT = 1
+	futuresCoin = 2
+)
+
+// balance is the transferable amount of an asset in one wallet
+type balance struct {
+	wallet string
+	asset  string
+	amount float64
+}
+
+// accounts returns the sub-accounts to sweep
+func (s *Sweeper) accounts() ([]string, error) {
+	if len(s.Accounts) > 0 {
+		return s.Accounts, nil
+	}
+	subAccounts, err := utils.CollectPages(subAccountPageSize, func(page int) ([]spot.SubAccount, error) {
+		response, err := s.subAccounts.SubAccountList(map[string]interface{}{"page": page, "limit": subAccountPageSize})
+		if err != nil {
+			return nil, err
+		}
+		var list spot.SubAccounts
+		if err := client.ParseResponse(response, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse sub-accounts: %w", err)
+		}
+		return list.SubAccounts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []string
+	for _, subAccount := range subAccounts {
+		// Managed sub-accounts are moved with ManagedSubAccountWithdraw instead
+		if subAccount.IsFreeze || subAccount.IsManagedSubAccount {
+			continue
+		}
+		accounts = append(accounts, subAccount.Email)
+	}
+	return accounts, nil
+}
+
+// balances reads the transferable balances of every swept wallet of account
+func (s *Sweeper) balances(account string) ([]balance, error) {
+	var balances []balance
+	for _, wallet := range s.Wallets {
+		var records []balance
+		var err error
+		switch wallet {
+		case ledger.WalletSpot:
+			records, err = s.spotBalances(account)
+		case ledger.WalletMargin:
+			records, err = s.marginBalances(account)
+		case ledger.WalletUSDTFuture:
+			records, err = s.futuresBalances(account, futuresUSDT)
+		case ledger.WalletCoinFuture:
+			records, err = s.futuresBalances(account, futuresCoin)
+		default:
+			return nil, fmt.Errorf("unsupported sweep wallet %q", wallet)
+		}
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, records...)
+	}
+	return balances, nil
+}
+
+// spotBalances reads the free spot balances of account
+func (s *Sweeper) spotBalances(account string) ([]balance, error) {
+	response, err := s.subAccounts.QuerySubAccountAssets(account, nil)
+	if err != nil {
+		return nil, err
+	}
+	var assets spot.SubAccountBalances
+	if err := client.ParseResponse(response, &assets); err != nil {
+		return nil, fmt.Errorf("failed to parse sub-account assets of %s: %w", account, err)
+	}
+
+	var balances []balance
+	for _, asset := range assets.Balances {
+		free, err := utils.ParseAmount(asset.Free)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance{wallet: ledger.WalletSpot, asset: asset.Asset, amount: free})
+	}
+	return balances, nil
+}
+
+// marginBalances reads the cross margin balances of account, capped at the
+// net asset so borrowed funds are never swept
+func (s *Sweeper) marginBalances(account string) ([]balance, error) {
+	response, err := s.subAccounts.SubAccountMarginAccount(account, nil)
+	if err != nil {
+		return nil, err
+	}
+	var detail spot.SubAccountMarginDetail
+	if err := client.ParseResponse(response, &detail); err != nil {
+		return nil, fmt.Errorf("failed to parse sub-account margin account of %s: %w", account, err)
+	}
+
+	var balances []balance
+	for _, asset := range detail.MarginUserAssetVoList {
+		free, err := utils.ParseAmount(asset.Free)
+		if err != nil {
+			return nil, err
+		}
+		net, err := utils.ParseAmount(asset.NetAsset)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance{wallet: ledger.WalletMargin, asset: asset.Asset, amount: math.Max(0, math.Min(free, net))})
+	}
+	return balances, nil
+}
+
+// futuresBalances reads the withdrawable futures balances of account
+func (s *Sweeper) futuresBalances(account string, futuresType int) ([]balance, error) {
+	response, err := s.subAccounts.SubAccountFuturesAccount(account, futuresType, nil)
+	if err != nil {
+		return nil, err
+	}
+	var detail spot.SubAccountFuturesDetail
+	if err := client.ParseResponse(response, &detail); err != nil {
+		return nil, fmt.Errorf("failed to parse sub-account futures account of %s: %w", account, err)
+	}
+
+	futures, wallet := detail.FutureAccountResp, ledger.WalletUSDTFuture
+	if futuresType == futuresCoin {
+		futures, wallet = detail.DeliveryAccountResp, ledger.WalletCoinFuture
+	}
+	if futures == nil {
+		return nil, nil
+	}
+
+	var balances []balance
+	for _, asset := range futures.Assets {
+		amount, err := utils.ParseAmount(asset.MaxWithdrawAmount)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance{wallet: wallet, asset: asset.Asset, amount: amount})
+	}
+	return balances, nil
+}
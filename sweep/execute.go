@@ -0,0 +1,125 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+// Statuses of SubAccountUniversalTransferHistory
+const (
+	historySuccess = "SUCCESS"
+	historyFailure = "FAILURE"
+)
+
+// Execute submits every planned transfer of plan, updating it in place
+//
+// A transfer the API rejects is marked failed and the others are still
+// submitted. A transfer whose request fails without an API error may or may
+// not have been made; it is marked submitted and left for Verify to resolve
+// by its clientTranId. Transfers already submitted are never resubmitted.
+func (s *Sweeper) Execute(plan *Plan) error {
+	var failed int
+	for i := range plan.Transfers {
+		transfer := &plan.Transfers[i]
+		if transfer.Status != StatusPlanned {
+			continue
+		}
+		if err := s.submit(transfer); err != nil {
+			transfer.Error = err.Error()
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) {
+				transfer.Status = StatusFailed
+				failed++
+				continue
+			}
+		}
+		transfer.Status = StatusSubmitted
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sweep transfers rejected", failed, len(plan.Transfers))
+	}
+	return nil
+}
+
+// submit makes one transfer from the sub-account to the master account
+func (s *Sweeper) submit(transfer *Transfer) error {
+	response, err := s.subAccounts.SubAccountUniversalTransfer(transfer.FromWallet, transfer.ToWallet, transfer.Asset, transfer.Amount, map[string]interface{}{
+		"fromEmail":    transfer.Account,
+		"clientTranId": transfer.ClientTranID,
+	})
+	if err != nil {
+		return err
+	}
+	var result spot.SubAccountUniversalTransferResult
+	if err := client.ParseResponse(response, &result); err != nil {
+		return fmt.Errorf("failed to parse universal transfer: %w", err)
+	}
+	transfer.TranID = result.TranID
+	return nil
+}
+
+// Verify queries the history once for every submitted transfer of plan and
+// updates its status
+func (s *Sweeper) Verify(plan *Plan) error {
+	for i := range plan.Transfers {
+		transfer := &plan.Transfers[i]
+		if transfer.Status != StatusSubmitted {
+			continue
+		}
+		response, err := s.subAccounts.SubAccountUniversalTransferHistory(map[string]interface{}{
+			"fromEmail":    transfer.Account,
+			"clientTranId": transfer.ClientTranID,
+		})
+		if err != nil {
+			return err
+		}
+		var history spot.SubAccountUniversalTransfers
+		if err := client.ParseResponse(response, &history); err != nil {
+			return fmt.Errorf("failed to parse universal transfer history: %w", err)
+		}
+		for _, record := range history.Result {
+			if record.ClientTranID != transfer.ClientTranID {
+				continue
+			}
+			transfer.TranID = record.TranID
+			switch record.Status {
+			case historySuccess:
+				transfer.Status = StatusSuccess
+				transfer.Error = ""
+			case historyFailure:
+				transfer.Status = StatusFailed
+				transfer.Error = "transfer failed"
+			}
+		}
+	}
+	return nil
+}
+
+// Wait verifies plan every PollInterval until every transfer is final or
+// timeout elapses
+//
+// It returns an error if a transfer failed or is still pending at the timeout.
+func (s *Sweeper) Wait(plan *Plan, timeout time.Duration) error {
+	deadline := s.now().Add(timeout)
+	for {
+		if err := s.Verify(plan); err != nil {
+			return err
+		}
+		pending := plan.Pending()
+		if len(pending) == 0 {
+			break
+		}
+		if !s.now().Before(deadline) {
+			return fmt.Errorf("%d sweep transfers not confirmed after %s", len(pending), timeout)
+		}
+		s.sleep(s.PollInterval)
+	}
+	if failed := plan.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%d of %d sweep transfers failed", len(failed), len(plan.Transfers))
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Package sweep consolidates sub-account balances into the master account
+//
+// A Sweeper reads the balances of every sub-account, applies the policy set
+// for each account and asset to decide how much stays behind, and plans
+// SubAccountUniversalTransfer moves of the rest to the master account. A plan
+// can be reviewed as a dry run before it is executed. Every transfer carries
+// a clientTranId unique to its plan, so executing a plan again never submits
+// a transfer twice, and is confirmed with SubAccountUniversalTransferHistory.
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+const (
+	defaultPrecision     = 8
+	defaultPollInterval  = 5 * time.Second
+	defaultVerifyTimeout = time.Minute
+)
+
+// Status is the state of a planned transfer
+type Status string
+
+const (
+	// StatusPlanned is a transfer not yet submitted
+	StatusPlanned Status = "PLANNED"
+	// StatusSubmitted is a transfer submitted but not yet confirmed by the history
+	StatusSubmitted Status = "SUBMITTED"
+	// StatusSuccess is a transfer the history reports as completed
+	StatusSuccess Status = "SUCCESS"
+	// StatusFailed is a transfer rejected on submission or failed in the history
+	StatusFailed Status = "FAILED"
+)
+
+// Final reports whether the transfer can no longer change status
+func (s Status) Final() bool {
+	return s == StatusSuccess || s == StatusFailed
+}
+
+// Policy decides how much of a sub-account balance is swept
+//
+// A balance above SweepAbove is swept down to KeepMinimum. The zero Policy
+// sweeps everything to the master spot wallet.
+type Policy struct {
+	// KeepMinimum is the amount left in the sub-account wallet
+	KeepMinimum float64
+	// SweepAbove is the balance a wallet must exceed before it is swept. A
+	// value below KeepMinimum is treated as KeepMinimum.
+	SweepAbove float64
+	// ToWallet is the master account wallet receiving the sweep, e.g.
+	// ledger.WalletUSDTFuture. Default ledger.WalletSpot.
+	ToWallet string
+	// Skip leaves the balance untouched
+	Skip bool
+}
+
+// amount returns how much of balance the policy sweeps
+func (p Policy) amount(balance float64) float64 {
+	if p.Skip || balance <= math.Max(p.SweepAbove, p.KeepMinimum) {
+		return 0
+	}
+	return balance - p.KeepMinimum
+}
+
+// Transfer is one planned move from a sub-account to the master account
+type Transfer struct {
+	Account    string // sub-account email
+	FromWallet string
+	ToWallet   string
+	Asset      string
+	// Balance is the transferable balance of the wallet when planned
+	Balance float64
+	Amount  float64
+	// ClientTranID identifies the transfer in the history
+	ClientTranID string
+	// TranID is set once the transfer is accepted
+	TranID int64
+	Status Status
+	// Error is why the transfer failed, or the error of a submission whose
+	// outcome is unknown and left to Verify
+	Error string
+}
+
+// Plan is the set of transfers of one sweep
+type Plan struct {
+	// ID prefixes the clientTranId of every transfer
+	ID        string
+	Time      time.Time
+	Transfers []Transfer
+}
+
+// Total returns the amount of each asset the plan sweeps
+func (p *Plan) Total() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, transfer := range p.Transfers {
+		totals[transfer.Asset] += transfer.Amount
+	}
+	return totals
+}
+
+// Pending returns the transfers not yet in a final status
+func (p *Plan) Pending() []Transfer {
+	var pending []Transfer
+	for _, transfer := range p.Transfers {
+		if !transfer.Status.Final() {
+			pending = append(pending, transfer)
+		}
+	}
+	return pending
+}
+
+// Failed returns the transfers that failed
+func (p *Plan) Failed() []Transfer {
+	var failed []Transfer
+	for _, transfer := range p.Transfers {
+		if transfer.Status == StatusFailed {
+			failed = append(failed, transfer)
+		}
+	}
+	return failed
+}
+
+// policyKey identifies a policy; an empty account or asset matches any
+type policyKey struct {
+	account string
+	asset   string
+}
+
+// Sweeper plans and executes sweeps of sub-account balances to the master account
+//
+// The API key needs the "internal transfer" permission to execute a plan.
+type Sweeper struct {
+	// Wallets are the sub-account wallets swept: ledger.WalletSpot,
+	// ledger.WalletMargin, ledger.WalletUSDTFuture or ledger.WalletCoinFuture.
+	// Default ledger.WalletSpot.
+	Wallets []string
+	// Accounts limits the sweep to these sub-account emails. Default every
+	// sub-account that is neither frozen nor managed.
+	Accounts []string
+	// Default is the policy of balances without a policy set with SetPolicy.
+	// Default skips them, so only assets given a policy are swept.
+	Default Policy
+	// Precision is the number of decimal places amounts are rounded down to. Default 8.
+	Precision int
+	// Prefix starts every plan ID and clientTranId. Default "sweep".
+	Prefix string
+	// DryRun makes Run return the plan without executing it
+	DryRun bool
+	// PollInterval is the wait between history queries in Wait. Default 5s.
+	PollInterval time.Duration
+	// VerifyTimeout bounds how long Run waits for transfers to complete. Default 1m.
+	VerifyTimeout time.Duration
+
+	subAccounts *spot.SubAccountClient
+	policies    map[policyKey]Policy
+	now         func() time.Time
+	sleep       func(time.Duration)
+}
+
+// NewSweeper creates a sweeper reading balances and transferring with subAccounts
+func NewSweeper(subAccounts *spot.SubAccountClient) *Sweeper {
+	return &Sweeper{
+		Wallets:       []string{ledger.WalletSpot},
+		Default:       Policy{Skip: true},
+		Precision:     defaultPrecision,
+		Prefix:        "sweep",
+		PollInterval:  defaultPollInterval,
+		VerifyTimeout: defaultVerifyTimeout,
+		subAccounts:   subAccounts,
+		policies:      make(map[policyKey]Policy),
+		now:           time.Now,
+		sleep:         time.Sleep,
+	}
+}
+
+// SetPolicy sets the policy of asset in account
+//
+// An empty account applies the policy to the asset in every sub-account, and
+// an empty asset to every asset of the account. The most specific policy
+// wins: account and asset, then asset, then account, then Default.
+func (s *Sweeper) SetPolicy(account, asset string, policy Policy) {
+	s.policies[policyKey{account, asset}] = policy
+}
+
+// Policy returns the policy applied to asset in account
+func (s *Sweeper) Policy(account, asset string) Policy {
+	for _, key := range []policyKey{{account, asset}, {"", asset}, {account, ""}} {
+		if policy, ok := s.policies[key]; ok {
+			return policy
+		}
+	}
+	return s.Default
+}
+
+// Run plans a sweep and, unless DryRun is set, executes it and waits up to
+// VerifyTimeout for every transfer to complete
+//
+// The transfers submitted are waited for even when others were rejected, and
+// the errors of both steps are returned together.
+func (s *Sweeper) Run() (*Plan, error) {
+	plan, err := s.Plan()
+	if err != nil || s.DryRun {
+		return plan, err
+	}
+	executeErr := s.Execute(plan)
+	return plan, errors.Join(executeErr, s.Wait(plan, s.VerifyTimeout))
+}
+
+// Plan reads the current balances and returns the transfers the policies call for
+func (s *Sweeper) Plan() (*Plan, error) {
+	accounts, err := s.accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	at := s.now()
+	plan := &Plan{ID: fmt.Sprintf("%s-%d", s.Prefix, at.UnixMilli()), Time: at}
+	for _, account := range accounts {
+		balances, err := s.balances(account)
+		if err != nil {
+			return nil, err
+		}
+		for _, balance := range balances {
+			policy := s.Policy(account, balance.asset)
+			amount := roundDown(policy.amount(balance.amount), s.Precision)
+			if amount <= 0 {
+				continue
+			}
+			toWallet := policy.ToWallet
+			if toWallet == "" {
+				toWallet = ledger.WalletSpot
+			}
+			plan.Transfers = append(plan.Transfers, Transfer{
+				Account:    account,
+				FromWallet: balance.wallet,
+				ToWallet:   toWallet,
+				Asset:      balance.asset,
+				Balance:    balance.amount,
+				Amount:     amount,
+				Status:     StatusPlanned,
+			})
+		}
+	}
+
+	sort.SliceStable(plan.Transfers, func(i, j int) bool {
+		a, b := plan.Transfers[i], plan.Transfers[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.FromWallet != b.FromWallet {
+			return a.FromWallet < b.FromWallet
+		}
+		return a.Asset < b.Asset
+	})
+	for i := range plan.Transfers {
+		plan.Transfers[i].ClientTranID = fmt.Sprintf("%s-%d", plan.ID, i+1)
+	}
+	return plan, nil
+}
+
+// roundDown rounds amount down to precision decimal places
+func roundDown(amount float64, precision int) float64 {
+	scale := math.Pow10(precision)
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(math.Floor(amount*scale+1e-9)/scale, 'f', precision, 64), 64)
+	return rounded
+}
@@ -0,0 +1,314 @@
+package sweep
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+// testExchange serves the sub-account endpoints of two sub-accounts and
+// records the universal transfers made
+type testExchange struct {
+	t         *testing.T
+	transfers []map[string]string
+	// history is the status reported for a clientTranId; missing ones are not listed
+	history map[string]string
+	reject  string // asset whose transfers are rejected
+}
+
+func (e *testExchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch r.URL.Path {
+	case "/sapi/v1/sub-account/list":
+		w.Write([]byte(`{"subAccounts":[{"email":"a@test.com","isFreeze":false},{"email":"frozen@test.com","isFreeze":true},
+			{"email":"b@test.com","isFreeze":false},{"email":"managed@test.com","isFreeze":false,"isManagedSubAccount":true}]}`))
+	case "/sapi/v4/sub-account/assets":
+		switch query.Get("email") {
+		case "a@test.com":
+			w.Write([]byte(`{"balances":[{"asset":"USDT","free":"1500.123456789","locked":"20"},{"asset":"BTC","free":"0.3","locked":"0"},{"asset":"BNB","free":"5","locked":"0"}]}`))
+		case "b@test.com":
+			w.Write([]byte(`{"balances":[{"asset":"USDT","free":"80","locked":"0"}]}`))
+		default:
+			e.t.Errorf("Unexpected sub-account %s", query.Get("email"))
+		}
+	case "/sapi/v2/sub-account/futures/account":
+		if query.Get("futuresType") == "1" {
+			w.Write([]byte(`{"futureAccountResp":{"email":"a@test.com","asset":"USDT","assets":[{"asset":"USDT","walletBalance":"900","maxWithdrawAmount":"600"}]}}`))
+		} else {
+			w.Write([]byte(`{"deliveryAccountResp":{"email":"a@test.com","assets":[]}}`))
+		}
+	case "/sapi/v1/sub-account/margin/account":
+		w.Write([]byte(`{"email":"a@test.com","marginUserAssetVoList":[{"asset":"USDT","borrowed":"100","free":"300","interest":"0","locked":"0","netAsset":"200"}]}`))
+	case "/sapi/v1/sub-account/universalTransfer":
+		if r.Method == http.MethodGet {
+			id := query.Get("clientTranId")
+			if query.Get("fromEmail") == "" || id == "" {
+				e.t.Errorf("Expected the history to be queried by fromEmail and clientTranId, got %s", r.URL.RawQuery)
+			}
+			status, ok := e.history[id]
+			if !ok {
+				w.Write([]byte(`{"result":[],"totalCount":0}`))
+				return
+			}
+			w.Write([]byte(`{"result":[{"tranId":11,"fromEmail":"` + query.Get("fromEmail") + `","asset":"USDT","amount":"1","status":"` + status + `","clientTranId":"` + id + `"}],"totalCount":1}`))
+			return
+		}
+		if query.Get("asset") == e.reject {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-9000,"msg":"Insufficient balance"}`))
+			return
+		}
+		transfer := make(map[string]string)
+		for key := range query {
+			transfer[key] = query.Get(key)
+		}
+		e.transfers = append(e.transfers, transfer)
+		w.Write([]byte(`{"tranId":11,"clientTranId":"` + query.Get("clientTranId") + `"}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestSweeper(t *testing.T, exchange *testExchange) (*Sweeper, func()) {
+	server := httptest.NewServer(exchange)
+	subAccounts := spot.NewSubAccountClient("test_key", "test_secret")
+	subAccounts.BaseURL = server.URL
+
+	sweeper := NewSweeper(subAccounts)
+	sweeper.now = func() time.Time { return time.UnixMilli(1700000000000) }
+	sweeper.sleep = func(time.Duration) {}
+	return sweeper, server.Close
+}
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		policy  Policy
+		balance float64
+		want    float64
+	}{
+		{Policy{}, 10, 10},
+		{Policy{KeepMinimum: 4}, 10, 6},
+		{Policy{KeepMinimum: 4}, 3, 0},
+		{Policy{KeepMinimum: 4, SweepAbove: 12}, 10, 0},
+		{Policy{KeepMinimum: 4, SweepAbove: 8}, 10, 6},
+		{Policy{KeepMinimum: 4, SweepAbove: 1}, 4, 0},
+		{Policy{Skip: true}, 10, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.amount(tt.balance); got != tt.want {
+			t.Errorf("%+v on %v: expected %v, got %v", tt.policy, tt.balance, tt.want, got)
+		}
+	}
+
+	sweeper := NewSweeper(nil)
+	sweeper.SetPolicy("", "USDT", Policy{KeepMinimum: 1})
+	sweeper.SetPolicy("a@test.com", "", Policy{KeepMinimum: 2})
+	sweeper.SetPolicy("a@test.com", "USDT", Policy{KeepMinimum: 3})
+	for _, tt := range []struct {
+		account, asset string
+		want           Policy
+	}{
+		{"a@test.com", "USDT", Policy{KeepMinimum: 3}},
+		{"b@test.com", "USDT", Policy{KeepMinimum: 1}},
+		{"a@test.com", "BTC", Policy{KeepMinimum: 2}},
+		{"b@test.com", "BTC", Policy{Skip: true}},
+	} {
+		if got := sweeper.Policy(tt.account, tt.asset); got != tt.want {
+			t.Errorf("Policy(%s, %s): expected %+v, got %+v", tt.account, tt.asset, tt.want, got)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	exchange := &testExchange{t: t}
+	sweeper, closeServer := newTestSweeper(t, exchange)
+	defer closeServer()
+
+	sweeper.SetPolicy("", "USDT", Policy{KeepMinimum: 100, SweepAbove: 500})
+	sweeper.SetPolicy("a@test.com", "BTC", Policy{ToWallet: ledger.WalletUSDTFuture})
+	plan, err := sweeper.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// b@test.com holds too little USDT and BNB has no policy
+	if plan.ID != "sweep-1700000000000" || len(plan.Transfers) != 2 {
+		t.Fatalf("Unexpected plan %+v", plan)
+	}
+	btc, usdt := plan.Transfers[0], plan.Transfers[1]
+	if btc.Asset != "BTC" || btc.Amount != 0.3 || btc.ToWallet != ledger.WalletUSDTFuture || btc.ClientTranID != "sweep-1700000000000-1" {
+		t.Errorf("Unexpected BTC transfer %+v", btc)
+	}
+	// Locked funds are not transferable and amounts are rounded down
+	if usdt.Account != "a@test.com" || usdt.FromWallet != ledger.WalletSpot || usdt.ToWallet != ledger.WalletSpot || usdt.Amount != 1400.12345678 || usdt.Status != StatusPlanned {
+		t.Errorf("Unexpected USDT transfer %+v", usdt)
+	}
+	if len(exchange.transfers) != 0 {
+		t.Errorf("Planning made %d transfers", len(exchange.transfers))
+	}
+}
+
+func TestPlanWallets(t *testing.T) {
+	exchange := &testExchange{t: t}
+	sweeper, closeServer := newTestSweeper(t, exchange)
+	defer closeServer()
+
+	sweeper.Accounts = []string{"a@test.com"}
+	sweeper.Wallets = []string{ledger.WalletMargin, ledger.WalletUSDTFuture, ledger.WalletCoinFuture}
+	sweeper.Default = Policy{}
+	plan, err := sweeper.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Transfers) != 2 || plan.Total()["USDT"] != 800 {
+		t.Fatalf("Unexpected plan %+v", plan.Transfers)
+	}
+	// Borrowed margin funds stay, and futures only give up their withdrawable amount
+	if margin := plan.Transfers[0]; margin.FromWallet != ledger.WalletMargin || margin.Amount != 200 {
+		t.Errorf("Unexpected margin transfer %+v", margin)
+	}
+	if futures := plan.Transfers[1]; futures.FromWallet != ledger.WalletUSDTFuture || futures.Amount != 600 {
+		t.Errorf("Unexpected futures transfer %+v", futures)
+	}
+
+	sweeper.Wallets = []string{ledger.WalletOption}
+	if _, err := sweeper.Plan(); err == nil {
+		t.Error("Expected error for an unsupported wallet")
+	}
+}
+
+func TestRun(t *testing.T) {
+	exchange := &testExchange{t: t, history: make(map[string]string)}
+	sweeper, closeServer := newTestSweeper(t, exchange)
+	defer closeServer()
+
+	sweeper.Default = Policy{}
+	sweeper.DryRun = true
+	plan, err := sweeper.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Transfers) != 4 || len(exchange.transfers) != 0 {
+		t.Fatalf("Expected a dry run of 4 transfers, got %d planned and %d made", len(plan.Transfers), len(exchange.transfers))
+	}
+
+	// Transfers complete after the first poll
+	polls := 0
+	sweeper.sleep = func(time.Duration) {
+		polls++
+		for _, transfer := range exchange.transfers {
+			exchange.history[transfer["clientTranId"]] = "SUCCESS"
+		}
+	}
+	sweeper.DryRun = false
+	plan, err = sweeper.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exchange.transfers) != 4 || polls != 1 {
+		t.Fatalf("Expected 4 transfers confirmed after 1 poll, got %d transfers and %d polls", len(exchange.transfers), polls)
+	}
+	first := exchange.transfers[0]
+	if first["fromEmail"] != "a@test.com" || first["toEmail"] != "" || first["fromAccountType"] != "SPOT" || first["toAccountType"] != "SPOT" || first["clientTranId"] != plan.Transfers[0].ClientTranID {
+		t.Errorf("Unexpected transfer %v", first)
+	}
+	for _, transfer := range plan.Transfers {
+		if transfer.Status != StatusSuccess || transfer.TranID != 11 {
+			t.Errorf("Unexpected transfer %+v", transfer)
+		}
+	}
+
+	// A plan is never submitted twice
+	if err := sweeper.Execute(plan); err != nil || len(exchange.transfers) != 4 {
+		t.Errorf("Expected no resubmission, got %d transfers, err %v", len(exchange.transfers), err)
+	}
+}
+
+func TestRunRejected(t *testing.T) {
+	exchange := &testExchange{t: t, history: make(map[string]string), reject: "BNB"}
+	sweeper, closeServer := newTestSweeper(t, exchange)
+	defer closeServer()
+
+	sweeper.Accounts = []string{"a@test.com"}
+	sweeper.Default = Policy{}
+	sweeper.sleep = func(time.Duration) {
+		for _, transfer := range exchange.transfers {
+			exchange.history[transfer["clientTranId"]] = "SUCCESS"
+		}
+	}
+	plan, err := sweeper.Run()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 sweep transfers rejected") {
+		t.Errorf("Expected the rejected transfer to be reported, got %v", err)
+	}
+	// The submitted transfers are still confirmed
+	if len(plan.Failed()) != 1 || len(plan.Pending()) != 0 || plan.Transfers[1].Status != StatusSuccess || plan.Transfers[2].Status != StatusSuccess {
+		t.Errorf("Unexpected transfers %+v", plan.Transfers)
+	}
+}
+
+func TestExecuteFailures(t *testing.T) {
+	exchange := &testExchange{t: t, history: make(map[string]string), reject: "BNB"}
+	sweeper, closeServer := newTestSweeper(t, exchange)
+	defer closeServer()
+
+	sweeper.Accounts = []string{"a@test.com"}
+	sweeper.Default = Policy{}
+	plan, err := sweeper.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sweeper.Execute(plan); err == nil {
+		t.Fatal("Expected error for the rejected transfer")
+	}
+	if bnb := plan.Transfers[0]; bnb.Asset != "BNB" || bnb.Status != StatusFailed || !strings.Contains(bnb.Error, "Insufficient balance") {
+		t.Errorf("Unexpected rejected transfer %+v", bnb)
+	}
+	if len(exchange.transfers) != 2 || len(plan.Pending()) != 2 {
+		t.Fatalf("Expected the other transfers to be submitted, got %d", len(exchange.transfers))
+	}
+
+	// One transfer fails in the history and the other never shows up
+	exchange.history[plan.Transfers[1].ClientTranID] = "FAILURE"
+	clock := time.UnixMilli(1700000000000)
+	sweeper.now = func() time.Time { return clock }
+	sweeper.sleep = func(d time.Duration) { clock = clock.Add(d) }
+	err = sweeper.Wait(plan, 12*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "1 sweep transfers not confirmed") {
+		t.Errorf("Expected timeout, got %v", err)
+	}
+	if plan.Transfers[1].Status != StatusFailed || plan.Transfers[2].Status != StatusSubmitted || len(plan.Failed()) != 2 {
+		t.Errorf("Unexpected transfers %+v", plan.Transfers)
+	}
+}
+
+func TestSweeperLive(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping live test: BINANCE_API_KEY or BINANCE_SECRET_KEY not set")
+	}
+
+	sweeper := NewSweeper(spot.NewSubAccountClient(apiKey, apiSecret))
+	sweeper.SetPolicy("", "USDT", Policy{KeepMinimum: 100, SweepAbove: 1000})
+	sweeper.DryRun = true
+	plan, err := sweeper.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, transfer := range plan.Transfers {
+		t.Logf("Would sweep %v %s from %s %s", transfer.Amount, transfer.Asset, transfer.Account, transfer.FromWallet)
+	}
+}
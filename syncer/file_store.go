@@ -0,0 +1,112 @@
+package syncer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileStore keeps checkpoints in a JSON file, rewritten on every Save
+type FileStore struct {
+	path string
+
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewFileStore opens the checkpoint file at path, which is created on the first Save
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		path:        path,
+		checkpoints: make(map[string]Checkpoint),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+	var saved []Checkpoint
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoints: %w", err)
+	}
+	for _, checkpoint := range saved {
+		s.checkpoints[checkpointKey(checkpoint.Source, checkpoint.Account)] = checkpoint
+	}
+	return s, nil
+}
+
+// Load returns the checkpoint of a source and account
+func (s *FileStore) Load(source, account string) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoint, ok := s.checkpoints[checkpointKey(source, account)]
+	return checkpoint, ok, nil
+}
+
+// Save stores a checkpoint and rewrites the file
+func (s *FileStore) Save(checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := checkpointKey(checkpoint.Source, checkpoint.Account)
+	previous, existed := s.checkpoints[key]
+	s.checkpoints[key] = checkpoint
+	if err := s.writeLocked(); err != nil {
+		// Keep memory consistent with the file
+		if existed {
+			s.checkpoints[key] = previous
+		} else {
+			delete(s.checkpoints, key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) writeLocked() error {
+	saved := make([]Checkpoint, 0, len(s.checkpoints))
+	for _, checkpoint := range s.checkpoints {
+		saved = append(saved, checkpoint)
+	}
+	sort.Slice(saved, func(i, j int) bool {
+		if saved[i].Source != saved[j].Source {
+			return saved[i].Source < saved[j].Source
+		}
+		return saved[i].Account < saved[j].Account
+	})
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoints: %w", err)
+	}
+
+	// Write to a temporary file first so a crash cannot leave a truncated file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	return nil
+}
+
+// checkpointKey identifies the checkpoint of a source and account
+func checkpointKey(source, account string) string {
+	return source + "|" + account
+}
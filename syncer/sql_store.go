@@ -0,0 +1,135 @@
+package syncer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	// Registers the "sqlite3" driver used by NewSQLiteStore
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// validTable matches the table names SQLStore accepts, since a table name cannot be a query parameter
+var validTable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLStore keeps checkpoints in a database/sql table, by default in an
+// embedded SQLite database opened by NewSQLiteStore
+//
+// Queries use "?" placeholders, as the SQLite and MySQL drivers do. Times are
+// stored as Unix milliseconds and keys as a newline separated list.
+type SQLStore struct {
+	db    *sql.DB
+	table string
+	// owned is set when the store opened db and closes it
+	owned bool
+}
+
+// NewSQLiteStore opens the SQLite database file at path, creating it if
+// needed, and keeps checkpoints in its table
+func NewSQLiteStore(path, table string) (*SQLStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint database: %w", err)
+	}
+	store, err := NewSQLStore(db, table)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	store.owned = true
+	return store, nil
+}
+
+// NewSQLStore creates the checkpoint table in db if it does not exist
+func NewSQLStore(db *sql.DB, table string) (*SQLStore, error) {
+	if !validTable.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS ` + table + ` (
+		source TEXT NOT NULL,
+		account TEXT NOT NULL,
+		mark INTEGER NOT NULL,
+		event_keys TEXT NOT NULL,
+		updated_at INTEGER NOT NULL,
+		PRIMARY KEY (source, account)
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint table: %w", err)
+	}
+	return &SQLStore{db: db, table: table}, nil
+}
+
+// Load returns the checkpoint of a source and account
+func (s *SQLStore) Load(source, account string) (Checkpoint, bool, error) {
+	var mark, updatedAt int64
+	var keys string
+	err := s.db.QueryRow(`SELECT mark, event_keys, updated_at FROM `+s.table+` WHERE source = ? AND account = ?`, source, account).
+		Scan(&mark, &keys, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Checkpoint{}, false, nil
+	}
+	if err != nil {
+		return Checkpoint{}, false, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	checkpoint := Checkpoint{
+		Source:    source,
+		Account:   account,
+		Time:      time.UnixMilli(mark).UTC(),
+		UpdatedAt: time.UnixMilli(updatedAt).UTC(),
+	}
+	if keys != "" {
+		checkpoint.Keys = strings.Split(keys, "\n")
+	}
+	return checkpoint, true, nil
+}
+
+// Close closes the database opened by NewSQLiteStore; a database passed to
+// NewSQLStore is left open for its owner
+func (s *SQLStore) Close() error {
+	if !s.owned {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// Save stores a checkpoint, replacing the previous one of its source and account
+func (s *SQLStore) Save(checkpoint Checkpoint) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	defer tx.Rollback()
+
+	mark := checkpoint.Time.UnixMilli()
+	keys := strings.Join(checkpoint.Keys, "\n")
+	updatedAt := checkpoint.UpdatedAt.UnixMilli()
+
+	// Look the row up rather than relying on an upsert, whose syntax differs
+	// between databases, or on the rows an UPDATE affected, which MySQL
+	// reports as 0 when the values are unchanged
+	var count int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM `+s.table+` WHERE source = ? AND account = ?`, checkpoint.Source, checkpoint.Account).
+		Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	if count > 0 {
+		_, err = tx.Exec(`UPDATE `+s.table+` SET mark = ?, event_keys = ?, updated_at = ? WHERE source = ? AND account = ?`,
+			mark, keys, updatedAt, checkpoint.Source, checkpoint.Account)
+	} else {
+		_, err = tx.Exec(`INSERT INTO `+s.table+` (source, account, mark, event_keys, updated_at) VALUES (?, ?, ?, ?, ?)`,
+			checkpoint.Source, checkpoint.Account, mark, keys, updatedAt)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
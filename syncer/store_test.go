@@ -0,0 +1,118 @@
+package syncer
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, store Store) {
+	t.Helper()
+
+	if _, ok, err := store.Load("deposits", "sub@test.com"); err != nil || ok {
+		t.Fatalf("Expected no checkpoint, got %v %v", ok, err)
+	}
+
+	checkpoint := Checkpoint{
+		Source:    "deposits",
+		Account:   "sub@test.com",
+		Time:      base,
+		Keys:      []string{"DEPOSIT|d1|sub@test.com|SPOT|USDT|+", "DEPOSIT|d2|sub@test.com|SPOT|USDT|+"},
+		UpdatedAt: base.Add(time.Second),
+	}
+	if err := store.Save(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint.Time = base.Add(time.Hour)
+	checkpoint.Keys = nil
+	if err := store.Save(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	// Saving an unchanged checkpoint again replaces it rather than adding a row
+	if err := store.Save(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(Checkpoint{Source: "deposits", Account: "other@test.com", Time: base}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, ok, err := store.Load("deposits", "sub@test.com")
+	if err != nil || !ok {
+		t.Fatalf("Expected a checkpoint, got %v %v", ok, err)
+	}
+	if !loaded.Time.Equal(checkpoint.Time) || len(loaded.Keys) != 0 || !loaded.UpdatedAt.Equal(checkpoint.UpdatedAt) {
+		t.Errorf("Expected %+v, got %+v", checkpoint, loaded)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint, ok, _ := reopened.Load("deposits", "other@test.com"); !ok || !checkpoint.Time.Equal(base) {
+		t.Errorf("Expected the checkpoint to be persisted, got %+v", checkpoint)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("Expected error for a corrupt file")
+	}
+}
+
+func TestSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.db")
+	if _, err := NewSQLiteStore(path, "checkpoints; DROP TABLE x"); err == nil {
+		t.Error("Expected error for an invalid table name")
+	}
+	store, err := NewSQLiteStore(path, "sync_checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewSQLiteStore(path, "sync_checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if checkpoint, ok, _ := reopened.Load("deposits", "other@test.com"); !ok || !checkpoint.Time.Equal(base) {
+		t.Errorf("Expected the checkpoint to be persisted, got %+v", checkpoint)
+	}
+}
+
+func TestSQLStore(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// Each connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+
+	store, err := NewSQLStore(db, "sync_checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Errorf("Expected a database passed to NewSQLStore to stay open, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+// Package syncer incrementally copies account history into a Sink
+//
+// Each job pairs a ledger.Source with the account it reads. A run resumes
+// from the job's checkpoint in a Store rather than re-reading the whole
+// history, and re-reads an Overlap window before it so records that appear in
+// the history late are still delivered. Events already delivered in that
+// window are recognized by their ledger key and skipped.
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+)
+
+const (
+	defaultOverlap         = 24 * time.Hour
+	defaultInitialLookback = 90 * 24 * time.Hour
+)
+
+// Checkpoint is the high-water mark of one source and account
+type Checkpoint struct {
+	Source  string `json:"source"`
+	Account string `json:"account"`
+	// Time is the end of the last synced range
+	Time time.Time `json:"time"`
+	// Keys are the ledger keys of the delivered events within the overlap
+	// window before Time, which the next run reads again
+	Keys      []string  `json:"keys"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Store persists checkpoints
+type Store interface {
+	// Load returns the checkpoint of a source and account, and false if there is none
+	Load(source, account string) (Checkpoint, bool, error)
+	Save(checkpoint Checkpoint) error
+}
+
+// Sink receives the new events of each run, in chronological order
+//
+// The checkpoint is saved only after Write succeeds, so a failed run is
+// retried from the previous checkpoint and events are delivered at least once.
+type Sink interface {
+	Write(source, account string, events []ledger.Event) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(source, account string, events []ledger.Event) error
+
+// Write calls f
+func (f SinkFunc) Write(source, account string, events []ledger.Event) error {
+	return f(source, account, events)
+}
+
+// Result is the outcome of one job in a run
+type Result struct {
+	Source  string
+	Account string
+	From    time.Time
+	To      time.Time
+	// Read is the number of events read, including those already delivered
+	Read int
+	// Written is the number of new events written to the sink
+	Written int
+	Err     error
+}
+
+type job struct {
+	source  string
+	account string
+	reader  ledger.Source
+}
+
+// Syncer runs incremental syncs of its jobs
+type Syncer struct {
+	// Overlap is how far before the checkpoint each run reads again. Default 24h.
+	Overlap time.Duration
+	// InitialLookback is how far back a job without a checkpoint starts. Default 90 days.
+	InitialLookback time.Duration
+
+	store Store
+	sink  Sink
+	jobs  []job
+	now   func() time.Time
+}
+
+// New creates a syncer keeping checkpoints in store and writing events to sink
+func New(store Store, sink Sink) *Syncer {
+	return &Syncer{
+		Overlap:         defaultOverlap,
+		InitialLookback: defaultInitialLookback,
+		store:           store,
+		sink:            sink,
+		now:             time.Now,
+	}
+}
+
+// Add registers a job reading reader for account, checkpointed under source,
+// e.g. Add("deposits", "sub@test.com", ledger.Deposits(subWallet, "sub@test.com"))
+func (s *Syncer) Add(source, account string, reader ledger.Source) {
+	s.jobs = append(s.jobs, job{source: source, account: account, reader: reader})
+}
+
+// Run syncs every job once, in the order they were added
+// A failing job does not stop the others; the returned error joins every job error.
+func (s *Syncer) Run() ([]Result, error) {
+	results := make([]Result, 0, len(s.jobs))
+	var errs []error
+	for _, j := range s.jobs {
+		result := s.sync(j)
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+		results = append(results, result)
+	}
+	return results, errors.Join(errs...)
+}
+
+// sync reads one job from its checkpoint, writes new events and saves the next checkpoint
+func (s *Syncer) sync(j job) Result {
+	result := Result{Source: j.source, Account: j.account, To: s.now()}
+	fail := func(err error) Result {
+		result.Err = fmt.Errorf("sync %s of %s: %w", j.source, j.account, err)
+		return result
+	}
+
+	checkpoint, ok, err := s.store.Load(j.source, j.account)
+	if err != nil {
+		return fail(err)
+	}
+	seen := make(map[string]bool)
+	if ok {
+		result.From = checkpoint.Time.Add(-s.Overlap)
+		for _, key := range checkpoint.Keys {
+			seen[key] = true
+		}
+	} else {
+		result.From = result.To.Add(-s.InitialLookback)
+	}
+
+	events, err := j.reader.Events(result.From, result.To)
+	if err != nil {
+		return fail(err)
+	}
+	events = ledger.Merge(events)
+	result.Read = len(events)
+
+	var fresh []ledger.Event
+	for _, event := range events {
+		if !seen[event.Key()] {
+			fresh = append(fresh, event)
+		}
+	}
+	if len(fresh) > 0 {
+		if err := s.sink.Write(j.source, j.account, fresh); err != nil {
+			return fail(err)
+		}
+	}
+	result.Written = len(fresh)
+
+	// Remember every event the next run will read again
+	windowStart := result.To.Add(-s.Overlap)
+	var keys []string
+	for _, event := range events {
+		if !event.Time.Before(windowStart) {
+			keys = append(keys, event.Key())
+		}
+	}
+	sort.Strings(keys)
+
+	err = s.store.Save(Checkpoint{
+		Source:    j.source,
+		Account:   j.account,
+		Time:      result.To,
+		Keys:      keys,
+		UpdatedAt: s.now(),
+	})
+	if err != nil {
+		return fail(err)
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+package syncer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+var base = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeSource returns the events of its history within the requested range
+type fakeSource struct {
+	history []ledger.Event
+	ranges  [][2]time.Time
+	err     error
+}
+
+func (s *fakeSource) Events(start, end time.Time) ([]ledger.Event, error) {
+	s.ranges = append(s.ranges, [2]time.Time{start, end})
+	if s.err != nil {
+		return nil, s.err
+	}
+	var events []ledger.Event
+	for _, event := range s.history {
+		if !event.Time.Before(start) && !event.Time.After(end) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func deposit(id string, at time.Time) ledger.Event {
+	return ledger.Event{Type: ledger.EventDeposit, Account: "sub@test.com", Wallet: ledger.WalletSpot, Asset: "USDT", Amount: 1, ExternalID: id, Time: at}
+}
+
+// recordingSink records written events by source
+type recordingSink struct {
+	written map[string][]string
+	err     error
+}
+
+func (s *recordingSink) Write(source, account string, events []ledger.Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.written == nil {
+		s.written = make(map[string][]string)
+	}
+	for _, event := range events {
+		s.written[source] = append(s.written[source], event.ExternalID)
+	}
+	return nil
+}
+
+func TestSyncerResumesWithOverlap(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &recordingSink{}
+	source := &fakeSource{history: []ledger.Event{
+		deposit("old", base.Add(-100*24*time.Hour)),
+		deposit("d1", base.Add(-2*time.Hour)),
+		deposit("d2", base.Add(-30*time.Minute)),
+	}}
+
+	now := base
+	syncer := New(store, sink)
+	syncer.Overlap = time.Hour
+	syncer.now = func() time.Time { return now }
+	syncer.Add("deposits", "sub@test.com", source)
+
+	results, err := syncer.Run()
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !results[0].From.Equal(base.Add(-defaultInitialLookback)) || results[0].Written != 2 {
+		t.Errorf("Unexpected first run %+v", results[0])
+	}
+
+	// d3 arrives late with a time before the checkpoint, d4 is new; d2 is read again
+	source.history = append(source.history, deposit("d3", base.Add(-10*time.Minute)), deposit("d4", base.Add(3*time.Hour)))
+	now = base.Add(4 * time.Hour)
+	results, err = syncer.Run()
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !results[0].From.Equal(base.Add(-time.Hour)) || results[0].Read != 3 || results[0].Written != 2 {
+		t.Errorf("Unexpected second run %+v", results[0])
+	}
+
+	want := []string{"d1", "d2", "d3", "d4"}
+	got := sink.written["deposits"]
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	}
+
+	checkpoint, ok, err := store.Load("deposits", "sub@test.com")
+	if err != nil || !ok {
+		t.Fatalf("Expected a checkpoint, got %v %v", ok, err)
+	}
+	// Only d4 falls within the overlap before the new checkpoint
+	if !checkpoint.Time.Equal(now) || len(checkpoint.Keys) != 1 || checkpoint.Keys[0] != deposit("d4", time.Time{}).Key() {
+		t.Errorf("Unexpected checkpoint %+v", checkpoint)
+	}
+}
+
+func TestSyncerKeepsCheckpointOnFailure(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &recordingSink{err: errors.New("disk full")}
+	failing := &fakeSource{history: []ledger.Event{deposit("d1", base.Add(-time.Hour))}}
+	broken := &fakeSource{err: errors.New("timeout")}
+
+	syncer := New(store, sink)
+	syncer.now = func() time.Time { return base }
+	syncer.Add("deposits", "a", failing)
+	syncer.Add("withdrawals", "a", broken)
+
+	results, err := syncer.Run()
+	if err == nil || len(results) != 2 || results[0].Err == nil || results[1].Err == nil {
+		t.Fatalf("Expected both jobs to fail, got %+v %v", results, err)
+	}
+	if _, ok, _ := store.Load("deposits", "a"); ok {
+		t.Error("Expected no checkpoint after a failed write")
+	}
+
+	// Retried from the start once the sink recovers
+	sink.err = nil
+	if _, err := syncer.Run(); err == nil {
+		t.Error("Expected the broken source to fail again")
+	}
+	if len(sink.written["deposits"]) != 1 || !failing.ranges[1][0].Equal(failing.ranges[0][0]) {
+		t.Errorf("Expected the deposit to be retried from the same start, got %v %v", sink.written, failing.ranges)
+	}
+}
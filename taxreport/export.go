@@ -0,0 +1,207 @@
+package taxreport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON format written by WriteJSON
+const SchemaVersion = "1"
+
+// JSONSchema is the JSON Schema of the document written by WriteJSON
+// Amounts are decimal strings so no precision is lost in transit.
+const JSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Capital gains report",
+  "type": "object",
+  "required": ["schemaVersion", "start", "end", "currency", "method", "longTermThresholdDays", "totals", "lines"],
+  "properties": {
+    "schemaVersion": {"const": "1"},
+    "start": {"type": "string", "format": "date-time"},
+    "end": {"type": "string", "format": "date-time"},
+    "currency": {"type": "string"},
+    "method": {"enum": ["FIFO", "LIFO", "HIFO", "AVERAGE"]},
+    "longTermThresholdDays": {"type": "number"},
+    "totals": {
+      "type": "object",
+      "required": ["proceeds", "cost", "gain", "shortTermGain", "longTermGain", "unknownTermGain"],
+      "properties": {
+        "proceeds": {"$ref": "#/$defs/decimal"},
+        "cost": {"$ref": "#/$defs/decimal"},
+        "gain": {"$ref": "#/$defs/decimal"},
+        "shortTermGain": {"$ref": "#/$defs/decimal"},
+        "longTermGain": {"$ref": "#/$defs/decimal"},
+        "unknownTermGain": {"$ref": "#/$defs/decimal"}
+      }
+    },
+    "lines": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["asset", "quantity", "disposed", "proceeds", "cost", "gain", "term", "disposalId"],
+        "properties": {
+          "asset": {"type": "string"},
+          "quantity": {"$ref": "#/$defs/decimal"},
+          "acquired": {"type": "string", "format": "date-time", "description": "Absent for UNKNOWN term lines"},
+          "disposed": {"type": "string", "format": "date-time"},
+          "holdingDays": {"type": "integer", "description": "Whole days between acquired and disposed"},
+          "term": {"enum": ["SHORT", "LONG", "UNKNOWN"]},
+          "proceeds": {"$ref": "#/$defs/decimal"},
+          "cost": {"$ref": "#/$defs/decimal"},
+          "gain": {"$ref": "#/$defs/decimal"},
+          "disposalId": {"type": "string"},
+          "lotId": {"type": "string"}
+        }
+      }
+    }
+  },
+  "$defs": {
+    "decimal": {"type": "string", "pattern": "^-?[0-9]+(\\.[0-9]+)?$"}
+  }
+}`
+
+// csvHeader are the columns written by WriteCSV
+var csvHeader = []string{
+	"asset", "quantity", "acquired", "disposed", "holding_days", "term",
+	"proceeds", "cost", "gain", "currency", "disposal_id", "lot_id",
+}
+
+type jsonReport struct {
+	SchemaVersion         string     `json:"schemaVersion"`
+	Start                 string     `json:"start"`
+	End                   string     `json:"end"`
+	Currency              string     `json:"currency"`
+	Method                string     `json:"method"`
+	LongTermThresholdDays float64    `json:"longTermThresholdDays"`
+	Totals                jsonTotals `json:"totals"`
+	Lines                 []jsonLine `json:"lines"`
+}
+
+type jsonTotals struct {
+	Proceeds        string `json:"proceeds"`
+	Cost            string `json:"cost"`
+	Gain            string `json:"gain"`
+	ShortTermGain   string `json:"shortTermGain"`
+	LongTermGain    string `json:"longTermGain"`
+	UnknownTermGain string `json:"unknownTermGain"`
+}
+
+type jsonLine struct {
+	Asset       string `json:"asset"`
+	Quantity    string `json:"quantity"`
+	Acquired    string `json:"acquired,omitempty"`
+	Disposed    string `json:"disposed"`
+	HoldingDays *int   `json:"holdingDays,omitempty"`
+	Term        Term   `json:"term"`
+	Proceeds    string `json:"proceeds"`
+	Cost        string `json:"cost"`
+	Gain        string `json:"gain"`
+	DisposalID  string `json:"disposalId"`
+	LotID       string `json:"lotId,omitempty"`
+}
+
+// WriteCSV writes the report's lines as CSV with a header row
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, line := range r.Lines {
+		record := []string{
+			line.Asset,
+			r.decimal(line.Quantity),
+			r.timestamp(line.Acquired),
+			r.timestamp(line.Disposed),
+			"",
+			string(line.Term),
+			r.decimal(line.Proceeds),
+			r.decimal(line.Cost),
+			r.decimal(line.Gain),
+			r.Currency,
+			line.DisposalID,
+			line.LotID,
+		}
+		if line.Term != UnknownTerm {
+			record[4] = strconv.Itoa(holdingDays(line.HoldingPeriod))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the report as a JSON document matching JSONSchema
+func (r *Report) WriteJSON(w io.Writer) error {
+	document := jsonReport{
+		SchemaVersion:         SchemaVersion,
+		Start:                 r.timestamp(r.Start),
+		End:                   r.timestamp(r.End),
+		Currency:              r.Currency,
+		Method:                string(r.Method),
+		LongTermThresholdDays: r.LongTermThreshold.Hours() / 24,
+		Totals: jsonTotals{
+			Proceeds:        r.decimal(r.Totals.Proceeds),
+			Cost:            r.decimal(r.Totals.Cost),
+			Gain:            r.decimal(r.Totals.Gain),
+			ShortTermGain:   r.decimal(r.Totals.ShortTermGain),
+			LongTermGain:    r.decimal(r.Totals.LongTermGain),
+			UnknownTermGain: r.decimal(r.Totals.UnknownTermGain),
+		},
+		Lines: make([]jsonLine, 0, len(r.Lines)),
+	}
+	for _, line := range r.Lines {
+		entry := jsonLine{
+			Asset:      line.Asset,
+			Quantity:   r.decimal(line.Quantity),
+			Acquired:   r.timestamp(line.Acquired),
+			Disposed:   r.timestamp(line.Disposed),
+			Term:       line.Term,
+			Proceeds:   r.decimal(line.Proceeds),
+			Cost:       r.decimal(line.Cost),
+			Gain:       r.decimal(line.Gain),
+			DisposalID: line.DisposalID,
+			LotID:      line.LotID,
+		}
+		if line.Term != UnknownTerm {
+			days := holdingDays(line.HoldingPeriod)
+			entry.HoldingDays = &days
+		}
+		document.Lines = append(document.Lines, entry)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// decimal formats an amount with the report's precision
+func (r *Report) decimal(value float64) string {
+	precision := r.Precision
+	if precision <= 0 {
+		precision = defaultPrecision
+	}
+	formatted := strconv.FormatFloat(value, 'f', precision, 64)
+	// Avoid "-0.00000000" for amounts that round to zero
+	if zero := strconv.FormatFloat(0, 'f', precision, 64); formatted == "-"+zero {
+		return zero
+	}
+	return formatted
+}
+
+// timestamp formats a time as RFC 3339 in UTC, or "" for the zero time
+func (r *Report) timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// holdingDays returns the whole days of a holding period
+func holdingDays(period time.Duration) int {
+	return int(period / (24 * time.Hour))
+}
@@ -0,0 +1,328 @@
+// Package taxreport produces capital gains reports from cost basis disposals
+//
+// Every disposal of a costbasis.Tracker is split into one line per lot it
+// consumed, so each line has one acquired date, one disposed date and one
+// holding period. Lines are classed as short or long term against a
+// configurable threshold rather than a particular jurisdiction's rule, and
+// valued in whatever currency the Generator's pricer quotes, e.g. EUR or USD.
+package taxreport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/costbasis"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+	"github.com/sidan-lab/sidan-binance-go/pnl"
+)
+
+// Term is the holding period class of a line
+type Term string
+
+const (
+	ShortTerm Term = "SHORT"
+	LongTerm  Term = "LONG"
+	// UnknownTerm is a disposal beyond the tracked lots, with no acquired date
+	UnknownTerm Term = "UNKNOWN"
+)
+
+const (
+	defaultLongTermThreshold = 365 * 24 * time.Hour
+	defaultPrecision         = 8
+)
+
+// Line is the disposal of one lot, or of the quantity no lot covered
+type Line struct {
+	Asset    string
+	Quantity float64
+	// Acquired is zero for UnknownTerm lines
+	Acquired time.Time
+	Disposed time.Time
+	// Proceeds is the disposal's proceeds in proportion to Quantity
+	Proceeds      float64
+	Cost          float64
+	Gain          float64
+	HoldingPeriod time.Duration
+	Term          Term
+	// DisposalID is the trade disposed in, LotID the transaction the lot was acquired in
+	DisposalID string
+	LotID      string
+}
+
+// Totals sums the lines of a report
+type Totals struct {
+	Proceeds        float64
+	Cost            float64
+	Gain            float64
+	ShortTermGain   float64
+	LongTermGain    float64
+	UnknownTermGain float64
+}
+
+// Report is the capital gains of the disposals in [Start, End)
+type Report struct {
+	Start             time.Time
+	End               time.Time
+	Currency          string
+	Method            costbasis.Method
+	LongTermThreshold time.Duration
+	// Precision is the number of decimals amounts are exported with
+	Precision int
+	Lines     []Line
+	Totals    Totals
+	// Withdrawn are the lots withdrawn and not returned by a deposit. They
+	// left without a disposal, so any of them sold or spent elsewhere still
+	// needs reporting.
+	Withdrawn []costbasis.Lot
+}
+
+// Generator builds reports by replaying deposits, withdrawals and trades
+// through a costbasis.Tracker
+type Generator struct {
+	// Method matches disposals to lots. Default costbasis.FIFO.
+	Method costbasis.Method
+	// LongTermThreshold is the holding period a lot must exceed to be long
+	// term. Default 365 days.
+	LongTermThreshold time.Duration
+	// Precision is the number of decimals amounts are exported with. Default 8.
+	Precision int
+
+	currency string
+	pricer   pnl.Pricer
+}
+
+// NewGenerator creates a generator valuing in currency with pricer
+// currency may be a fiat currency such as EUR as long as pricer can price
+// every traded asset in it, e.g. a pnl.KlinePricer through the EUR markets.
+func NewGenerator(currency string, pricer pnl.Pricer) *Generator {
+	return &Generator{
+		Method:            costbasis.FIFO,
+		LongTermThreshold: defaultLongTermThreshold,
+		Precision:         defaultPrecision,
+		currency:          strings.ToUpper(currency),
+		pricer:            pricer,
+	}
+}
+
+// Generate reports the disposals between start, inclusive, and end, exclusive
+//
+// deposits, withdrawals and trades should cover the whole history, not only
+// the period: lots acquired before start are needed to cost the disposals
+// within it, and a deposit returning an earlier withdrawal needs its lots.
+func (g *Generator) Generate(start, end time.Time, deposits []costbasis.Deposit, withdrawals []costbasis.Withdrawal, trades []costbasis.Trade) (*Report, error) {
+	tracker, err := costbasis.NewTracker(g.Method, g.currency, g.pricer)
+	if err != nil {
+		return nil, err
+	}
+	if err := tracker.Process(deposits, withdrawals, trades); err != nil {
+		return nil, err
+	}
+
+	var disposals []costbasis.Disposal
+	for _, disposal := range tracker.Disposals() {
+		if !disposal.Time.Before(start) && disposal.Time.Before(end) {
+			disposals = append(disposals, disposal)
+		}
+	}
+
+	report := &Report{
+		Start:             start,
+		End:               end,
+		Currency:          g.currency,
+		Method:            g.Method,
+		LongTermThreshold: g.LongTermThreshold,
+		Precision:         g.Precision,
+		Lines:             Lines(disposals, g.LongTermThreshold),
+		Withdrawn:         tracker.Withdrawn(),
+	}
+	report.Totals = Sum(report.Lines)
+	return report, nil
+}
+
+// Year reports the disposals of a UTC calendar year
+func (g *Generator) Year(year int, deposits []costbasis.Deposit, withdrawals []costbasis.Withdrawal, trades []costbasis.Trade) (*Report, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return g.Generate(start, start.AddDate(1, 0, 0), deposits, withdrawals, trades)
+}
+
+// Lines splits disposals into one line per matched lot, classing holding
+// periods longer than threshold as long term
+func Lines(disposals []costbasis.Disposal, threshold time.Duration) []Line {
+	var lines []Line
+	for _, disposal := range disposals {
+		if disposal.Quantity == 0 {
+			continue
+		}
+		share := func(quantity float64) float64 {
+			return disposal.Proceeds * quantity / disposal.Quantity
+		}
+
+		for _, match := range disposal.Matches {
+			line := Line{
+				Asset:         disposal.Asset,
+				Quantity:      match.Quantity,
+				Acquired:      match.Acquired,
+				Disposed:      disposal.Time,
+				Proceeds:      share(match.Quantity),
+				Cost:          match.Cost,
+				HoldingPeriod: disposal.Time.Sub(match.Acquired),
+				Term:          ShortTerm,
+				DisposalID:    disposal.ID,
+				LotID:         match.LotID,
+			}
+			line.Gain = line.Proceeds - line.Cost
+			if line.HoldingPeriod > threshold {
+				line.Term = LongTerm
+			}
+			lines = append(lines, line)
+		}
+
+		if disposal.Unmatched > 0 {
+			proceeds := share(disposal.Unmatched)
+			lines = append(lines, Line{
+				Asset:      disposal.Asset,
+				Quantity:   disposal.Unmatched,
+				Disposed:   disposal.Time,
+				Proceeds:   proceeds,
+				Gain:       proceeds,
+				Term:       UnknownTerm,
+				DisposalID: disposal.ID,
+			})
+		}
+	}
+	return lines
+}
+
+// Sum totals lines by term
+func Sum(lines []Line) Totals {
+	var totals Totals
+	for _, line := range lines {
+		totals.Proceeds += line.Proceeds
+		totals.Cost += line.Cost
+		totals.Gain += line.Gain
+		switch line.Term {
+		case ShortTerm:
+			totals.ShortTermGain += line.Gain
+		case LongTerm:
+			totals.LongTermGain += line.Gain
+		default:
+			totals.UnknownTermGain += line.Gain
+		}
+	}
+	return totals
+}
+
+// Acquisitions converts the deposits and incoming transfers of account in
+// events into cost basis deposits priced in currency at the daily close
+//
+// Transfers between wallets of the account are skipped: a transfer is an
+// acquisition only when its debit is in another account or not in events.
+// Every deposit is acquired at its price; use MarkReturns for those bringing
+// back coins of an earlier withdrawal from Outflows.
+func Acquisitions(events []ledger.Event, account, currency string, pricer pnl.Pricer) ([]costbasis.Deposit, error) {
+	currency = strings.ToUpper(currency)
+	internal := internalTransfers(events, account, false)
+
+	var deposits []costbasis.Deposit
+	for _, event := range events {
+		if event.Account != account || event.Amount <= 0 {
+			continue
+		}
+		switch event.Type {
+		case ledger.EventDeposit:
+		case ledger.EventTransfer:
+			if internal[event.ExternalID+"|"+event.Asset] {
+				continue
+			}
+		default:
+			continue
+		}
+
+		price := 1.0
+		if !strings.EqualFold(event.Asset, currency) {
+			var err error
+			price, err = pricer.Price(strings.ToUpper(event.Asset), currency, event.Time)
+			if err != nil {
+				return nil, fmt.Errorf("failed to price %s %s: %w", strings.ToLower(string(event.Type)), event.ExternalID, err)
+			}
+		}
+		deposits = append(deposits, costbasis.Deposit{
+			ID:       strings.ToLower(string(event.Type)) + ":" + event.ExternalID,
+			Asset:    event.Asset,
+			Quantity: event.Amount,
+			Price:    price,
+			Time:     event.Time,
+		})
+	}
+	return deposits, nil
+}
+
+// Outflows converts the withdrawals and outgoing transfers of account in
+// events into cost basis withdrawals
+//
+// Transfers between wallets of the account are skipped: a transfer is an
+// outflow only when its credit is in another account or not in events.
+func Outflows(events []ledger.Event, account string) []costbasis.Withdrawal {
+	internal := internalTransfers(events, account, true)
+
+	var withdrawals []costbasis.Withdrawal
+	for _, event := range events {
+		if event.Account != account || event.Amount >= 0 {
+			continue
+		}
+		switch event.Type {
+		case ledger.EventWithdrawal:
+		case ledger.EventTransfer:
+			if internal[event.ExternalID+"|"+event.Asset] {
+				continue
+			}
+		default:
+			continue
+		}
+
+		withdrawal := costbasis.Withdrawal{
+			ID:       strings.ToLower(string(event.Type)) + ":" + event.ExternalID,
+			Asset:    event.Asset,
+			Quantity: -event.Amount,
+			Time:     event.Time,
+		}
+		if strings.EqualFold(event.FeeAsset, event.Asset) {
+			withdrawal.Fee = event.Fee
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+	return withdrawals
+}
+
+// MarkReturns marks deposits as bringing back earlier withdrawals, e.g. coins
+// sent to an own wallet and deposited again, so they keep their cost and
+// acquired date. returns maps deposit IDs from Acquisitions to withdrawal IDs
+// from Outflows, e.g. "deposit:d1" to "withdrawal:w1".
+func MarkReturns(deposits []costbasis.Deposit, returns map[string]string) error {
+	marked := make(map[string]bool)
+	for i := range deposits {
+		if withdrawal, ok := returns[deposits[i].ID]; ok {
+			deposits[i].Returns = withdrawal
+			marked[deposits[i].ID] = true
+		}
+	}
+	for deposit := range returns {
+		if !marked[deposit] {
+			return fmt.Errorf("deposit %s to mark as a return is not in deposits", deposit)
+		}
+	}
+	return nil
+}
+
+// internalTransfers returns the transfers with a credit, or else a debit, in
+// account, keyed by ID and asset
+func internalTransfers(events []ledger.Event, account string, credits bool) map[string]bool {
+	internal := make(map[string]bool)
+	for _, event := range events {
+		if event.Type == ledger.EventTransfer && event.Account == account && (event.Amount > 0) == credits && event.Amount != 0 {
+			internal[event.ExternalID+"|"+event.Asset] = true
+		}
+	}
+	return internal
+}
@@ -0,0 +1,268 @@
+package taxreport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/costbasis"
+	"github.com/sidan-lab/sidan-binance-go/ledger"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// fixedPricer prices assets with fixed rates against any quote
+type fixedPricer map[string]float64
+
+func (p fixedPricer) Price(asset, quote string, day time.Time) (float64, error) {
+	price, ok := p[asset]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", asset)
+	}
+	return price, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+// history buys 1 BTC in 2022 and 1 BTC in 2023, then sells 2.5 BTC in 2023
+// and 0.1 BTC in 2024; 0.5 BTC of the first sale has no lot
+func history() ([]costbasis.Deposit, []costbasis.Trade) {
+	deposits := []costbasis.Deposit{{ID: "opening", Asset: "BTC", Quantity: 1, Price: 20000, Time: date(2022, time.March, 1)}}
+	trades := []costbasis.Trade{
+		{ID: "BTCEUR:1", Base: "BTC", Quote: "EUR", Buy: true, Quantity: 1, QuoteQuantity: 30000, Time: date(2023, time.January, 10)},
+		{ID: "BTCEUR:2", Base: "BTC", Quote: "EUR", Quantity: 2.5, QuoteQuantity: 100000, Commission: 100, CommissionAsset: "EUR", Time: date(2023, time.June, 1)},
+		{ID: "BTCEUR:3", Base: "BTC", Quote: "EUR", Quantity: 0.1, QuoteQuantity: 5000, Time: date(2024, time.February, 1)},
+	}
+	return deposits, trades
+}
+
+func TestGeneratorYear(t *testing.T) {
+	deposits, trades := history()
+	generator := NewGenerator("eur", nil)
+	report, err := generator.Year(2023, deposits, nil, trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Currency != "EUR" || report.Method != costbasis.FIFO || len(report.Lines) != 3 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	long, short, unknown := report.Lines[0], report.Lines[1], report.Lines[2]
+
+	// Net proceeds of 99900 for 2.5 BTC are split by quantity
+	if long.LotID != "opening" || long.Term != LongTerm || !almostEqual(long.Proceeds, 39960) || !almostEqual(long.Gain, 19960) || holdingDays(long.HoldingPeriod) != 457 {
+		t.Errorf("Unexpected long term line %+v", long)
+	}
+	if short.LotID != "BTCEUR:1" || short.Term != ShortTerm || !almostEqual(short.Cost, 30000) || !almostEqual(short.Gain, 9960) {
+		t.Errorf("Unexpected short term line %+v", short)
+	}
+	if unknown.Term != UnknownTerm || !unknown.Acquired.IsZero() || !almostEqual(unknown.Quantity, 0.5) || !almostEqual(unknown.Gain, 19980) {
+		t.Errorf("Unexpected unknown term line %+v", unknown)
+	}
+	if !almostEqual(report.Totals.Proceeds, 99900) || !almostEqual(report.Totals.LongTermGain, 19960) ||
+		!almostEqual(report.Totals.ShortTermGain, 9960) || !almostEqual(report.Totals.UnknownTermGain, 19980) {
+		t.Errorf("Unexpected totals %+v", report.Totals)
+	}
+
+	// A two year threshold makes every line short term
+	generator.LongTermThreshold = 2 * 365 * 24 * time.Hour
+	report, err = generator.Year(2023, deposits, nil, trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Lines[0].Term != ShortTerm || report.Totals.LongTermGain != 0 {
+		t.Errorf("Expected no long term lines, got %+v", report.Lines[0])
+	}
+
+	report, err = generator.Year(2024, deposits, nil, trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Lines) != 1 || report.Lines[0].Term != UnknownTerm || report.Lines[0].Cost != 0 {
+		t.Errorf("Expected a sale without lots in 2024, got %+v", report.Lines)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	deposits, trades := history()
+	report, err := NewGenerator("EUR", nil).Year(2023, deposits, nil, trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.Precision = 2
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || records[0][0] != "asset" || records[0][4] != "holding_days" {
+		t.Fatalf("Unexpected CSV %v", records)
+	}
+	expected := []string{"BTC", "1.00", "2022-03-01T12:00:00Z", "2023-06-01T12:00:00Z", "457", "LONG", "39960.00", "20000.00", "19960.00", "EUR", "BTCEUR:2", "opening"}
+	if fmt.Sprint(records[1]) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected first line %v", records[1])
+	}
+	if records[3][2] != "" || records[3][4] != "" || records[3][5] != "UNKNOWN" {
+		t.Errorf("Unexpected unknown term line %v", records[3])
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(JSONSchema), &schema); err != nil {
+		t.Fatalf("JSONSchema is not valid JSON: %v", err)
+	}
+
+	deposits, trades := history()
+	report, err := NewGenerator("EUR", nil).Year(2023, deposits, nil, trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		SchemaVersion         string  `json:"schemaVersion"`
+		Start                 string  `json:"start"`
+		Method                string  `json:"method"`
+		LongTermThresholdDays float64 `json:"longTermThresholdDays"`
+		Totals                struct {
+			Gain string `json:"gain"`
+		} `json:"totals"`
+		Lines []map[string]interface{} `json:"lines"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+	if document.SchemaVersion != SchemaVersion || document.Start != "2023-01-01T00:00:00Z" || document.Method != "FIFO" ||
+		document.LongTermThresholdDays != 365 || document.Totals.Gain != "49900.00000000" {
+		t.Errorf("Unexpected document %+v", document)
+	}
+	if len(document.Lines) != 3 || document.Lines[0]["holdingDays"] != float64(457) || document.Lines[0]["proceeds"] != "39960.00000000" {
+		t.Errorf("Unexpected first line %v", document.Lines[0])
+	}
+	if _, ok := document.Lines[2]["acquired"]; ok {
+		t.Errorf("Expected no acquired date on the unknown term line, got %v", document.Lines[2])
+	}
+}
+
+func TestAcquisitions(t *testing.T) {
+	at := date(2023, time.May, 1)
+	events := []ledger.Event{
+		{Type: ledger.EventDeposit, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: 0.5, ExternalID: "d1", Time: at},
+		// Wallet move within the account
+		{Type: ledger.EventTransfer, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: -0.1, ExternalID: "t1", Time: at},
+		{Type: ledger.EventTransfer, Account: "master", Wallet: ledger.WalletFunding, Asset: "BTC", Amount: 0.1, ExternalID: "t1", Time: at},
+		// Transfer in from a sub-account
+		{Type: ledger.EventTransfer, Account: "sub@test.com", Wallet: ledger.WalletSpot, Asset: "EUR", Amount: -100, ExternalID: "t2", Time: at},
+		{Type: ledger.EventTransfer, Account: "master", Wallet: ledger.WalletSpot, Asset: "EUR", Amount: 100, ExternalID: "t2", Time: at},
+		{Type: ledger.EventWithdrawal, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: -0.2, ExternalID: "w1", Time: at},
+		{Type: ledger.EventDeposit, Account: "other", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: 1, ExternalID: "d2", Time: at},
+	}
+
+	deposits, err := Acquisitions(events, "master", "EUR", fixedPricer{"BTC": 25000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 2 {
+		t.Fatalf("Expected the deposit and the incoming transfer, got %+v", deposits)
+	}
+	if deposits[0].ID != "deposit:d1" || deposits[0].Price != 25000 || deposits[1].ID != "transfer:t2" || deposits[1].Price != 1 {
+		t.Errorf("Unexpected acquisitions %+v", deposits)
+	}
+
+	if _, err := Acquisitions(events, "master", "EUR", fixedPricer{}); err == nil {
+		t.Error("Expected error for a deposit that cannot be priced")
+	}
+}
+
+func TestOutflows(t *testing.T) {
+	at := date(2023, time.May, 1)
+	events := []ledger.Event{
+		{Type: ledger.EventWithdrawal, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: -0.2, Fee: 0.0005, FeeAsset: "BTC", ExternalID: "w1", Time: at},
+		// Wallet move within the account
+		{Type: ledger.EventTransfer, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: -0.1, ExternalID: "t1", Time: at},
+		{Type: ledger.EventTransfer, Account: "master", Wallet: ledger.WalletFunding, Asset: "BTC", Amount: 0.1, ExternalID: "t1", Time: at},
+		// Transfer out to a sub-account
+		{Type: ledger.EventTransfer, Account: "master", Wallet: ledger.WalletSpot, Asset: "ETH", Amount: -2, ExternalID: "t2", Time: at},
+		{Type: ledger.EventTransfer, Account: "sub@test.com", Wallet: ledger.WalletSpot, Asset: "ETH", Amount: 2, ExternalID: "t2", Time: at},
+		{Type: ledger.EventTrade, Account: "master", Wallet: ledger.WalletSpot, Asset: "BTC", Amount: -0.3, ExternalID: "BTCEUR:1", Time: at},
+	}
+
+	withdrawals := Outflows(events, "master")
+	if len(withdrawals) != 2 {
+		t.Fatalf("Expected the withdrawal and the outgoing transfer, got %+v", withdrawals)
+	}
+	if withdrawals[0].ID != "withdrawal:w1" || withdrawals[0].Quantity != 0.2 || withdrawals[0].Fee != 0.0005 {
+		t.Errorf("Unexpected withdrawal %+v", withdrawals[0])
+	}
+	if withdrawals[1].ID != "transfer:t2" || withdrawals[1].Asset != "ETH" || withdrawals[1].Quantity != 2 {
+		t.Errorf("Unexpected transfer %+v", withdrawals[1])
+	}
+}
+
+func TestGeneratorWithdrawals(t *testing.T) {
+	deposits, trades := history()
+	// The 2022 BTC leaves for an own wallet and comes back before the sale
+	out := date(2022, time.December, 1)
+	events := []ledger.Event{
+		{Type: ledger.EventWithdrawal, Account: "master", Asset: "BTC", Amount: -1, ExternalID: "w1", Time: out},
+		{Type: ledger.EventDeposit, Account: "master", Asset: "BTC", Amount: 1, ExternalID: "d1", Time: out.AddDate(0, 1, 0)},
+	}
+	acquired, err := Acquisitions(events, "master", "EUR", fixedPricer{"BTC": 15000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unmarked, the deposit is a new lot at its price and the opening lot stays withdrawn
+	report, err := NewGenerator("EUR", nil).Year(2023, append(deposits, acquired...), Outflows(events, "master"), trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Lines[0].LotID != "deposit:d1" || !almostEqual(report.Lines[0].Cost, 15000) {
+		t.Errorf("Expected the sale to match the deposit at its price, got %+v", report.Lines)
+	}
+	if len(report.Withdrawn) != 1 || report.Withdrawn[0].ID != "opening" || report.Withdrawn[0].Quantity != 1 {
+		t.Errorf("Expected the opening lot withdrawn, got %+v", report.Withdrawn)
+	}
+
+	// Marked as a return, the deposit takes back the opening lot: same lines as without the round trip
+	if err := MarkReturns(acquired, map[string]string{"deposit:d1": "withdrawal:w1"}); err != nil {
+		t.Fatal(err)
+	}
+	report, err = NewGenerator("EUR", nil).Year(2023, append(deposits, acquired...), Outflows(events, "master"), trades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Lines) != 3 || report.Lines[0].LotID != "opening" || report.Lines[0].Cost != 20000 || report.Lines[2].Term != UnknownTerm {
+		t.Errorf("Unexpected lines %+v", report.Lines)
+	}
+	if len(report.Withdrawn) != 0 {
+		t.Errorf("Expected nothing left withdrawn, got %+v", report.Withdrawn)
+	}
+
+	if err := MarkReturns(acquired, map[string]string{"deposit:d9": "withdrawal:w1"}); err == nil {
+		t.Error("Expected error for an unknown deposit")
+	}
+}
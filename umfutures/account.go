@@ -0,0 +1,316 @@
+package umfutures
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Income types of IncomeHistory
+const (
+	IncomeTypeTransfer            = "TRANSFER"
+	IncomeTypeWelcomeBonus        = "WELCOME_BONUS"
+	IncomeTypeRealizedPnl         = "REALIZED_PNL"
+	IncomeTypeFundingFee          = "FUNDING_FEE"
+	IncomeTypeCommission          = "COMMISSION"
+	IncomeTypeInsuranceClear      = "INSURANCE_CLEAR"
+	IncomeTypeReferralKickback    = "REFERRAL_KICKBACK"
+	IncomeTypeCommissionRebate    = "COMMISSION_REBATE"
+	IncomeTypeAPIRebate           = "API_REBATE"
+	IncomeTypeContestReward       = "CONTEST_REWARD"
+	IncomeTypeCrossCollateral     = "CROSS_COLLATERAL_TRANSFER"
+	IncomeTypeInternalTransfer    = "INTERNAL_TRANSFER"
+	IncomeTypeAutoExchange        = "AUTO_EXCHANGE"
+	IncomeTypeDeliveredSettlement = "DELIVERED_SETTELMENT" // spelled as sent by the API
+)
+
+// Account is the futures account returned by Account
+type Account struct {
+	TotalInitialMargin          string            `json:"totalInitialMargin"`
+	TotalMaintMargin            string            `json:"totalMaintMargin"`
+	TotalWalletBalance          string            `json:"totalWalletBalance"`
+	TotalUnrealizedProfit       string            `json:"totalUnrealizedProfit"`
+	TotalMarginBalance          string            `json:"totalMarginBalance"`
+	TotalPositionInitialMargin  string            `json:"totalPositionInitialMargin"`
+	TotalOpenOrderInitialMargin string            `json:"totalOpenOrderInitialMargin"`
+	TotalCrossWalletBalance     string            `json:"totalCrossWalletBalance"`
+	TotalCrossUnPnl             string            `json:"totalCrossUnPnl"`
+	AvailableBalance            string            `json:"availableBalance"`
+	MaxWithdrawAmount           string            `json:"maxWithdrawAmount"`
+	Assets                      []AccountAsset    `json:"assets"`
+	Positions                   []AccountPosition `json:"positions"`
+}
+
+// AccountAsset is a margin asset of the futures account
+type AccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+// AccountPosition is an open position of the futures account
+type AccountPosition struct {
+	Symbol           string `json:"symbol"`
+	PositionSide     string `json:"positionSide"`
+	PositionAmt      string `json:"positionAmt"`
+	UnrealizedProfit string `json:"unrealizedProfit"`
+	IsolatedMargin   string `json:"isolatedMargin"`
+	Notional         string `json:"notional"`
+	IsolatedWallet   string `json:"isolatedWallet"`
+	InitialMargin    string `json:"initialMargin"`
+	MaintMargin      string `json:"maintMargin"`
+	UpdateTime       int64  `json:"updateTime"`
+}
+
+// Balance is a futures asset balance returned by Balance
+type Balance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	MaxWithdrawAmount  string `json:"maxWithdrawAmount"`
+	MarginAvailable    bool   `json:"marginAvailable"`
+	UpdateTime         int64  `json:"updateTime"`
+}
+
+// PositionRisk is a position returned by PositionRisk
+type PositionRisk struct {
+	Symbol                 string `json:"symbol"`
+	PositionSide           string `json:"positionSide"`
+	PositionAmt            string `json:"positionAmt"`
+	EntryPrice             string `json:"entryPrice"`
+	BreakEvenPrice         string `json:"breakEvenPrice"`
+	MarkPrice              string `json:"markPrice"`
+	UnRealizedProfit       string `json:"unRealizedProfit"`
+	LiquidationPrice       string `json:"liquidationPrice"`
+	IsolatedMargin         string `json:"isolatedMargin"`
+	Notional               string `json:"notional"`
+	MarginAsset            string `json:"marginAsset"`
+	IsolatedWallet         string `json:"isolatedWallet"`
+	InitialMargin          string `json:"initialMargin"`
+	MaintMargin            string `json:"maintMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	Adl                    int    `json:"adl"`
+	BidNotional            string `json:"bidNotional"`
+	AskNotional            string `json:"askNotional"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+// Leverage is the response of ChangeLeverage
+type Leverage struct {
+	Symbol           string `json:"symbol"`
+	Leverage         int    `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"`
+}
+
+// PositionMode is the response of GetPositionMode
+type PositionMode struct {
+	// DualSidePosition is true in hedge mode and false in one-way mode
+	DualSidePosition bool `json:"dualSidePosition"`
+}
+
+// Income is an income history record
+type Income struct {
+	Symbol     string `json:"symbol"`
+	IncomeType string `json:"incomeType"`
+	Income     string `json:"income"`
+	Asset      string `json:"asset"`
+	Info       string `json:"info"`
+	Time       int64  `json:"time"`
+	TranID     int64  `json:"tranId"`
+	TradeID    string `json:"tradeId"`
+}
+
+// Account queries current account information (USER_DATA)
+// Response can be decoded into Account with client.ParseResponse.
+//
+// Weight(IP): 5
+//
+// GET /fapi/v3/account
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Account-Information-V3
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) Account(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/fapi/v3/account", params)
+}
+
+// Balance queries futures account balances (USER_DATA)
+// Response can be decoded into []Balance with client.ParseResponse.
+//
+// Weight(IP): 5
+//
+// GET /fapi/v3/balance
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Futures-Account-Balance-V3
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) Balance(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/fapi/v3/balance", params)
+}
+
+// PositionRisk queries current position information (USER_DATA)
+// Response can be decoded into []PositionRisk with client.ParseResponse.
+//
+// Weight(IP): 5
+//
+// GET /fapi/v3/positionRisk
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Position-Information-V3
+//
+// Optional parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) PositionRisk(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/fapi/v3/positionRisk", params)
+}
+
+// ChangeLeverage changes the initial leverage of a symbol (TRADE)
+// Response can be decoded into Leverage with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// POST /fapi/v1/leverage
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Change-Initial-Leverage
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//   - leverage: Target initial leverage, 1 to 125
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) ChangeLeverage(symbol string, leverage int, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol":   symbol,
+		"leverage": leverage,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	params["leverage"] = leverage
+
+	return f.SignRequest("POST", "/fapi/v1/leverage", params)
+}
+
+// ChangeMarginType changes the margin type of a symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /fapi/v1/marginType
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Change-Margin-Type
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//   - marginType: MarginTypeIsolated or MarginTypeCrossed
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) ChangeMarginType(symbol, marginType string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameters(map[string]interface{}{
+		"symbol":     symbol,
+		"marginType": marginType,
+	}); err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	params["marginType"] = marginType
+
+	return f.SignRequest("POST", "/fapi/v1/marginType", params)
+}
+
+// ChangePositionMode switches between hedge mode and one-way mode on every symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// POST /fapi/v1/positionSide/dual
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Change-Position-Mode
+//
+// Parameters:
+//   - dualSidePosition: true for hedge mode, false for one-way mode
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) ChangePositionMode(dualSidePosition bool, params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["dualSidePosition"] = dualSidePosition
+	return f.SignRequest("POST", "/fapi/v1/positionSide/dual", params)
+}
+
+// GetPositionMode queries the position mode on every symbol (USER_DATA)
+// Response can be decoded into PositionMode with client.ParseResponse.
+//
+// Weight(IP): 30
+//
+// GET /fapi/v1/positionSide/dual
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Current-Position-Mode
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) GetPositionMode(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/fapi/v1/positionSide/dual", params)
+}
+
+// IncomeHistory queries income history (USER_DATA)
+// Response can be decoded into []Income with client.ParseResponse.
+//
+// Weight(IP): 30
+//
+// GET /fapi/v1/income
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Income-History
+//
+// If neither startTime nor endTime is sent, the last 7 days are returned.
+//
+// Optional parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//   - incomeType: One of the IncomeType constants
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds
+//   - page: Page number
+//   - limit: Default 100, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) IncomeHistory(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/fapi/v1/income", params)
+}
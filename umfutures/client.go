@@ -0,0 +1,22 @@
+package umfutures
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+// BaseURL is the base URL of the USDⓈ-M futures API
+const BaseURL = "https://fapi.binance.com"
+
+// UMFuturesClient handles USDⓈ-M futures API endpoints on fapi.binance.com
+type UMFuturesClient struct {
+	*client.Client
+}
+
+// NewUMFuturesClient creates a new UMFuturesClient
+func NewUMFuturesClient(apiKey, apiSecret string) *UMFuturesClient {
+	c := client.NewClient(apiKey, apiSecret)
+	c.BaseURL = BaseURL
+	return &UMFuturesClient{
+		Client: c,
+	}
+}
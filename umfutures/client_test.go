@@ -0,0 +1,140 @@
+package umfutures
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+func init() {
+	// Try to load .env file, but don't fail if it doesn't exist
+	// Environment variables will still work if set directly
+	_ = godotenv.Load("../.env")
+}
+
+func TestUMFuturesClientMethods(t *testing.T) {
+	client := NewUMFuturesClient("test_key", "test_secret")
+
+	if client == nil {
+		t.Fatal("Expected non-nil client")
+	}
+
+	if client.Client == nil {
+		t.Error("Expected embedded Client to be non-nil")
+	}
+
+	if client.BaseURL != BaseURL {
+		t.Errorf("Expected BaseURL %s, got %s", BaseURL, client.BaseURL)
+	}
+
+	t.Log("UMFuturesClient structure verification passed")
+}
+
+func TestUMFuturesRequiresParameters(t *testing.T) {
+	futures := NewUMFuturesClient("test_key", "test_secret")
+
+	if _, err := futures.ChangeLeverage("", 10, nil); err == nil {
+		t.Error("Expected error for empty symbol, got nil")
+	}
+	if _, err := futures.ChangeMarginType("BTCUSDT", "", nil); err == nil {
+		t.Error("Expected error for empty margin type, got nil")
+	}
+	if _, err := futures.CancelOrder("BTCUSDT", nil); err == nil {
+		t.Error("Expected error without orderId or origClientOrderId, got nil")
+	}
+	if _, err := futures.NewOrder(OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeMarket}, nil); err == nil {
+		t.Error("Expected error for invalid order, got nil")
+	}
+}
+
+func TestUMFuturesSignedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("signature") == "" || r.Header.Get("X-MBX-APIKEY") != "test_key" {
+			t.Errorf("Expected signed request, got %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/fapi/v1/leverage":
+			if query.Get("leverage") != "20" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":-4028,"msg":"Leverage 0 is not valid"}`))
+				return
+			}
+			w.Write([]byte(`{"leverage":20,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	futures := NewUMFuturesClient("test_key", "test_secret")
+	futures.BaseURL = server.URL
+
+	response, err := futures.ChangeLeverage("BTCUSDT", 20, nil)
+	if err != nil {
+		t.Fatalf("ChangeLeverage() error = %v", err)
+	}
+	var leverage Leverage
+	if err := client.ParseResponse(response, &leverage); err != nil || leverage.Leverage != 20 || leverage.MaxNotionalValue != "1000000" {
+		t.Errorf("Unexpected leverage %+v, error %v", leverage, err)
+	}
+
+	_, err = futures.ChangeLeverage("BTCUSDT", 0, nil)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != -4028 {
+		t.Errorf("Expected APIError with code -4028, got %v", err)
+	}
+}
+
+func TestAccount(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	futures := NewUMFuturesClient(apiKey, apiSecret)
+
+	response, err := futures.Account(nil)
+	if err != nil {
+		t.Fatalf("Account() error = %v", err)
+	}
+
+	var account Account
+	if err := client.ParseResponse(response, &account); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	prettyJSON, _ := json.MarshalIndent(account, "", "  ")
+	t.Logf("USDⓈ-M Futures Account Response:\n%s", string(prettyJSON))
+}
+
+func TestPremiumIndex(t *testing.T) {
+	apiKey := os.Getenv("BINANCE_API_KEY")
+	apiSecret := os.Getenv("BINANCE_SECRET_KEY")
+
+	if apiKey == "" || apiSecret == "" {
+		t.Skip("Skipping test: BINANCE_API_KEY and BINANCE_SECRET_KEY environment variables not set")
+	}
+
+	futures := NewUMFuturesClient(apiKey, apiSecret)
+
+	response, err := futures.PremiumIndex(map[string]interface{}{"symbol": "BTCUSDT"})
+	if err != nil {
+		t.Fatalf("PremiumIndex() error = %v", err)
+	}
+
+	var index PremiumIndex
+	if err := client.ParseResponse(response, &index); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	t.Logf("BTCUSDT mark price %s, funding rate %s", index.MarkPrice, index.LastFundingRate)
+}
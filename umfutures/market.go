@@ -0,0 +1,80 @@
+package umfutures
+
+// FundingRate is a funding rate history record
+type FundingRate struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+	MarkPrice   string `json:"markPrice"`
+}
+
+// PremiumIndex is the mark price and current funding rate of a symbol
+type PremiumIndex struct {
+	Symbol               string `json:"symbol"`
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"`
+	LastFundingRate      string `json:"lastFundingRate"`
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      int64  `json:"nextFundingTime"`
+	Time                 int64  `json:"time"`
+}
+
+// FundingInfo is the funding configuration of a symbol with adjusted funding parameters
+type FundingInfo struct {
+	Symbol                   string `json:"symbol"`
+	AdjustedFundingRateCap   string `json:"adjustedFundingRateCap"`
+	AdjustedFundingRateFloor string `json:"adjustedFundingRateFloor"`
+	FundingIntervalHours     int    `json:"fundingIntervalHours"`
+}
+
+// FundingRateHistory queries funding rate history (MARKET_DATA)
+// Response can be decoded into []FundingRate with client.ParseResponse.
+//
+// Weight(IP): shares a 500/5min/IP rate limit
+//
+// GET /fapi/v1/fundingRate
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Get-Funding-Rate-History
+//
+// Optional parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//   - startTime: Start time in milliseconds, inclusive
+//   - endTime: End time in milliseconds, inclusive
+//   - limit: Default 100, max 1000
+func (f *UMFuturesClient) FundingRateHistory(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.Query("GET", "/fapi/v1/fundingRate", params)
+}
+
+// PremiumIndex queries the mark price and funding rate of a symbol, or of all symbols (MARKET_DATA)
+// Response can be decoded into PremiumIndex, or []PremiumIndex without symbol, with client.ParseResponse.
+//
+// Weight(IP): 1 for a single symbol, 10 for all symbols
+//
+// GET /fapi/v1/premiumIndex
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Mark-Price
+//
+// Optional parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+func (f *UMFuturesClient) PremiumIndex(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.Query("GET", "/fapi/v1/premiumIndex", params)
+}
+
+// FundingInfo queries funding rate caps, floors and intervals of symbols with adjusted funding (MARKET_DATA)
+// Response can be decoded into []FundingInfo with client.ParseResponse.
+//
+// Weight(IP): shares a 500/5min/IP rate limit with FundingRateHistory
+//
+// GET /fapi/v1/fundingInfo
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Get-Funding-Rate-Info
+func (f *UMFuturesClient) FundingInfo() ([]byte, error) {
+	return f.Query("GET", "/fapi/v1/fundingInfo", nil)
+}
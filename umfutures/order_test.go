@@ -0,0 +1,73 @@
+package umfutures
+
+import "testing"
+
+func TestOrderRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   OrderRequest
+		wantErr bool
+	}{
+		{"limit", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001, Price: 60000}, false},
+		{"limit with price match", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001, PriceMatch: "QUEUE"}, false},
+		{"limit without price", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: 0.001}, true},
+		{"limit without timeInForce", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, Quantity: 0.001, Price: 60000}, true},
+		{"GTD without goodTillDate", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTD, Quantity: 0.001, Price: 60000}, true},
+		{"market", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, PositionSide: PositionSideShort, Type: OrderTypeMarket, Quantity: 0.001}, false},
+		{"market without quantity", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeMarket}, true},
+		{"stop", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStop, Quantity: 0.001, Price: 55000, StopPrice: 55500}, false},
+		{"stop without stopPrice", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStop, Quantity: 0.001, Price: 55000}, true},
+		{"stop market closing position", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStopMarket, StopPrice: 55000, ClosePosition: true}, false},
+		{"stop market without quantity", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStopMarket, StopPrice: 55000}, true},
+		{"close position with quantity", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTakeProfitMarket, StopPrice: 70000, Quantity: 1, ClosePosition: true}, true},
+		{"close position on limit", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Price: 70000, ClosePosition: true}, true},
+		{"trailing stop", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTrailingStopMarket, Quantity: 0.001, CallbackRate: 1}, false},
+		{"trailing stop without callbackRate", OrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTrailingStopMarket, Quantity: 0.001}, true},
+		{"missing symbol", OrderRequest{Side: SideBuy, Type: OrderTypeMarket, Quantity: 1}, true},
+		{"invalid side", OrderRequest{Symbol: "BTCUSDT", Side: "LONG", Type: OrderTypeMarket, Quantity: 1}, true},
+		{"invalid type", OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: "STOP_LOSS", Quantity: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrderRequestParams(t *testing.T) {
+	order := OrderRequest{
+		Symbol:        "BTCUSDT",
+		Side:          SideSell,
+		PositionSide:  PositionSideLong,
+		Type:          OrderTypeStopMarket,
+		StopPrice:     55000,
+		ClosePosition: true,
+		WorkingType:   WorkingTypeMarkPrice,
+		PriceProtect:  true,
+	}
+	params := order.Params(map[string]interface{}{"recvWindow": 5000})
+
+	expected := map[string]interface{}{
+		"symbol":        "BTCUSDT",
+		"side":          "SELL",
+		"positionSide":  "LONG",
+		"type":          "STOP_MARKET",
+		"stopPrice":     55000.0,
+		"closePosition": "true",
+		"workingType":   "MARK_PRICE",
+		"priceProtect":  "TRUE",
+		"recvWindow":    5000,
+	}
+	if len(params) != len(expected) {
+		t.Errorf("Expected %d params, got %v", len(expected), params)
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, params[key])
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package umfutures
+
+import (
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+// Order is a futures order as returned by NewOrder, CancelOrder, GetOrder,
+// GetOpenOrders and GetOrders
+type Order struct {
+	Symbol                  string `json:"symbol"`
+	OrderID                 int64  `json:"orderId"`
+	ClientOrderID           string `json:"clientOrderId"`
+	Price                   string `json:"price"`
+	AvgPrice                string `json:"avgPrice"`
+	OrigQty                 string `json:"origQty"`
+	ExecutedQty             string `json:"executedQty"`
+	CumQuote                string `json:"cumQuote"`
+	Status                  string `json:"status"`
+	TimeInForce             string `json:"timeInForce"`
+	Type                    string `json:"type"`
+	OrigType                string `json:"origType"`
+	Side                    string `json:"side"`
+	PositionSide            string `json:"positionSide"`
+	ReduceOnly              bool   `json:"reduceOnly"`
+	ClosePosition           bool   `json:"closePosition"`
+	StopPrice               string `json:"stopPrice"`
+	ActivatePrice           string `json:"activatePrice"`
+	PriceRate               string `json:"priceRate"`
+	WorkingType             string `json:"workingType"`
+	PriceProtect            bool   `json:"priceProtect"`
+	PriceMatch              string `json:"priceMatch"`
+	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
+	GoodTillDate            int64  `json:"goodTillDate"`
+	Time                    int64  `json:"time"`
+	UpdateTime              int64  `json:"updateTime"`
+}
+
+// Trade is an account trade returned by UserTrades
+type Trade struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	Side            string `json:"side"`
+	PositionSide    string `json:"positionSide"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	RealizedPnl     string `json:"realizedPnl"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Buyer           bool   `json:"buyer"`
+	Maker           bool   `json:"maker"`
+	Time            int64  `json:"time"`
+}
+
+// NewOrder sends in a new order (TRADE)
+// Response can be decoded into Order with client.ParseResponse.
+//
+// Weight: 1 on the order rate limits, 0 on IP
+//
+// POST /fapi/v1/order
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/New-Order
+//
+// Parameters:
+//   - order: The order to place, validated against its type
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) NewOrder(order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return f.SignRequest("POST", "/fapi/v1/order", order.Params(params))
+}
+
+// NewOrderTest tests new order creation without sending it into the matching engine (TRADE)
+//
+// Weight(IP): 0
+//
+// POST /fapi/v1/order/test
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Test-Order
+//
+// Parameters:
+//   - order: The order to test, validated against its type
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) NewOrderTest(order OrderRequest, params map[string]interface{}) ([]byte, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return f.SignRequest("POST", "/fapi/v1/order/test", order.Params(params))
+}
+
+// CancelOrder cancels an active order (TRADE)
+// Response can be decoded into Order with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// DELETE /fapi/v1/order
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Cancel-Order
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) CancelOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("DELETE", "/fapi/v1/order", params)
+}
+
+// CancelOpenOrders cancels all open orders on a symbol (TRADE)
+//
+// Weight(IP): 1
+//
+// DELETE /fapi/v1/allOpenOrders
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Cancel-All-Open-Orders
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) CancelOpenOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("DELETE", "/fapi/v1/allOpenOrders", params)
+}
+
+// GetOrder checks an order's status (USER_DATA)
+// Response can be decoded into Order with client.ParseResponse.
+//
+// Weight(IP): 1
+//
+// GET /fapi/v1/order
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Query-Order
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) GetOrder(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("GET", "/fapi/v1/order", params)
+}
+
+// GetOpenOrders queries open orders on a symbol, or on all symbols (USER_DATA)
+// Response can be decoded into []Order with client.ParseResponse.
+//
+// Weight(IP): 1 for a single symbol, 40 for all symbols
+//
+// GET /fapi/v1/openOrders
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Current-All-Open-Orders
+//
+// Optional parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) GetOpenOrders(params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	return f.SignRequest("GET", "/fapi/v1/openOrders", params)
+}
+
+// GetOrders queries all orders of a symbol: active, canceled, or filled (USER_DATA)
+// Response can be decoded into []Order with client.ParseResponse.
+//
+// Weight(IP): 5
+//
+// GET /fapi/v1/allOrders
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/All-Orders
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//
+// Optional parameters:
+//   - orderId: Return orders from this ID onwards
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds, at most 7 days after startTime
+//   - limit: Default 500, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) GetOrders(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("GET", "/fapi/v1/allOrders", params)
+}
+
+// UserTrades queries trades of the account on a symbol (USER_DATA)
+// Response can be decoded into []Trade with client.ParseResponse.
+//
+// Weight(IP): 5
+//
+// GET /fapi/v1/userTrades
+//
+// https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Account-Trade-List
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BTCUSDT
+//
+// Optional parameters:
+//   - orderId: Only return trades of this order
+//   - startTime: Start time in milliseconds
+//   - endTime: End time in milliseconds, at most 7 days after startTime
+//   - fromId: Trade ID to fetch from
+//   - limit: Default 500, max 1000
+//   - recvWindow: The value cannot be greater than 60000
+func (f *UMFuturesClient) UserTrades(symbol string, params map[string]interface{}) ([]byte, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["symbol"] = symbol
+	return f.SignRequest("GET", "/fapi/v1/userTrades", params)
+}
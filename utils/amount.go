@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseAmount parses a decimal string returned by the API, treating an empty string as 0
+func ParseAmount(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	return amount, nil
+}
@@ -0,0 +1,15 @@
+package utils
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	if amount, err := ParseAmount(""); err != nil || amount != 0 {
+		t.Errorf("Expected 0 for an empty amount, got %v, %v", amount, err)
+	}
+	if amount, err := ParseAmount("0.00050000"); err != nil || amount != 0.0005 {
+		t.Errorf("Expected 0.0005, got %v, %v", amount, err)
+	}
+	if _, err := ParseAmount("1,5"); err == nil {
+		t.Error("Expected error for an invalid amount")
+	}
+}
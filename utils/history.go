@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// CollectWindows fetches records between start and end, inclusive, in windows
+// of at most span, for history endpoints that bound the queried time range
+//
+// A window returning limit records may have been truncated, so it is split in
+// half and fetched again; a limit of 0 disables the split. A single millisecond
+// that still returns limit records is an error rather than a truncated result.
+func CollectWindows[T any](start, end time.Time, span time.Duration, limit int, fetch func(from, to time.Time) ([]T, error)) ([]T, error) {
+	var records []T
+	for from := start; !from.After(end); {
+		to := from.Add(span - time.Millisecond)
+		if to.After(end) {
+			to = end
+		}
+		batch, err := collectWindow(from, to, limit, fetch)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, batch...)
+		from = to.Add(time.Millisecond)
+	}
+	return records, nil
+}
+
+func collectWindow[T any](from, to time.Time, limit int, fetch func(from, to time.Time) ([]T, error)) ([]T, error) {
+	batch, err := fetch(from, to)
+	if err != nil || limit == 0 || len(batch) < limit {
+		return batch, err
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%d records at %s fill the page limit and may be truncated", len(batch), from.UTC().Format(time.RFC3339Nano))
+	}
+	// The API takes milliseconds, so halves must not split one
+	mid := from.Add((to.Sub(from) / 2).Truncate(time.Millisecond))
+	first, err := collectWindow(from, mid, limit, fetch)
+	if err != nil {
+		return nil, err
+	}
+	second, err := collectWindow(mid.Add(time.Millisecond), to, limit, fetch)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
+
+// CollectPages fetches numbered pages, starting at 1, until a page returns
+// fewer than size records
+func CollectPages[T any](size int, fetch func(page int) ([]T, error)) ([]T, error) {
+	var records []T
+	for page := 1; ; page++ {
+		batch, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, batch...)
+		if len(batch) < size {
+			return records, nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+func TestCollectSplitsFullWindows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10*day - time.Millisecond)
+	var windows int
+	records, err := CollectWindows(start, end, 4*day, 2, func(from, to time.Time) ([]int64, error) {
+		windows++
+		// One record per day start in the window, truncated to the limit like the API
+		var days []int64
+		for d := from.Truncate(day); !d.After(to) && len(days) < 2; d = d.Add(day) {
+			if !d.Before(from) {
+				days = append(days, d.UnixMilli())
+			}
+		}
+		return days, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 10 {
+		t.Errorf("Expected 10 records, got %d", len(records))
+	}
+	seen := make(map[int64]bool)
+	for _, record := range records {
+		if seen[record] {
+			t.Errorf("Duplicate record %d", record)
+		}
+		seen[record] = true
+	}
+	if windows <= 3 {
+		t.Errorf("Expected full windows to be split, got %d requests", windows)
+	}
+}
+
+func TestCollectFullMillisecond(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := CollectWindows(start, start.Add(time.Second), day, 2, func(from, to time.Time) ([]int64, error) {
+		// Every window is full, down to a single millisecond
+		return []int64{from.UnixMilli(), to.UnixMilli()}, nil
+	})
+	if err == nil {
+		t.Error("Expected error for a millisecond filling the page limit")
+	}
+}
+
+func TestCollectPages(t *testing.T) {
+	var requested []int
+	records, err := CollectPages(2, func(page int) ([]int, error) {
+		requested = append(requested, page)
+		if page < 3 {
+			return []int{page, page}, nil
+		}
+		return []int{page}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 || len(requested) != 3 {
+		t.Errorf("Expected 5 records from 3 pages, got %v from %v", records, requested)
+	}
+}
@@ -32,3 +32,13 @@ func CheckRequiredParameters(params map[string]interface{}) error {
 	}
 	return nil
 }
+
+// CheckOneOfParameters validates that at least one of the named parameters is set
+func CheckOneOfParameters(params map[string]interface{}, names ...string) error {
+	for _, name := range names {
+		if value, ok := params[name]; ok && CheckRequiredParameter(value, name) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("one of parameters %v is required", names)
+}
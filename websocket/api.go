@@ -0,0 +1,469 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+	"github.com/sidan-lab/sidan-binance-go/utils"
+)
+
+const (
+	// APIBaseURL is the endpoint of the spot WebSocket API
+	APIBaseURL = "wss://ws-api.binance.com:443/ws-api/v3"
+
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// RateLimit is the usage of a rate limit reported with a WebSocket API response
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+	Count         int    `json:"count"`
+}
+
+// APIResponse is a WebSocket API response
+// Result can be decoded with client.ParseResponse into the same types as the REST response.
+type APIResponse struct {
+	ID         string          `json:"id"`
+	Status     int             `json:"status"`
+	Result     json.RawMessage `json:"result"`
+	Error      json.RawMessage `json:"error"`
+	RateLimits []RateLimit     `json:"rateLimits"`
+}
+
+// APIClient sends requests over the WebSocket API on one persistent connection
+//
+// Requests are signed with the signer of the underlying client.Client and
+// responses are matched to requests by ID. After Logon, signed requests are
+// authenticated by the session instead; the session is logged on again
+// whenever the connection is re-established.
+type APIClient struct {
+	// BaseURL of the WebSocket API. Default APIBaseURL.
+	BaseURL string
+	// RequestTimeout is how long to wait for a response or to connect. Default 30s.
+	RequestTimeout time.Duration
+	// Options configures keepalive pings, reconnection and error reporting.
+	Options Options
+
+	client *client.Client
+	conn   *connection
+
+	mu            sync.Mutex
+	nextID        int64
+	pending       map[string]chan *APIResponse
+	rateLimits    []RateLimit
+	loggedOn      bool
+	sessionActive bool
+}
+
+// NewAPIClient creates a WebSocket API client authenticating with the API key and signer of c
+func NewAPIClient(c *client.Client) *APIClient {
+	return &APIClient{
+		BaseURL:        APIBaseURL,
+		RequestTimeout: defaultRequestTimeout,
+		client:         c,
+		pending:        make(map[string]chan *APIResponse),
+	}
+}
+
+// Connect opens the connection and waits until it is established
+func (a *APIClient) Connect() error {
+	if a.conn != nil {
+		return fmt.Errorf("websocket API client already connected")
+	}
+
+	ready := make(chan struct{})
+	var once sync.Once
+	a.conn = newConnection(a.Options, a.url, a.handle)
+	a.conn.onConnect = func() error {
+		once.Do(func() { close(ready) })
+		a.relogon()
+		return nil
+	}
+	a.conn.onDisconnect = a.failPending
+	go a.conn.run()
+
+	select {
+	case <-ready:
+		return nil
+	case <-time.After(a.RequestTimeout):
+		a.conn.close()
+		a.conn = nil
+		return fmt.Errorf("timed out connecting to %s", a.BaseURL)
+	}
+}
+
+// Close closes the connection
+func (a *APIClient) Close() {
+	if a.conn == nil {
+		return
+	}
+	a.conn.close()
+}
+
+// RateLimits returns the rate limit usage reported with the latest response
+func (a *APIClient) RateLimits() []RateLimit {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.rateLimits
+}
+
+// Request sends an unsigned request and waits for its response
+// A response with a non-200 status is returned together with a *client.APIError.
+func (a *APIClient) Request(method string, params map[string]interface{}) (*APIResponse, error) {
+	return a.send(method, encodeParams(params))
+}
+
+// SignedRequest sends a request signed with the client's signer and waits for its response
+// When the session is logged on, the request is authenticated by the session instead.
+func (a *APIClient) SignedRequest(method string, params map[string]interface{}) (*APIResponse, error) {
+	a.mu.Lock()
+	active := a.sessionActive
+	a.mu.Unlock()
+
+	if active {
+		params = withTimestamp(params)
+		return a.send(method, encodeParams(params))
+	}
+	signed, err := a.sign(params)
+	if err != nil {
+		return nil, err
+	}
+	return a.send(method, signed)
+}
+
+// Ping tests connectivity to the WebSocket API
+//
+// Weight(IP): 1
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/general-requests#test-connectivity
+func (a *APIClient) Ping() (*APIResponse, error) {
+	return a.Request("ping", nil)
+}
+
+// Time returns the current server time
+//
+// Weight(IP): 1
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/general-requests#check-server-time
+func (a *APIClient) Time() (*APIResponse, error) {
+	return a.Request("time", nil)
+}
+
+// Logon authenticates the connection with an Ed25519 key (SIGNED)
+// Subsequent signed requests are authenticated by the session without a signature.
+//
+// Weight(IP): 2
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/authentication-requests#log-in-with-api-key-signed
+func (a *APIClient) Logon() (*APIResponse, error) {
+	if _, ok := a.client.Signer.(*client.Ed25519Signer); !ok {
+		return nil, fmt.Errorf("session logon requires an Ed25519 signer")
+	}
+	response, err := a.logon()
+	if err != nil {
+		return response, err
+	}
+	a.mu.Lock()
+	a.loggedOn = true
+	a.mu.Unlock()
+	return response, nil
+}
+
+// Logout forgets the API key of the authenticated session
+//
+// Weight(IP): 2
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/authentication-requests#log-out-of-the-session
+func (a *APIClient) Logout() (*APIResponse, error) {
+	a.mu.Lock()
+	a.loggedOn = false
+	a.sessionActive = false
+	a.mu.Unlock()
+	return a.Request("session.logout", nil)
+}
+
+// SessionStatus returns the authentication status of the connection
+//
+// Weight(IP): 2
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/authentication-requests#query-session-status
+func (a *APIClient) SessionStatus() (*APIResponse, error) {
+	return a.Request("session.status", nil)
+}
+
+// PlaceOrder sends in a new order (TRADE)
+//
+// Weight(IP): 1
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/trading-requests#place-new-order-trade
+//
+// Parameters:
+//   - order: The order to place, validated against its type
+//
+// Optional parameters:
+//   - recvWindow: The value cannot be greater than 60000
+func (a *APIClient) PlaceOrder(order spot.OrderRequest, params map[string]interface{}) (*APIResponse, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return a.SignedRequest("order.place", order.Params(params))
+}
+
+// TestOrder tests order placement without sending the order into the matching engine (TRADE)
+//
+// Weight(IP): 1, or 20 with computeCommissionRates
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/trading-requests#test-new-order-trade
+//
+// Parameters:
+//   - order: The order to test, validated against its type
+//
+// Optional parameters:
+//   - computeCommissionRates: true or false
+//   - recvWindow: The value cannot be greater than 60000
+func (a *APIClient) TestOrder(order spot.OrderRequest, params map[string]interface{}) (*APIResponse, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	return a.SignedRequest("order.test", order.Params(params))
+}
+
+// CancelOrder cancels an active order (TRADE)
+//
+// Weight(IP): 1
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/trading-requests#cancel-order-trade
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BNBUSDT
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - newClientOrderId: New ID for the cancel, automatically generated if not sent
+//   - cancelRestrictions: ONLY_NEW or ONLY_PARTIALLY_FILLED
+//   - recvWindow: The value cannot be greater than 60000
+func (a *APIClient) CancelOrder(symbol string, params map[string]interface{}) (*APIResponse, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return a.SignedRequest("order.cancel", params)
+}
+
+// OrderStatus checks the execution status of an order (USER_DATA)
+//
+// Weight(IP): 4
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/account-requests#query-order-user_data
+//
+// Parameters:
+//   - symbol: Trading symbol, e.g. BNBUSDT
+//
+// Optional parameters (either orderId or origClientOrderId must be sent):
+//   - orderId: Order ID
+//   - origClientOrderId: Client order ID
+//   - recvWindow: The value cannot be greater than 60000
+func (a *APIClient) OrderStatus(symbol string, params map[string]interface{}) (*APIResponse, error) {
+	if err := utils.CheckRequiredParameter(symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckOneOfParameters(params, "orderId", "origClientOrderId"); err != nil {
+		return nil, err
+	}
+	params["symbol"] = symbol
+	return a.SignedRequest("order.status", params)
+}
+
+// OpenOrders returns the open orders of a symbol, or of all symbols (USER_DATA)
+//
+// Weight(IP): 6 for a single symbol, 80 for all symbols
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/account-requests#current-open-orders-user_data
+//
+// Optional parameters:
+//   - symbol: Trading symbol, e.g. BNBUSDT
+//   - recvWindow: The value cannot be greater than 60000
+func (a *APIClient) OpenOrders(params map[string]interface{}) (*APIResponse, error) {
+	return a.SignedRequest("openOrders.status", params)
+}
+
+// AccountStatus returns the account information (USER_DATA)
+//
+// Weight(IP): 20
+//
+// https://developers.binance.com/docs/binance-spot-api-docs/websocket-api/account-requests#account-information-user_data
+//
+// Optional parameters:
+//   - omitZeroBalances: true or false
+//   - recvWindow: The value cannot be greater than 60000
+func (a *APIClient) AccountStatus(params map[string]interface{}) (*APIResponse, error) {
+	return a.SignedRequest("account.status", params)
+}
+
+// logon sends session.logon and marks the session active on success
+func (a *APIClient) logon() (*APIResponse, error) {
+	params, err := a.sign(nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := a.send("session.logon", params)
+	if err != nil {
+		return response, err
+	}
+	a.mu.Lock()
+	a.sessionActive = true
+	a.mu.Unlock()
+	return response, nil
+}
+
+// relogon logs the session on again after a reconnect
+// It runs in the background since responses are only read once onConnect returns.
+func (a *APIClient) relogon() {
+	a.mu.Lock()
+	loggedOn := a.loggedOn
+	a.mu.Unlock()
+	if !loggedOn {
+		return
+	}
+	go func() {
+		if _, err := a.logon(); err != nil {
+			a.conn.reportError(fmt.Errorf("failed to log on session after reconnect: %w", err))
+		}
+	}()
+}
+
+// sign adds the API key, timestamp and signature to params
+// The signature covers the same query string as a REST request.
+func (a *APIClient) sign(params map[string]interface{}) (map[string]interface{}, error) {
+	params = withTimestamp(params)
+	params["apiKey"] = a.client.APIKey
+
+	signature, err := a.client.Sign(client.EncodeParams(params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	signed := encodeParams(params)
+	signed["signature"] = signature
+	return signed, nil
+}
+
+// send writes a request and waits for the response with the same ID
+func (a *APIClient) send(method string, params map[string]interface{}) (*APIResponse, error) {
+	if a.conn == nil {
+		return nil, fmt.Errorf("websocket API client not connected")
+	}
+
+	a.mu.Lock()
+	a.nextID++
+	id := strconv.FormatInt(a.nextID, 10)
+	result := make(chan *APIResponse, 1)
+	a.pending[id] = result
+	a.mu.Unlock()
+
+	defer func() {
+		a.mu.Lock()
+		delete(a.pending, id)
+		a.mu.Unlock()
+	}()
+
+	request := map[string]interface{}{
+		"id":     id,
+		"method": method,
+	}
+	if len(params) > 0 {
+		request["params"] = params
+	}
+	if err := a.conn.writeJSON(request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response, ok := <-result:
+		if !ok {
+			return nil, fmt.Errorf("connection lost waiting for %s response", method)
+		}
+		if response.Status != 200 {
+			return response, client.NewAPIError(response.Status, response.Error)
+		}
+		return response, nil
+	case <-time.After(a.RequestTimeout):
+		return nil, fmt.Errorf("%s timed out after %s", method, a.RequestTimeout)
+	case <-a.conn.closed:
+		return nil, ErrClosed
+	}
+}
+
+func (a *APIClient) url() (string, error) {
+	return a.BaseURL, nil
+}
+
+// handle matches a response to its pending request and records the rate limits
+func (a *APIClient) handle(message []byte) {
+	var response APIResponse
+	if err := json.Unmarshal(message, &response); err != nil {
+		a.conn.reportError(fmt.Errorf("failed to decode API response: %w", err))
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if response.RateLimits != nil {
+		a.rateLimits = response.RateLimits
+	}
+	if result, ok := a.pending[response.ID]; ok {
+		delete(a.pending, response.ID)
+		result <- &response
+	}
+}
+
+// failPending fails every request awaiting a response on the dropped connection
+func (a *APIClient) failPending() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sessionActive = false
+	for id, result := range a.pending {
+		close(result)
+		delete(a.pending, id)
+	}
+}
+
+// withTimestamp returns a copy of params with the current timestamp
+func withTimestamp(params map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(params)+1)
+	maps.Copy(out, params)
+	out["timestamp"] = time.Now().UnixMilli()
+	return out
+}
+
+// encodeParams converts params to JSON request parameters
+// Empty values are dropped and floats are formatted as in the signed query string.
+func encodeParams(params map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v != "" {
+				out[key] = v
+			}
+		case float64:
+			out[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			out[key] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,227 @@
+package websocket
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/sidan-lab/sidan-binance-go/client"
+	"github.com/sidan-lab/sidan-binance-go/spot"
+)
+
+// apiRequest is a WebSocket API request as received by the test server
+type apiRequest struct {
+	ID     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// param returns a parameter formatted as in the signed query string
+func (r apiRequest) param(key string) string {
+	if value, ok := r.Params[key]; ok {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// payload rebuilds the signed query string from the request parameters
+func (r apiRequest) payload() string {
+	values := url.Values{}
+	for key := range r.Params {
+		if key != "signature" {
+			values.Set(key, r.param(key))
+		}
+	}
+	return values.Encode()
+}
+
+// newAPITestServer starts a WebSocket API server that answers requests with respond
+func newAPITestServer(t *testing.T, respond func(conn *gorilla.Conn, request apiRequest) (status int, result interface{})) (*APIClient, *[]apiRequest, *sync.Mutex) {
+	var mu sync.Mutex
+	var requests []apiRequest
+
+	server := newTestServer(t, func(conn *gorilla.Conn, r *http.Request) {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request apiRequest
+			decoder := json.NewDecoder(bytes.NewReader(message))
+			decoder.UseNumber()
+			if err := decoder.Decode(&request); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+				return
+			}
+			mu.Lock()
+			requests = append(requests, request)
+			count := len(requests)
+			status, result := respond(conn, request)
+			mu.Unlock()
+			if status == 0 {
+				return
+			}
+			response := map[string]interface{}{
+				"id":     request.ID,
+				"status": status,
+				"rateLimits": []map[string]interface{}{
+					{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 6000, "count": count},
+				},
+			}
+			if status == 200 {
+				response["result"] = result
+			} else {
+				response["error"] = result
+			}
+			conn.WriteJSON(response)
+		}
+	})
+
+	api := NewAPIClient(client.NewClient("test-api-key", "test-secret"))
+	api.BaseURL = wsURL(server)
+	api.Options.MinBackoff = 10 * time.Millisecond
+	return api, &requests, &mu
+}
+
+func TestAPIClientSignedRequest(t *testing.T) {
+	api, requests, mu := newAPITestServer(t, func(conn *gorilla.Conn, request apiRequest) (int, interface{}) {
+		if request.Method == "order.place" {
+			signature, _ := client.HMACSigner{Secret: "test-secret"}.Sign(request.payload())
+			if request.param("signature") != signature {
+				return 400, map[string]interface{}{"code": -1022, "msg": "Signature for this request is not valid."}
+			}
+			if request.param("quantity") == "0.00000001" {
+				return 400, map[string]interface{}{"code": -1013, "msg": "Filter failure: LOT_SIZE"}
+			}
+		}
+		return 200, map[string]interface{}{}
+	})
+	if err := api.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer api.Close()
+
+	if _, err := api.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if limits := api.RateLimits(); len(limits) != 1 || limits[0].RateLimitType != "REQUEST_WEIGHT" || limits[0].Count != 1 {
+		t.Errorf("Unexpected rate limits: %+v", limits)
+	}
+
+	order := spot.OrderRequest{Symbol: "BTCUSDT", Side: spot.SideBuy, Type: spot.OrderTypeMarket, Quantity: 0.001}
+	response, err := api.PlaceOrder(order, nil)
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+	if response.Status != 200 || response.RateLimits[0].Count != 2 {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+	mu.Lock()
+	placed := (*requests)[1]
+	mu.Unlock()
+	if placed.param("apiKey") != "test-api-key" || placed.param("quantity") != "0.001" || placed.param("timestamp") == "" {
+		t.Errorf("Unexpected signed params: %v", placed.Params)
+	}
+
+	order.Quantity = 0.00000001
+	_, err = api.PlaceOrder(order, nil)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 || apiErr.Code != -1013 {
+		t.Errorf("Expected APIError with code -1013, got %v", err)
+	}
+
+	if _, err := api.CancelOrder("BTCUSDT", nil); err == nil {
+		t.Error("Expected error without orderId or origClientOrderId, got nil")
+	}
+}
+
+func TestAPIClientSessionLogon(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var authenticated bool
+	var logons int
+	api, requests, mu := newAPITestServer(t, func(conn *gorilla.Conn, request apiRequest) (int, interface{}) {
+		switch request.Method {
+		case "session.logon":
+			authenticated = false
+			signature, _ := base64.StdEncoding.DecodeString(request.param("signature"))
+			if !ed25519.Verify(publicKey, []byte(request.payload()), signature) {
+				return 401, map[string]interface{}{"code": -1022, "msg": "Signature for this request is not valid."}
+			}
+			authenticated = true
+			logons++
+		case "account.status":
+			if !authenticated {
+				return 401, map[string]interface{}{"code": -2015, "msg": "Invalid API-key, IP, or permissions for action."}
+			}
+			if _, ok := request.Params["signature"]; ok {
+				t.Error("Expected session authenticated request without signature")
+			}
+		case "drop":
+			// Drop the connection without answering
+			return 0, nil
+		}
+		return 200, map[string]interface{}{}
+	})
+	if err := api.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer api.Close()
+
+	if _, err := api.Logon(); err == nil {
+		t.Error("Expected error logging on with an HMAC signer, got nil")
+	}
+	api.client.Signer = &client.Ed25519Signer{PrivateKey: privateKey}
+
+	if _, err := api.Logon(); err != nil {
+		t.Fatalf("Logon() error = %v", err)
+	}
+	if _, err := api.AccountStatus(nil); err != nil {
+		t.Fatalf("AccountStatus() error = %v", err)
+	}
+
+	// Pending requests fail when the connection drops, and the session is logged on again
+	if _, err := api.Request("drop", nil); err == nil {
+		t.Error("Expected error for request on a dropped connection, got nil")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		api.mu.Lock()
+		active := api.sessionActive
+		api.mu.Unlock()
+		if active {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for session logon after reconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	if logons != 2 {
+		t.Errorf("Expected 2 logons, got %d", logons)
+	}
+	mu.Unlock()
+
+	if _, err := api.AccountStatus(nil); err != nil {
+		t.Errorf("AccountStatus() after reconnect error = %v", err)
+	}
+	mu.Lock()
+	if last := (*requests)[len(*requests)-1]; last.Method != "account.status" {
+		t.Errorf("Unexpected last request %s", last.Method)
+	}
+	mu.Unlock()
+}
@@ -0,0 +1,294 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+)
+
+const (
+	// StreamBaseURL is the base URL of the spot market and user data streams
+	StreamBaseURL = "wss://stream.binance.com:9443"
+
+	defaultPingInterval = 30 * time.Second
+	defaultReadTimeout  = 90 * time.Second
+	defaultMinBackoff   = time.Second
+	defaultMaxBackoff   = time.Minute
+)
+
+// ErrClosed is returned when using a connection that has been closed
+var ErrClosed = errors.New("websocket connection closed")
+
+// errNotConnected is returned when writing while the connection is being re-established
+var errNotConnected = errors.New("websocket not connected")
+
+// Options configures connection keepalive and reconnection
+// Zero values fall back to the defaults.
+type Options struct {
+	// PingInterval is how often a ping frame is sent to the server. Default 30s.
+	PingInterval time.Duration
+	// ReadTimeout is how long the connection may stay silent, including
+	// pings and pongs, before it is considered dead. Default 90s.
+	ReadTimeout time.Duration
+	// MinBackoff is the first reconnect delay, doubled after each failed attempt. Default 1s.
+	MinBackoff time.Duration
+	// MaxBackoff caps the reconnect delay. Default 1m.
+	MaxBackoff time.Duration
+	// OnError receives connection, decoding and keepalive errors. Optional.
+	OnError func(err error)
+}
+
+// connection is a WebSocket connection that reconnects with exponential backoff
+type connection struct {
+	opts Options
+
+	// url resolves the endpoint before every (re)connect
+	url func() (string, error)
+	// onConnect runs after every (re)connect, e.g. to resubscribe
+	onConnect func() error
+	// onMessage receives every text message
+	onMessage func(message []byte)
+	// onDisconnect runs after an established connection drops, e.g. to fail pending requests
+	onDisconnect func()
+
+	mu      sync.Mutex
+	writeMu sync.Mutex
+	conn    *gorilla.Conn
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	done      chan struct{}
+}
+
+func newConnection(opts Options, url func() (string, error), onMessage func([]byte)) *connection {
+	if opts.PingInterval <= 0 {
+		opts.PingInterval = defaultPingInterval
+	}
+	if opts.ReadTimeout <= 0 {
+		opts.ReadTimeout = defaultReadTimeout
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(defaultMaxBackoff, opts.MinBackoff)
+	}
+	return &connection{
+		opts:      opts,
+		url:       url,
+		onMessage: onMessage,
+		closed:    make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// run connects and reads until close is called, reconnecting whenever the connection drops
+func (c *connection) run() {
+	defer close(c.done)
+
+	backoff := c.opts.MinBackoff
+	for !c.isClosed() {
+		conn, err := c.connect()
+		if err != nil {
+			c.reportError(err)
+			if !c.sleep(backoff) {
+				return
+			}
+			backoff = min(backoff*2, c.opts.MaxBackoff)
+			continue
+		}
+		backoff = c.opts.MinBackoff
+
+		err = c.read(conn)
+
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+		conn.Close()
+		if c.onDisconnect != nil {
+			c.onDisconnect()
+		}
+
+		if c.isClosed() {
+			return
+		}
+		c.reportError(err)
+		if !c.sleep(backoff) {
+			return
+		}
+	}
+}
+
+// connect dials the endpoint and runs the onConnect hook
+func (c *connection) connect() (*gorilla.Conn, error) {
+	url, err := c.url()
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := gorilla.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+
+	if c.isClosed() {
+		conn.Close()
+		return nil, ErrClosed
+	}
+	if c.onConnect != nil {
+		if err := c.onConnect(); err != nil {
+			c.mu.Lock()
+			c.conn = nil
+			c.mu.Unlock()
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// read delivers messages until the connection fails, answering server pings and sending our own
+func (c *connection) read(conn *gorilla.Conn) error {
+	extend := func() {
+		conn.SetReadDeadline(time.Now().Add(c.opts.ReadTimeout))
+	}
+	extend()
+	conn.SetPongHandler(func(string) error {
+		extend()
+		return nil
+	})
+	conn.SetPingHandler(func(data string) error {
+		extend()
+		err := conn.WriteControl(gorilla.PongMessage, []byte(data), time.Now().Add(10*time.Second))
+		if errors.Is(err, gorilla.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
+	stopPing := make(chan struct{})
+	defer close(stopPing)
+	go func() {
+		ticker := time.NewTicker(c.opts.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := conn.WriteControl(gorilla.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+					return
+				}
+			case <-stopPing:
+				return
+			}
+		}
+	}()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		extend()
+		c.onMessage(message)
+	}
+}
+
+// writeJSON sends v as a JSON text message on the current connection
+func (c *connection) writeJSON(v interface{}) error {
+	if c.isClosed() {
+		return ErrClosed
+	}
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return errNotConnected
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteJSON(v)
+}
+
+// reconnect drops the current connection so the run loop dials again
+func (c *connection) reconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// close stops reconnecting, closes the connection and waits for the run loop to exit
+func (c *connection) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.mu.Lock()
+		if c.conn != nil {
+			c.conn.WriteControl(gorilla.CloseMessage,
+				gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""),
+				time.Now().Add(time.Second))
+			c.conn.Close()
+		}
+		c.mu.Unlock()
+	})
+	<-c.done
+}
+
+func (c *connection) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleep waits for d, returning false if the connection is closed meanwhile
+func (c *connection) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+func (c *connection) reportError(err error) {
+	if err != nil && c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// EventHeader is the common header of stream events
+type EventHeader struct {
+	Event string `json:"e"`
+	Time  int64  `json:"E"`
+}
+
+// EventType returns the event type, e.g. "executionReport"
+func (h EventHeader) EventType() string {
+	return h.Event
+}
+
+// EventTime returns the event time in milliseconds
+func (h EventHeader) EventTime() int64 {
+	return h.Time
+}
+
+// decode unmarshals message into a new T
+func decode[T any](message []byte) (*T, error) {
+	v := new(T)
+	if err := json.Unmarshal(message, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,441 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const subscribeTimeout = 10 * time.Second
+
+// TradeStream returns the raw trade stream name of a symbol
+func TradeStream(symbol string) string {
+	return strings.ToLower(symbol) + "@trade"
+}
+
+// AggTradeStream returns the aggregate trade stream name of a symbol
+func AggTradeStream(symbol string) string {
+	return strings.ToLower(symbol) + "@aggTrade"
+}
+
+// KlineStream returns the kline stream name of a symbol, e.g. interval "1m" or "1h"
+func KlineStream(symbol, interval string) string {
+	return strings.ToLower(symbol) + "@kline_" + interval
+}
+
+// MiniTickerStream returns the 24hr rolling window mini-ticker stream name of a symbol
+func MiniTickerStream(symbol string) string {
+	return strings.ToLower(symbol) + "@miniTicker"
+}
+
+// TickerStream returns the 24hr rolling window ticker stream name of a symbol
+func TickerStream(symbol string) string {
+	return strings.ToLower(symbol) + "@ticker"
+}
+
+// BookTickerStream returns the best bid/ask stream name of a symbol
+func BookTickerStream(symbol string) string {
+	return strings.ToLower(symbol) + "@bookTicker"
+}
+
+// PartialDepthStream returns the top levels (5, 10 or 20) depth stream name of a symbol,
+// updated every 100ms when fast is set and every 1000ms otherwise
+func PartialDepthStream(symbol string, levels int, fast bool) string {
+	stream := strings.ToLower(symbol) + "@depth" + strconv.Itoa(levels)
+	if fast {
+		stream += "@100ms"
+	}
+	return stream
+}
+
+// DiffDepthStream returns the diff depth stream name of a symbol,
+// updated every 100ms when fast is set and every 1000ms otherwise
+func DiffDepthStream(symbol string, fast bool) string {
+	stream := strings.ToLower(symbol) + "@depth"
+	if fast {
+		stream += "@100ms"
+	}
+	return stream
+}
+
+// MarketEvent is a message received on a market stream
+//
+// Data is one of *Trade, *AggTrade, *Kline, *MiniTicker, *Ticker,
+// *BookTicker, *PartialDepth or *DepthUpdate, or json.RawMessage for
+// streams without a typed decoder.
+type MarketEvent struct {
+	Stream string
+	Data   interface{}
+}
+
+// Trade is a raw trade stream event
+type Trade struct {
+	EventHeader
+	Symbol       string `json:"s"`
+	TradeID      int64  `json:"t"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	TradeTime    int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+	Ignore       bool   `json:"M"`
+}
+
+// AggTrade is an aggregate trade stream event
+type AggTrade struct {
+	EventHeader
+	Symbol           string `json:"s"`
+	AggregateTradeID int64  `json:"a"`
+	Price            string `json:"p"`
+	Quantity         string `json:"q"`
+	FirstTradeID     int64  `json:"f"`
+	LastTradeID      int64  `json:"l"`
+	TradeTime        int64  `json:"T"`
+	IsBuyerMaker     bool   `json:"m"`
+	Ignore           bool   `json:"M"`
+}
+
+// Kline is a kline/candlestick stream event
+type Kline struct {
+	EventHeader
+	Symbol string    `json:"s"`
+	Kline  KlineData `json:"k"`
+}
+
+// KlineData is the candlestick of a Kline event
+type KlineData struct {
+	StartTime           int64  `json:"t"`
+	CloseTime           int64  `json:"T"`
+	Symbol              string `json:"s"`
+	Interval            string `json:"i"`
+	FirstTradeID        int64  `json:"f"`
+	LastTradeID         int64  `json:"L"`
+	Open                string `json:"o"`
+	Close               string `json:"c"`
+	High                string `json:"h"`
+	Low                 string `json:"l"`
+	Volume              string `json:"v"`
+	TradeCount          int64  `json:"n"`
+	IsClosed            bool   `json:"x"`
+	QuoteVolume         string `json:"q"`
+	TakerBuyBaseVolume  string `json:"V"`
+	TakerBuyQuoteVolume string `json:"Q"`
+	Ignore              string `json:"B"`
+}
+
+// MiniTicker is a 24hr rolling window mini-ticker stream event
+type MiniTicker struct {
+	EventHeader
+	Symbol      string `json:"s"`
+	Close       string `json:"c"`
+	Open        string `json:"o"`
+	High        string `json:"h"`
+	Low         string `json:"l"`
+	Volume      string `json:"v"`
+	QuoteVolume string `json:"q"`
+}
+
+// Ticker is a 24hr rolling window ticker stream event
+type Ticker struct {
+	EventHeader
+	Symbol             string `json:"s"`
+	PriceChange        string `json:"p"`
+	PriceChangePercent string `json:"P"`
+	WeightedAvgPrice   string `json:"w"`
+	PrevClose          string `json:"x"`
+	LastPrice          string `json:"c"`
+	LastQuantity       string `json:"Q"`
+	BidPrice           string `json:"b"`
+	BidQuantity        string `json:"B"`
+	AskPrice           string `json:"a"`
+	AskQuantity        string `json:"A"`
+	Open               string `json:"o"`
+	High               string `json:"h"`
+	Low                string `json:"l"`
+	Volume             string `json:"v"`
+	QuoteVolume        string `json:"q"`
+	OpenTime           int64  `json:"O"`
+	CloseTime          int64  `json:"C"`
+	FirstTradeID       int64  `json:"F"`
+	LastTradeID        int64  `json:"L"`
+	TradeCount         int64  `json:"n"`
+}
+
+// BookTicker is a best bid/ask stream event
+type BookTicker struct {
+	UpdateID    int64  `json:"u"`
+	Symbol      string `json:"s"`
+	BidPrice    string `json:"b"`
+	BidQuantity string `json:"B"`
+	AskPrice    string `json:"a"`
+	AskQuantity string `json:"A"`
+}
+
+// PriceLevel is a [price, quantity] order book level
+type PriceLevel [2]string
+
+// Price returns the price of the level
+func (p PriceLevel) Price() string {
+	return p[0]
+}
+
+// Quantity returns the quantity of the level
+func (p PriceLevel) Quantity() string {
+	return p[1]
+}
+
+// PartialDepth is a top levels depth stream event
+type PartialDepth struct {
+	LastUpdateID int64        `json:"lastUpdateId"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+}
+
+// DepthUpdate is a diff depth stream event
+type DepthUpdate struct {
+	EventHeader
+	Symbol        string       `json:"s"`
+	FirstUpdateID int64        `json:"U"`
+	FinalUpdateID int64        `json:"u"`
+	Bids          []PriceLevel `json:"b"`
+	Asks          []PriceLevel `json:"a"`
+}
+
+// MarketStream is a combined market data stream with dynamic subscriptions
+//
+// The connection is kept alive with pings and is re-established with backoff
+// when it drops, resubscribing to every stream subscribed at that time.
+type MarketStream struct {
+	// BaseURL of the stream endpoint. Default StreamBaseURL.
+	BaseURL string
+	// Options configures keepalive pings, reconnection and error reporting.
+	Options Options
+
+	events    chan MarketEvent
+	conn      *connection
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	streams []string
+	nextID  int64
+	pending map[int64]chan error
+}
+
+// NewMarketStream creates a market data stream client
+func NewMarketStream() *MarketStream {
+	return &MarketStream{
+		BaseURL: StreamBaseURL,
+		pending: make(map[int64]chan error),
+	}
+}
+
+// Start connects to the combined stream of the given streams and returns the
+// channel on which events are delivered. The channel is closed by Close.
+func (m *MarketStream) Start(streams ...string) (<-chan MarketEvent, error) {
+	if m.conn != nil {
+		return nil, fmt.Errorf("market stream already started")
+	}
+
+	m.mu.Lock()
+	m.streams = appendUnique(m.streams, streams...)
+	m.mu.Unlock()
+
+	m.events = make(chan MarketEvent, 100)
+	m.conn = newConnection(m.Options, m.url, m.handle)
+	go m.conn.run()
+	return m.events, nil
+}
+
+// Close stops the stream and closes the events channel
+// Calling it again has no effect.
+func (m *MarketStream) Close() {
+	if m.conn == nil {
+		return
+	}
+	m.closeOnce.Do(func() {
+		m.conn.close()
+		close(m.events)
+	})
+}
+
+// Streams returns the currently subscribed streams
+func (m *MarketStream) Streams() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.streams)
+}
+
+// Subscribe subscribes to streams and waits for the server to confirm
+// While the connection is being re-established the streams are subscribed on reconnect.
+func (m *MarketStream) Subscribe(streams ...string) error {
+	m.mu.Lock()
+	var added []string
+	for _, s := range streams {
+		if !slices.Contains(m.streams, s) {
+			added = append(added, s)
+		}
+	}
+	m.streams = append(m.streams, added...)
+	m.mu.Unlock()
+
+	err := m.send("SUBSCRIBE", added)
+	if err != nil && err != errNotConnected {
+		m.removeStreams(added)
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from streams and waits for the server to confirm
+// While the connection is being re-established the streams are dropped on reconnect.
+func (m *MarketStream) Unsubscribe(streams ...string) error {
+	err := m.send("UNSUBSCRIBE", streams)
+	if err != nil && err != errNotConnected {
+		return err
+	}
+	m.removeStreams(streams)
+	return nil
+}
+
+func (m *MarketStream) removeStreams(streams []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.streams = slices.DeleteFunc(m.streams, func(s string) bool {
+		return slices.Contains(streams, s)
+	})
+}
+
+// send issues a SUBSCRIBE or UNSUBSCRIBE request and waits for its response
+func (m *MarketStream) send(method string, streams []string) error {
+	if m.conn == nil {
+		return fmt.Errorf("market stream not started")
+	}
+	if len(streams) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	m.nextID++
+	id := m.nextID
+	result := make(chan error, 1)
+	m.pending[id] = result
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+	}()
+
+	if err := m.conn.writeJSON(map[string]interface{}{
+		"method": method,
+		"params": streams,
+		"id":     id,
+	}); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(subscribeTimeout):
+		return fmt.Errorf("%s timed out after %s", method, subscribeTimeout)
+	case <-m.conn.closed:
+		return ErrClosed
+	}
+}
+
+// url returns the combined stream URL of the current subscriptions
+func (m *MarketStream) url() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.streams) == 0 {
+		return m.BaseURL + "/stream", nil
+	}
+	return m.BaseURL + "/stream?streams=" + strings.Join(m.streams, "/"), nil
+}
+
+// handle dispatches subscription responses and decodes stream events
+func (m *MarketStream) handle(message []byte) {
+	var envelope struct {
+		Stream string          `json:"stream"`
+		Data   json.RawMessage `json:"data"`
+		ID     *int64          `json:"id"`
+		Error  *struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		m.conn.reportError(fmt.Errorf("failed to decode market event: %w", err))
+		return
+	}
+
+	if envelope.ID != nil {
+		var err error
+		if envelope.Error != nil {
+			err = fmt.Errorf("stream request failed (code %d): %s", envelope.Error.Code, envelope.Error.Msg)
+		}
+		m.mu.Lock()
+		result, ok := m.pending[*envelope.ID]
+		m.mu.Unlock()
+		if ok {
+			result <- err
+		}
+		return
+	}
+	if envelope.Stream == "" {
+		return
+	}
+
+	data, err := decodeMarketData(envelope.Stream, envelope.Data)
+	if err != nil {
+		m.conn.reportError(fmt.Errorf("failed to decode %s event: %w", envelope.Stream, err))
+		return
+	}
+
+	select {
+	case m.events <- MarketEvent{Stream: envelope.Stream, Data: data}:
+	case <-m.conn.closed:
+	}
+}
+
+// decodeMarketData decodes the payload of a stream based on its name
+func decodeMarketData(stream string, data json.RawMessage) (interface{}, error) {
+	_, kind, _ := strings.Cut(stream, "@")
+	kind, _, _ = strings.Cut(kind, "@")
+
+	switch {
+	case kind == "trade":
+		return decode[Trade](data)
+	case kind == "aggTrade":
+		return decode[AggTrade](data)
+	case strings.HasPrefix(kind, "kline_"):
+		return decode[Kline](data)
+	case kind == "miniTicker":
+		return decode[MiniTicker](data)
+	case kind == "ticker":
+		return decode[Ticker](data)
+	case kind == "bookTicker":
+		return decode[BookTicker](data)
+	case kind == "depth":
+		return decode[DepthUpdate](data)
+	case strings.HasPrefix(kind, "depth"):
+		return decode[PartialDepth](data)
+	default:
+		return data, nil
+	}
+}
+
+// appendUnique appends values not already present in list
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,198 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+)
+
+func TestStreamNames(t *testing.T) {
+	tests := map[string]string{
+		TradeStream("BTCUSDT"):                 "btcusdt@trade",
+		AggTradeStream("BTCUSDT"):              "btcusdt@aggTrade",
+		KlineStream("BTCUSDT", "1m"):           "btcusdt@kline_1m",
+		MiniTickerStream("BTCUSDT"):            "btcusdt@miniTicker",
+		TickerStream("BTCUSDT"):                "btcusdt@ticker",
+		BookTickerStream("BTCUSDT"):            "btcusdt@bookTicker",
+		PartialDepthStream("BTCUSDT", 5, true): "btcusdt@depth5@100ms",
+		DiffDepthStream("BTCUSDT", false):      "btcusdt@depth",
+	}
+	for got, want := range tests {
+		if got != want {
+			t.Errorf("Expected stream name %s, got %s", want, got)
+		}
+	}
+}
+
+func TestDecodeMarketData(t *testing.T) {
+	tests := []struct {
+		stream string
+		data   string
+		check  func(t *testing.T, v interface{})
+	}{
+		{"btcusdt@trade", `{"e":"trade","E":1672515782136,"s":"BNBBTC","t":12345,"p":"0.001","q":"100","T":1672515782136,"m":true,"M":true}`, func(t *testing.T, v interface{}) {
+			trade := v.(*Trade)
+			if trade.TradeID != 12345 || trade.TradeTime != 1672515782136 || !trade.IsBuyerMaker {
+				t.Errorf("Unexpected trade: %+v", trade)
+			}
+		}},
+		{"btcusdt@aggTrade", `{"e":"aggTrade","E":1672515782136,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":1672515782136,"m":true,"M":true}`, func(t *testing.T, v interface{}) {
+			trade := v.(*AggTrade)
+			if trade.AggregateTradeID != 12345 || trade.FirstTradeID != 100 || trade.LastTradeID != 105 {
+				t.Errorf("Unexpected aggregate trade: %+v", trade)
+			}
+		}},
+		{"btcusdt@kline_1m", `{"e":"kline","E":1672515782136,"s":"BNBBTC","k":{"t":1672515780000,"T":1672515839999,"s":"BNBBTC","i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,"x":false,"q":"1.0000","V":"500","Q":"0.500","B":"123456"}}`, func(t *testing.T, v interface{}) {
+			kline := v.(*Kline)
+			if kline.Kline.StartTime != 1672515780000 || kline.Kline.CloseTime != 1672515839999 || kline.Kline.Low != "0.0015" || kline.Kline.LastTradeID != 200 || kline.Kline.TakerBuyBaseVolume != "500" {
+				t.Errorf("Unexpected kline: %+v", kline.Kline)
+			}
+		}},
+		{"btcusdt@miniTicker", `{"e":"24hrMiniTicker","E":1672515782136,"s":"BNBBTC","c":"0.0025","o":"0.0010","h":"0.0025","l":"0.0010","v":"10000","q":"18"}`, func(t *testing.T, v interface{}) {
+			ticker := v.(*MiniTicker)
+			if ticker.Close != "0.0025" || ticker.QuoteVolume != "18" {
+				t.Errorf("Unexpected mini ticker: %+v", ticker)
+			}
+		}},
+		{"btcusdt@ticker", `{"e":"24hrTicker","E":1672515782136,"s":"BNBBTC","p":"0.0015","P":"250.00","w":"0.0018","x":"0.0009","c":"0.0025","Q":"10","b":"0.0024","B":"10","a":"0.0026","A":"100","o":"0.0010","h":"0.0025","l":"0.0010","v":"10000","q":"18","O":0,"C":86400000,"F":0,"L":18150,"n":18151}`, func(t *testing.T, v interface{}) {
+			ticker := v.(*Ticker)
+			if ticker.PriceChange != "0.0015" || ticker.PriceChangePercent != "250.00" || ticker.BidQuantity != "10" || ticker.AskQuantity != "100" || ticker.CloseTime != 86400000 {
+				t.Errorf("Unexpected ticker: %+v", ticker)
+			}
+		}},
+		{"btcusdt@bookTicker", `{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}`, func(t *testing.T, v interface{}) {
+			ticker := v.(*BookTicker)
+			if ticker.UpdateID != 400900217 || ticker.BidQuantity != "31.21000000" || ticker.AskPrice != "25.36520000" {
+				t.Errorf("Unexpected book ticker: %+v", ticker)
+			}
+		}},
+		{"btcusdt@depth5@100ms", `{"lastUpdateId":160,"bids":[["0.0024","10"]],"asks":[["0.0026","100"]]}`, func(t *testing.T, v interface{}) {
+			depth := v.(*PartialDepth)
+			if depth.LastUpdateID != 160 || depth.Bids[0].Price() != "0.0024" || depth.Asks[0].Quantity() != "100" {
+				t.Errorf("Unexpected partial depth: %+v", depth)
+			}
+		}},
+		{"btcusdt@depth@100ms", `{"e":"depthUpdate","E":1672515782136,"s":"BNBBTC","U":157,"u":160,"b":[["0.0024","10"]],"a":[["0.0026","100"]]}`, func(t *testing.T, v interface{}) {
+			depth := v.(*DepthUpdate)
+			if depth.FirstUpdateID != 157 || depth.FinalUpdateID != 160 || len(depth.Bids) != 1 {
+				t.Errorf("Unexpected depth update: %+v", depth)
+			}
+		}},
+		{"!ticker_1h@arr", `[]`, func(t *testing.T, v interface{}) {
+			if _, ok := v.(json.RawMessage); !ok {
+				t.Errorf("Expected json.RawMessage for unknown stream, got %T", v)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.stream, func(t *testing.T) {
+			v, err := decodeMarketData(tt.stream, json.RawMessage(tt.data))
+			if err != nil {
+				t.Fatalf("decodeMarketData() error = %v", err)
+			}
+			tt.check(t, v)
+		})
+	}
+}
+
+func TestMarketStream(t *testing.T) {
+	var mu sync.Mutex
+	var requestedStreams []string
+	connections := 0
+
+	server := newTestServer(t, func(conn *gorilla.Conn, r *http.Request) {
+		mu.Lock()
+		connections++
+		n := connections
+		requestedStreams = append(requestedStreams, r.URL.Query().Get("streams"))
+		mu.Unlock()
+
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"stream":"btcusdt@trade","data":{"e":"trade","E":1,"s":"BTCUSDT","t":1,"p":"1","q":"1","T":1,"m":true,"M":true}}`))
+		for {
+			var request struct {
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+				ID     int64    `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			if request.Params[0] == "invalid" {
+				conn.WriteJSON(map[string]interface{}{"error": map[string]interface{}{"code": 2, "msg": "Invalid request"}, "id": request.ID})
+				continue
+			}
+			conn.WriteJSON(map[string]interface{}{"result": nil, "id": request.ID})
+			if n == 1 && request.Method == "SUBSCRIBE" {
+				// Drop the connection to force a reconnect with the new subscriptions
+				return
+			}
+		}
+	})
+
+	stream := NewMarketStream()
+	stream.BaseURL = wsURL(server)
+	stream.Options.MinBackoff = 10 * time.Millisecond
+
+	events, err := stream.Start(TradeStream("BTCUSDT"))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer stream.Close()
+
+	next := func() MarketEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for event")
+		}
+		return MarketEvent{}
+	}
+
+	if event := next(); event.Stream != "btcusdt@trade" {
+		t.Errorf("Unexpected event stream %s", event.Stream)
+	} else if _, ok := event.Data.(*Trade); !ok {
+		t.Errorf("Expected *Trade, got %T", event.Data)
+	}
+
+	if err := stream.Subscribe(BookTickerStream("BTCUSDT")); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// The reconnected stream resubscribes to both streams
+	next()
+	mu.Lock()
+	if len(requestedStreams) != 2 || requestedStreams[1] != "btcusdt@trade/btcusdt@bookTicker" {
+		t.Errorf("Expected reconnect with both streams, got %v", requestedStreams)
+	}
+	mu.Unlock()
+
+	if err := stream.Subscribe("invalid"); err == nil {
+		t.Error("Expected error for rejected subscription, got nil")
+	}
+	if err := stream.Unsubscribe(BookTickerStream("BTCUSDT")); err != nil {
+		t.Errorf("Unsubscribe() error = %v", err)
+	}
+	if streams := stream.Streams(); len(streams) != 1 || streams[0] != "btcusdt@trade" {
+		t.Errorf("Unexpected streams after unsubscribe: %v", streams)
+	}
+
+	// Closing twice, as the deferred Close does, must not panic
+	stream.Close()
+	for {
+		select {
+		case _, ok := <-events:
+			if ok {
+				continue
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("Expected events channel to be closed")
+		}
+		return
+	}
+}
@@ -0,0 +1,303 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sidan-lab/sidan-binance-go/client"
+)
+
+const defaultKeepaliveInterval = 30 * time.Minute
+
+// ListenKeyService creates, keeps alive and closes user data stream listen keys
+// spot.UserDataStreamClient implements it for the spot account.
+type ListenKeyService interface {
+	NewListenKey() ([]byte, error)
+	RenewListenKey(listenKey string) ([]byte, error)
+	CloseListenKey(listenKey string) ([]byte, error)
+}
+
+// UserDataEvent is an event received on the user data stream:
+// *OutboundAccountPosition, *BalanceUpdate, *ExecutionReport or *ListStatus
+type UserDataEvent interface {
+	EventType() string
+	EventTime() int64
+}
+
+// OutboundAccountPosition is sent when an account balance has changed
+type OutboundAccountPosition struct {
+	EventHeader
+	LastUpdateTime int64            `json:"u"`
+	Balances       []AccountBalance `json:"B"`
+}
+
+// AccountBalance is a balance in an OutboundAccountPosition event
+type AccountBalance struct {
+	Asset  string `json:"a"`
+	Free   string `json:"f"`
+	Locked string `json:"l"`
+}
+
+// BalanceUpdate is sent on deposits, withdrawals and transfers between accounts
+type BalanceUpdate struct {
+	EventHeader
+	Asset        string `json:"a"`
+	BalanceDelta string `json:"d"`
+	ClearTime    int64  `json:"T"`
+}
+
+// ExecutionReport is sent when an order is created, updated or filled
+//
+// Several keys differ only by case (e.g. "c" and "C"), so every key is
+// declared to stop encoding/json from matching the wrong field.
+type ExecutionReport struct {
+	EventHeader
+	Symbol                   string `json:"s"`
+	ClientOrderID            string `json:"c"`
+	Side                     string `json:"S"`
+	OrderType                string `json:"o"`
+	TimeInForce              string `json:"f"`
+	Quantity                 string `json:"q"`
+	Price                    string `json:"p"`
+	StopPrice                string `json:"P"`
+	IcebergQuantity          string `json:"F"`
+	OrderListID              int64  `json:"g"`
+	OrigClientOrderID        string `json:"C"`
+	ExecutionType            string `json:"x"`
+	OrderStatus              string `json:"X"`
+	RejectReason             string `json:"r"`
+	OrderID                  int64  `json:"i"`
+	LastExecutedQuantity     string `json:"l"`
+	CumulativeFilledQuantity string `json:"z"`
+	LastExecutedPrice        string `json:"L"`
+	Commission               string `json:"n"`
+	CommissionAsset          string `json:"N"`
+	TransactionTime          int64  `json:"T"`
+	TradeID                  int64  `json:"t"`
+	PreventedMatchID         int64  `json:"v"`
+	ExecutionID              int64  `json:"I"`
+	IsOnBook                 bool   `json:"w"`
+	IsMaker                  bool   `json:"m"`
+	Ignore                   bool   `json:"M"`
+	CreationTime             int64  `json:"O"`
+	CumulativeQuoteQuantity  string `json:"Z"`
+	LastQuoteQuantity        string `json:"Y"`
+	QuoteOrderQuantity       string `json:"Q"`
+	WorkingTime              int64  `json:"W"`
+	SelfTradePreventionMode  string `json:"V"`
+	TrailingDelta            int64  `json:"d"`
+	TrailingTime             int64  `json:"D"`
+	StrategyID               int64  `json:"j"`
+	StrategyType             int64  `json:"J"`
+	TradeGroupID             int64  `json:"u"`
+	CounterOrderID           int64  `json:"U"`
+	PreventedQuantity        string `json:"A"`
+	LastPreventedQuantity    string `json:"B"`
+}
+
+// ListStatus is sent with an ExecutionReport whenever an order belongs to an order list
+type ListStatus struct {
+	EventHeader
+	Symbol            string            `json:"s"`
+	OrderListID       int64             `json:"g"`
+	ContingencyType   string            `json:"c"`
+	ListStatusType    string            `json:"l"`
+	ListOrderStatus   string            `json:"L"`
+	ListRejectReason  string            `json:"r"`
+	ListClientOrderID string            `json:"C"`
+	TransactionTime   int64             `json:"T"`
+	Orders            []ListStatusOrder `json:"O"`
+}
+
+// ListStatusOrder is an order of a ListStatus event
+type ListStatusOrder struct {
+	Symbol        string `json:"s"`
+	OrderID       int64  `json:"i"`
+	ClientOrderID string `json:"c"`
+}
+
+// UserDataStream consumes the user data stream of a listen key
+//
+// The listen key is kept alive every KeepaliveInterval. When the connection
+// drops or the listen key expires, the stream reconnects with backoff and
+// requests a listen key again, which resubscribes to the same account.
+type UserDataStream struct {
+	// BaseURL of the stream endpoint. Default StreamBaseURL.
+	BaseURL string
+	// KeepaliveInterval between listen key renewals. Default 30 minutes.
+	KeepaliveInterval time.Duration
+	// Options configures keepalive pings, reconnection and error reporting.
+	Options Options
+
+	service   ListenKeyService
+	events    chan UserDataEvent
+	conn      *connection
+	closeOnce sync.Once
+
+	mu        sync.Mutex
+	listenKey string
+	fresh     bool
+}
+
+// NewUserDataStream creates a user data stream using service to manage listen keys
+func NewUserDataStream(service ListenKeyService) *UserDataStream {
+	return &UserDataStream{
+		BaseURL:           StreamBaseURL,
+		KeepaliveInterval: defaultKeepaliveInterval,
+		service:           service,
+	}
+}
+
+// Start obtains a listen key, connects and returns the channel on which events are delivered
+// The channel is closed by Close. Events must be consumed for the stream to make progress.
+func (s *UserDataStream) Start() (<-chan UserDataEvent, error) {
+	if s.conn != nil {
+		return nil, fmt.Errorf("user data stream already started")
+	}
+	if _, err := s.refreshListenKey(); err != nil {
+		return nil, err
+	}
+	s.fresh = true
+
+	s.events = make(chan UserDataEvent, 100)
+	s.conn = newConnection(s.Options, s.url, s.handle)
+	go s.conn.run()
+	go s.keepalive()
+	return s.events, nil
+}
+
+// Close stops the stream, closes the listen key and the events channel
+// Calling it again has no effect.
+func (s *UserDataStream) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	var err error
+	s.closeOnce.Do(func() {
+		s.conn.close()
+		close(s.events)
+
+		s.mu.Lock()
+		listenKey := s.listenKey
+		s.listenKey = ""
+		s.mu.Unlock()
+
+		if listenKey != "" {
+			_, err = s.service.CloseListenKey(listenKey)
+		}
+	})
+	return err
+}
+
+// ListenKey returns the listen key currently in use
+func (s *UserDataStream) ListenKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listenKey
+}
+
+// url returns the stream URL, requesting the listen key again on every
+// reconnect so an expired key is replaced
+func (s *UserDataStream) url() (string, error) {
+	s.mu.Lock()
+	listenKey, fresh := s.listenKey, s.fresh
+	s.fresh = false
+	s.mu.Unlock()
+
+	if !fresh || listenKey == "" {
+		var err error
+		if listenKey, err = s.refreshListenKey(); err != nil {
+			return "", err
+		}
+	}
+	return s.BaseURL + "/ws/" + listenKey, nil
+}
+
+func (s *UserDataStream) refreshListenKey() (string, error) {
+	response, err := s.service.NewListenKey()
+	if err != nil {
+		return "", err
+	}
+
+	var key struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := client.ParseResponse(response, &key); err != nil {
+		return "", fmt.Errorf("failed to parse listen key: %w", err)
+	}
+	if key.ListenKey == "" {
+		return "", fmt.Errorf("empty listen key in response: %s", string(response))
+	}
+
+	s.mu.Lock()
+	s.listenKey = key.ListenKey
+	s.mu.Unlock()
+	return key.ListenKey, nil
+}
+
+// expireListenKey forgets the listen key and reconnects with a new one
+func (s *UserDataStream) expireListenKey() {
+	s.mu.Lock()
+	s.listenKey = ""
+	s.mu.Unlock()
+	s.conn.reconnect()
+}
+
+// keepalive renews the listen key until the stream is closed
+func (s *UserDataStream) keepalive() {
+	ticker := time.NewTicker(s.KeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			listenKey := s.ListenKey()
+			if listenKey == "" {
+				continue
+			}
+			if _, err := s.service.RenewListenKey(listenKey); err != nil {
+				s.conn.reportError(fmt.Errorf("failed to renew listen key: %w", err))
+				s.expireListenKey()
+			}
+		case <-s.conn.closed:
+			return
+		}
+	}
+}
+
+// handle decodes a stream message and delivers it on the events channel
+func (s *UserDataStream) handle(message []byte) {
+	var header EventHeader
+	if err := json.Unmarshal(message, &header); err != nil {
+		s.conn.reportError(fmt.Errorf("failed to decode user data event: %w", err))
+		return
+	}
+
+	var event UserDataEvent
+	var err error
+	switch header.Event {
+	case "outboundAccountPosition":
+		event, err = decode[OutboundAccountPosition](message)
+	case "balanceUpdate":
+		event, err = decode[BalanceUpdate](message)
+	case "executionReport":
+		event, err = decode[ExecutionReport](message)
+	case "listStatus":
+		event, err = decode[ListStatus](message)
+	case "listenKeyExpired":
+		s.expireListenKey()
+		return
+	default:
+		return
+	}
+	if err != nil {
+		s.conn.reportError(fmt.Errorf("failed to decode %s event: %w", header.Event, err))
+		return
+	}
+
+	select {
+	case s.events <- event:
+	case <-s.conn.closed:
+	}
+}
@@ -0,0 +1,194 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+)
+
+// fakeListenKeyService counts listen key calls
+type fakeListenKeyService struct {
+	mu      sync.Mutex
+	created int
+	renewed int
+	closed  []string
+}
+
+func (f *fakeListenKeyService) NewListenKey() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created++
+	return []byte(`{"listenKey":"test-listen-key"}`), nil
+}
+
+func (f *fakeListenKeyService) RenewListenKey(listenKey string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.renewed++
+	return []byte(`{}`), nil
+}
+
+func (f *fakeListenKeyService) CloseListenKey(listenKey string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = append(f.closed, listenKey)
+	return []byte(`{}`), nil
+}
+
+// newTestServer starts a WebSocket server that runs handler for every connection
+func newTestServer(t *testing.T, handler func(conn *gorilla.Conn, r *http.Request)) *httptest.Server {
+	upgrader := gorilla.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Failed to upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		handler(conn, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestUserDataStream(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	server := newTestServer(t, func(conn *gorilla.Conn, r *http.Request) {
+		if r.URL.Path != "/ws/test-listen-key" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		mu.Lock()
+		connections++
+		n := connections
+		mu.Unlock()
+
+		if n == 1 {
+			// Drop the first connection to force a reconnect
+			conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"balanceUpdate","E":1573200697110,"a":"BTC","d":"100.00000000","T":1573200697068}`))
+			return
+		}
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000","g":-1,"C":"","x":"NEW","X":"NEW","r":"NONE","i":4293153,"l":"0.00000000","z":"0.00000000","L":"0.00000000","n":"0","N":null,"T":1499405658657,"t":-1,"I":8641984,"w":true,"m":false,"M":false,"O":1499405658657,"Z":"0.00000000","Y":"0.00000000","Q":"0.00000000","W":1499405658657,"V":"NONE"}`))
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,"B":[{"a":"ETH","f":"10000.000000","l":"0.000000"}]}`))
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"listStatus","E":1564035303637,"s":"ETHBTC","g":2,"c":"OCO","l":"EXEC_STARTED","L":"EXECUTING","r":"NONE","C":"F4QN4G8DlFATFlIUQ0cjdD","T":1564035303625,"O":[{"s":"ETHBTC","i":17,"c":"AJYsMjErWJesZvqlJCTUgL"}]}`))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+
+	service := &fakeListenKeyService{}
+	stream := NewUserDataStream(service)
+	stream.BaseURL = wsURL(server)
+	stream.KeepaliveInterval = 50 * time.Millisecond
+	stream.Options.MinBackoff = 10 * time.Millisecond
+
+	events, err := stream.Start()
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	var received []UserDataEvent
+	timeout := time.After(5 * time.Second)
+	for len(received) < 4 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("Timed out after receiving %d events", len(received))
+		}
+	}
+
+	balance, ok := received[0].(*BalanceUpdate)
+	if !ok || balance.Asset != "BTC" || balance.BalanceDelta != "100.00000000" {
+		t.Errorf("Unexpected balance update: %+v", received[0])
+	}
+
+	report, ok := received[1].(*ExecutionReport)
+	if !ok {
+		t.Fatalf("Expected *ExecutionReport, got %T", received[1])
+	}
+	if report.OrderID != 4293153 || report.ExecutionID != 8641984 || report.ClientOrderID != "mUvoqJxFIILMdfAW5iGSOW" || report.OrigClientOrderID != "" {
+		t.Errorf("Execution report decoded with case-colliding keys: %+v", report)
+	}
+	if report.EventType() != "executionReport" || report.EventTime() != 1499405658658 {
+		t.Errorf("Unexpected header: %s %d", report.EventType(), report.EventTime())
+	}
+
+	position, ok := received[2].(*OutboundAccountPosition)
+	if !ok || len(position.Balances) != 1 || position.Balances[0].Free != "10000.000000" {
+		t.Errorf("Unexpected account position: %+v", received[2])
+	}
+
+	list, ok := received[3].(*ListStatus)
+	if !ok || list.ListStatusType != "EXEC_STARTED" || list.ListOrderStatus != "EXECUTING" || len(list.Orders) != 1 {
+		t.Errorf("Unexpected list status: %+v", received[3])
+	}
+
+	time.Sleep(120 * time.Millisecond)
+
+	if err := stream.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Error("Expected events channel to be closed")
+	}
+	if err := stream.Close(); err != nil {
+		t.Errorf("Second Close() error = %v", err)
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if service.created < 1 {
+		t.Errorf("Expected a listen key to be created, got %d", service.created)
+	}
+	if service.renewed < 1 {
+		t.Errorf("Expected the listen key to be renewed, got %d renewals", service.renewed)
+	}
+	if len(service.closed) != 1 || service.closed[0] != "test-listen-key" {
+		t.Errorf("Expected the listen key to be closed, got %v", service.closed)
+	}
+}
+
+func TestUserDataStreamListenKeyExpired(t *testing.T) {
+	server := newTestServer(t, func(conn *gorilla.Conn, r *http.Request) {
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"listenKeyExpired","E":1576653824250,"listenKey":"test-listen-key"}`))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+
+	service := &fakeListenKeyService{}
+	stream := NewUserDataStream(service)
+	stream.BaseURL = wsURL(server)
+	stream.Options.MinBackoff = 10 * time.Millisecond
+
+	if _, err := stream.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer stream.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		service.mu.Lock()
+		created := service.created
+		service.mu.Unlock()
+		if created >= 2 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expected a new listen key after listenKeyExpired")
+}